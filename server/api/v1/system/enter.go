@@ -26,30 +26,70 @@ type ApiGroup struct {
 	AlbumApi
 	DrawingApi
 	MustReadApi
+	BeadInventoryApi
+	ImageProxyApi
+	AlbumFieldApi
+	DrawingCompletionApi
+	AlbumMemberApi
+	AccessGrantApi
+	NotificationApi
+	AccessRequestApi
+	AlbumInviteApi
+	DrawingShareApi
+	AlbumSerialApi
+	RecycleBinApi
+	DrawingReviewApi
+	DownloadAnalyticsApi
+	DownloadAuditApi
+	DownloadQuotaApi
+	DrawingTrendApi
+	DrawingRelationApi
+	SearchApi
+	DrawingCollectionApi
 }
 
 var (
-	apiService              = service.ServiceGroupApp.SystemServiceGroup.ApiService
-	jwtService              = service.ServiceGroupApp.SystemServiceGroup.JwtService
-	menuService             = service.ServiceGroupApp.SystemServiceGroup.MenuService
-	userService             = service.ServiceGroupApp.SystemServiceGroup.UserService
-	initDBService           = service.ServiceGroupApp.SystemServiceGroup.InitDBService
-	casbinService           = service.ServiceGroupApp.SystemServiceGroup.CasbinService
-	baseMenuService         = service.ServiceGroupApp.SystemServiceGroup.BaseMenuService
-	authorityService        = service.ServiceGroupApp.SystemServiceGroup.AuthorityService
-	dictionaryService       = service.ServiceGroupApp.SystemServiceGroup.DictionaryService
-	authorityBtnService     = service.ServiceGroupApp.SystemServiceGroup.AuthorityBtnService
-	systemConfigService     = service.ServiceGroupApp.SystemServiceGroup.SystemConfigService
-	sysParamsService        = service.ServiceGroupApp.SystemServiceGroup.SysParamsService
-	operationRecordService  = service.ServiceGroupApp.SystemServiceGroup.OperationRecordService
-	dictionaryDetailService = service.ServiceGroupApp.SystemServiceGroup.DictionaryDetailService
-	autoCodeService         = service.ServiceGroupApp.SystemServiceGroup.AutoCodeService
-	autoCodePluginService   = service.ServiceGroupApp.SystemServiceGroup.AutoCodePlugin
-	autoCodePackageService  = service.ServiceGroupApp.SystemServiceGroup.AutoCodePackage
-	autoCodeHistoryService  = service.ServiceGroupApp.SystemServiceGroup.AutoCodeHistory
-	autoCodeTemplateService = service.ServiceGroupApp.SystemServiceGroup.AutoCodeTemplate
-	sysVersionService       = service.ServiceGroupApp.SystemServiceGroup.SysVersionService
-	albumService            = service.ServiceGroupApp.SystemServiceGroup.AlbumService
-	drawingService          = service.ServiceGroupApp.SystemServiceGroup.DrawingService
-	mustReadService         = service.ServiceGroupApp.SystemServiceGroup.MustReadService
+	apiService               = service.ServiceGroupApp.SystemServiceGroup.ApiService
+	jwtService               = service.ServiceGroupApp.SystemServiceGroup.JwtService
+	menuService              = service.ServiceGroupApp.SystemServiceGroup.MenuService
+	userService              = service.ServiceGroupApp.SystemServiceGroup.UserService
+	initDBService            = service.ServiceGroupApp.SystemServiceGroup.InitDBService
+	casbinService            = service.ServiceGroupApp.SystemServiceGroup.CasbinService
+	baseMenuService          = service.ServiceGroupApp.SystemServiceGroup.BaseMenuService
+	authorityService         = service.ServiceGroupApp.SystemServiceGroup.AuthorityService
+	dictionaryService        = service.ServiceGroupApp.SystemServiceGroup.DictionaryService
+	authorityBtnService      = service.ServiceGroupApp.SystemServiceGroup.AuthorityBtnService
+	systemConfigService      = service.ServiceGroupApp.SystemServiceGroup.SystemConfigService
+	sysParamsService         = service.ServiceGroupApp.SystemServiceGroup.SysParamsService
+	operationRecordService   = service.ServiceGroupApp.SystemServiceGroup.OperationRecordService
+	dictionaryDetailService  = service.ServiceGroupApp.SystemServiceGroup.DictionaryDetailService
+	autoCodeService          = service.ServiceGroupApp.SystemServiceGroup.AutoCodeService
+	autoCodePluginService    = service.ServiceGroupApp.SystemServiceGroup.AutoCodePlugin
+	autoCodePackageService   = service.ServiceGroupApp.SystemServiceGroup.AutoCodePackage
+	autoCodeHistoryService   = service.ServiceGroupApp.SystemServiceGroup.AutoCodeHistory
+	autoCodeTemplateService  = service.ServiceGroupApp.SystemServiceGroup.AutoCodeTemplate
+	sysVersionService        = service.ServiceGroupApp.SystemServiceGroup.SysVersionService
+	albumService             = service.ServiceGroupApp.SystemServiceGroup.AlbumService
+	drawingService           = service.ServiceGroupApp.SystemServiceGroup.DrawingService
+	mustReadService          = service.ServiceGroupApp.SystemServiceGroup.MustReadService
+	beadInventoryService     = service.ServiceGroupApp.SystemServiceGroup.BeadInventoryService
+	imageProxyService        = service.ServiceGroupApp.SystemServiceGroup.ImageProxyService
+	albumFieldService        = service.ServiceGroupApp.SystemServiceGroup.AlbumFieldService
+	drawingCompletionService = service.ServiceGroupApp.SystemServiceGroup.DrawingCompletionService
+	albumMemberService       = service.ServiceGroupApp.SystemServiceGroup.AlbumMemberService
+	accessGrantService       = service.ServiceGroupApp.SystemServiceGroup.AccessGrantService
+	notificationService      = service.ServiceGroupApp.SystemServiceGroup.NotificationService
+	accessRequestService     = service.ServiceGroupApp.SystemServiceGroup.AccessRequestService
+	albumInviteService       = service.ServiceGroupApp.SystemServiceGroup.AlbumInviteService
+	drawingShareService      = service.ServiceGroupApp.SystemServiceGroup.DrawingShareService
+	albumSerialService       = service.ServiceGroupApp.SystemServiceGroup.AlbumSerialService
+	recycleBinService        = service.ServiceGroupApp.SystemServiceGroup.RecycleBinService
+	drawingReviewService     = service.ServiceGroupApp.SystemServiceGroup.DrawingReviewService
+	downloadAnalyticsService = service.ServiceGroupApp.SystemServiceGroup.DownloadAnalyticsService
+	downloadAuditService     = service.ServiceGroupApp.SystemServiceGroup.DownloadAuditService
+	downloadQuotaService     = service.ServiceGroupApp.SystemServiceGroup.DownloadQuotaService
+	drawingTrendService      = service.ServiceGroupApp.SystemServiceGroup.DrawingTrendService
+	drawingRelationService   = service.ServiceGroupApp.SystemServiceGroup.DrawingRelationService
+	searchService            = service.ServiceGroupApp.SystemServiceGroup.SearchService
+	drawingCollectionService = service.ServiceGroupApp.SystemServiceGroup.DrawingCollectionService
 )
@@ -0,0 +1,109 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AccessGrantApi struct{}
+
+// AddDrawingGrants 批量授予图纸访问权限
+// @Tags AccessGrant
+// @Summary 为用户批量授予图纸访问权限，可设置生效与过期时间（图纸创建者或相册创建者/管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.AddDrawingGrants true "图纸、用户与授权时间"
+// @Success 200 {object} response.Response{data=int,msg=string} "授权成功"
+// @Router /drawing/grants/add [post]
+func (grantApi *AccessGrantApi) AddDrawingGrants(c *gin.Context) {
+	var req request.AddDrawingGrants
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	count, err := accessGrantService.AddDrawingGrants(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("图纸授权失败!", zap.Error(err))
+		response.FailWithMessage("图纸授权失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(count, "授权成功", c)
+}
+
+// RemoveDrawingGrants 批量撤销图纸授权
+// @Tags AccessGrant
+// @Summary 批量撤销图纸授权（图纸创建者或相册创建者/管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RemoveDrawingGrants true "图纸与用户"
+// @Success 200 {object} response.Response{data=int64,msg=string} "撤销成功"
+// @Router /drawing/grants/remove [delete]
+func (grantApi *AccessGrantApi) RemoveDrawingGrants(c *gin.Context) {
+	var req request.RemoveDrawingGrants
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	removed, err := accessGrantService.RemoveDrawingGrants(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("撤销图纸授权失败!", zap.Error(err))
+		response.FailWithMessage("撤销图纸授权失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(removed, "撤销成功", c)
+}
+
+// GetDrawingGrants 获取图纸授权列表
+// @Tags AccessGrant
+// @Summary 获取图纸的限时授权列表（图纸创建者或相册创建者/管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetDrawingGrants true "查询参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /drawing/grants/list [post]
+func (grantApi *AccessGrantApi) GetDrawingGrants(c *gin.Context) {
+	var req request.GetDrawingGrants
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := accessGrantService.GetDrawingGrants(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取图纸授权失败!", zap.Error(err))
+		response.FailWithMessage("获取图纸授权失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     systemRes.ToDrawingGrantResponses(list),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
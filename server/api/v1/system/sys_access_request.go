@@ -0,0 +1,139 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AccessRequestApi struct{}
+
+// CreateAccessRequest 提交访问申请
+// @Tags AccessRequest
+// @Summary 申请相册或图纸的访问权限，相册创建者与管理员会收到站内通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateAccessRequest true "申请对象与留言"
+// @Success 200 {object} response.Response{data=system.SysAccessRequest,msg=string} "提交成功"
+// @Router /accessRequest/create [post]
+func (requestApi *AccessRequestApi) CreateAccessRequest(c *gin.Context) {
+	var req request.CreateAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	accessRequest, err := accessRequestService.CreateRequest(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("提交访问申请失败!", zap.Error(err))
+		response.FailWithMessage("提交访问申请失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(accessRequest, "提交成功", c)
+}
+
+// DecideAccessRequests 处理访问申请
+// @Tags AccessRequest
+// @Summary 批量通过或拒绝访问申请，通过时自动授权（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DecideAccessRequests true "处理参数"
+// @Success 200 {object} response.Response{msg=string} "处理成功"
+// @Router /accessRequest/decide [put]
+func (requestApi *AccessRequestApi) DecideAccessRequests(c *gin.Context) {
+	var req request.DecideAccessRequests
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := accessRequestService.DecideRequests(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("处理访问申请失败!", zap.Error(err))
+		response.FailWithMessage("处理访问申请失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("处理成功", c)
+}
+
+// GetMyAccessRequests 获取我的访问申请
+// @Tags AccessRequest
+// @Summary 获取当前用户提交的访问申请
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetMyAccessRequests true "查询参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /accessRequest/my [post]
+func (requestApi *AccessRequestApi) GetMyAccessRequests(c *gin.Context) {
+	var req request.GetMyAccessRequests
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := accessRequestService.GetMyRequests(req, userUUID)
+	respondAccessRequests(c, list, total, req.Page, req.PageSize, err)
+}
+
+// GetAccessRequests 获取待处理的访问申请
+// @Tags AccessRequest
+// @Summary 获取当前用户管理的相册下的访问申请（相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetAccessRequests true "查询参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /accessRequest/list [post]
+func (requestApi *AccessRequestApi) GetAccessRequests(c *gin.Context) {
+	var req request.GetAccessRequests
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := accessRequestService.GetRequests(req, utils.GetUserID(c), userUUID)
+	respondAccessRequests(c, list, total, req.Page, req.PageSize, err)
+}
+
+// respondAccessRequests 返回访问申请分页结果
+func respondAccessRequests(c *gin.Context, list []system.SysAccessRequest, total int64, page, pageSize int, err error) {
+	if err != nil {
+		global.GVA_LOG.Error("获取访问申请失败!", zap.Error(err))
+		response.FailWithMessage("获取访问申请失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     systemRes.ToAccessRequestResponses(list),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, "获取成功", c)
+}
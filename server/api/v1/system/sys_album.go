@@ -102,6 +102,30 @@ func (albumApi *AlbumApi) UpdateAlbum(c *gin.Context) {
 	response.OkWithMessage("更新成功", c)
 }
 
+// ReorderAlbums 调整相册顺序
+// @Tags Album
+// @Summary 按给定的相册ID顺序设置排序位置，未包含的已排序相册顺延到其后
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ReorderAlbums true "按目标顺序排列的相册ID"
+// @Success 200 {object} response.Response{msg=string} "排序成功"
+// @Router /album/reorder [put]
+func (albumApi *AlbumApi) ReorderAlbums(c *gin.Context) {
+	var req request.ReorderAlbums
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	if err := albumService.ReorderAlbums(req); err != nil {
+		global.GVA_LOG.Error("调整相册顺序失败!", zap.Error(err))
+		response.FailWithMessage("调整相册顺序失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("排序成功", c)
+}
+
 // GetAlbum 根据ID获取相册
 // @Tags Album
 // @Summary 根据ID获取相册
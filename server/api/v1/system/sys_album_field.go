@@ -0,0 +1,69 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AlbumFieldApi struct{}
+
+// SaveAlbumFields 保存相册自定义字段
+// @Tags AlbumField
+// @Summary 保存相册自定义字段定义（整体替换，仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SaveAlbumFields true "相册自定义字段"
+// @Success 200 {object} response.Response{data=[]response.AlbumFieldResponse,msg=string} "保存成功"
+// @Router /album/fields/save [put]
+func (fieldApi *AlbumFieldApi) SaveAlbumFields(c *gin.Context) {
+	var req request.SaveAlbumFields
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	fields, err := albumFieldService.SaveAlbumFields(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("保存相册字段失败!", zap.Error(err))
+		response.FailWithMessage("保存相册字段失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.ToAlbumFieldResponses(fields), "保存成功", c)
+}
+
+// GetAlbumFields 获取相册自定义字段
+// @Tags AlbumField
+// @Summary 获取相册自定义字段定义
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetAlbumFields true "相册ID"
+// @Success 200 {object} response.Response{data=[]response.AlbumFieldResponse,msg=string} "获取成功"
+// @Router /album/fields/list [post]
+func (fieldApi *AlbumFieldApi) GetAlbumFields(c *gin.Context) {
+	var req request.GetAlbumFields
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	fields, err := albumFieldService.GetAlbumFields(req)
+	if err != nil {
+		global.GVA_LOG.Error("获取相册字段失败!", zap.Error(err))
+		response.FailWithMessage("获取相册字段失败", c)
+		return
+	}
+	response.OkWithData(systemRes.ToAlbumFieldResponses(fields), c)
+}
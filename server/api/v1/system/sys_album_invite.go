@@ -0,0 +1,173 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AlbumInviteApi struct{}
+
+// CreateAlbumInvite 创建相册邀请链接
+// @Tags AlbumInvite
+// @Summary 创建相册邀请链接，令牌明文仅在创建时返回一次（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateAlbumInvite true "相册ID、角色、使用次数、过期时间与邮箱域名限制"
+// @Success 200 {object} response.Response{data=systemRes.CreateAlbumInviteResponse,msg=string} "创建成功"
+// @Router /album/invites/create [post]
+func (inviteApi *AlbumInviteApi) CreateAlbumInvite(c *gin.Context) {
+	var req request.CreateAlbumInvite
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	res, err := albumInviteService.CreateInvite(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("创建邀请链接失败!", zap.Error(err))
+		response.FailWithMessage("创建邀请链接失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(res, "创建成功", c)
+}
+
+// GetAlbumInvites 获取相册邀请链接列表
+// @Tags AlbumInvite
+// @Summary 获取相册的邀请链接及使用情况（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetAlbumInvites true "相册ID与分页参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /album/invites/list [post]
+func (inviteApi *AlbumInviteApi) GetAlbumInvites(c *gin.Context) {
+	var req request.GetAlbumInvites
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := albumInviteService.GetInvites(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取邀请链接失败!", zap.Error(err))
+		response.FailWithMessage("获取邀请链接失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// RevokeAlbumInvite 撤销相册邀请链接
+// @Tags AlbumInvite
+// @Summary 撤销邀请链接，已加入的成员不受影响（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RevokeAlbumInvite true "邀请ID"
+// @Success 200 {object} response.Response{msg=string} "撤销成功"
+// @Router /album/invites/revoke [put]
+func (inviteApi *AlbumInviteApi) RevokeAlbumInvite(c *gin.Context) {
+	var req request.RevokeAlbumInvite
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := albumInviteService.RevokeInvite(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("撤销邀请链接失败!", zap.Error(err))
+		response.FailWithMessage("撤销邀请链接失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("撤销成功", c)
+}
+
+// GetInviteRedemptions 获取邀请链接兑换记录
+// @Tags AlbumInvite
+// @Summary 获取邀请链接的兑换记录（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetInviteRedemptions true "邀请ID与分页参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /album/invites/redemptions [post]
+func (inviteApi *AlbumInviteApi) GetInviteRedemptions(c *gin.Context) {
+	var req request.GetInviteRedemptions
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := albumInviteService.GetRedemptions(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取兑换记录失败!", zap.Error(err))
+		response.FailWithMessage("获取兑换记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     systemRes.ToInviteRedemptionResponses(list),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// RedeemAlbumInvite 兑换相册邀请链接
+// @Tags AlbumInvite
+// @Summary 使用邀请令牌加入相册
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RedeemAlbumInvite true "邀请令牌"
+// @Success 200 {object} response.Response{data=systemRes.RedeemAlbumInviteResponse,msg=string} "加入成功"
+// @Router /album/invites/redeem [post]
+func (inviteApi *AlbumInviteApi) RedeemAlbumInvite(c *gin.Context) {
+	var req request.RedeemAlbumInvite
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	res, err := albumInviteService.RedeemInvite(req, userUUID, c.ClientIP())
+	if err != nil {
+		global.GVA_LOG.Error("兑换邀请链接失败!", zap.Error(err))
+		response.FailWithMessage("兑换邀请链接失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(res, "加入成功", c)
+}
@@ -0,0 +1,139 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AlbumMemberApi struct{}
+
+// AddAlbumMembers 批量添加相册成员
+// @Tags AlbumMember
+// @Summary 批量添加相册成员，成员权限作用于相册下全部图纸（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.AddAlbumMembers true "成员列表与角色"
+// @Success 200 {object} response.Response{data=[]response.AlbumMemberResponse,msg=string} "添加成功"
+// @Router /album/members/add [post]
+func (memberApi *AlbumMemberApi) AddAlbumMembers(c *gin.Context) {
+	var req request.AddAlbumMembers
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	members, err := albumMemberService.AddMembers(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("添加相册成员失败!", zap.Error(err))
+		response.FailWithMessage("添加相册成员失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.ToAlbumMemberResponses(members), "添加成功", c)
+}
+
+// RemoveAlbumMembers 批量移除相册成员
+// @Tags AlbumMember
+// @Summary 批量移除相册成员（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RemoveAlbumMembers true "相册ID与用户ID列表"
+// @Success 200 {object} response.Response{data=int64,msg=string} "移除成功"
+// @Router /album/members/remove [delete]
+func (memberApi *AlbumMemberApi) RemoveAlbumMembers(c *gin.Context) {
+	var req request.RemoveAlbumMembers
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	removed, err := albumMemberService.RemoveMembers(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("移除相册成员失败!", zap.Error(err))
+		response.FailWithMessage("移除相册成员失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(removed, "移除成功", c)
+}
+
+// GetAlbumMembers 获取相册成员列表
+// @Tags AlbumMember
+// @Summary 获取相册成员列表（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetAlbumMembers true "查询参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /album/members/list [post]
+func (memberApi *AlbumMemberApi) GetAlbumMembers(c *gin.Context) {
+	var req request.GetAlbumMembers
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := albumMemberService.GetMembers(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取相册成员失败!", zap.Error(err))
+		response.FailWithMessage("获取相册成员失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     systemRes.ToAlbumMemberResponses(list),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// ExplainDrawingAccess 查询图纸访问权限来源
+// @Tags AlbumMember
+// @Summary 列出对图纸有访问权限的用户及权限来源（图纸创建者或相册创建者/管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ExplainDrawingAccess true "图纸ID"
+// @Success 200 {object} response.Response{data=[]response.DrawingAccessEntry,msg=string} "获取成功"
+// @Router /album/members/explain [post]
+func (memberApi *AlbumMemberApi) ExplainDrawingAccess(c *gin.Context) {
+	var req request.ExplainDrawingAccess
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	entries, err := albumMemberService.ExplainDrawingAccess(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取图纸访问来源失败!", zap.Error(err))
+		response.FailWithMessage("获取图纸访问来源失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithData(entries, c)
+}
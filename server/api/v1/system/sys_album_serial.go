@@ -0,0 +1,97 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AlbumSerialApi struct{}
+
+// SaveAlbumSerialScheme 保存相册编号规则
+// @Tags AlbumSerial
+// @Summary 设置相册图纸编号规则（前缀、日期、补零流水号），创建图纸未填写序号时自动分配（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SaveAlbumSerialScheme true "编号规则"
+// @Success 200 {object} response.Response{data=system.SysAlbumSerialScheme,msg=string} "保存成功"
+// @Router /album/serialScheme/save [put]
+func (serialApi *AlbumSerialApi) SaveAlbumSerialScheme(c *gin.Context) {
+	var req request.SaveAlbumSerialScheme
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	scheme, err := albumSerialService.SaveScheme(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("保存编号规则失败!", zap.Error(err))
+		response.FailWithMessage("保存编号规则失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(scheme, "保存成功", c)
+}
+
+// GetAlbumSerialScheme 获取相册编号规则
+// @Tags AlbumSerial
+// @Summary 获取相册编号规则及下一个将分配的序号
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetAlbumSerialScheme true "相册ID"
+// @Success 200 {object} response.Response{data=systemRes.AlbumSerialSchemeResponse,msg=string} "获取成功"
+// @Router /album/serialScheme/get [post]
+func (serialApi *AlbumSerialApi) GetAlbumSerialScheme(c *gin.Context) {
+	var req request.GetAlbumSerialScheme
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	res, err := albumSerialService.GetScheme(req)
+	if err != nil {
+		global.GVA_LOG.Error("获取编号规则失败!", zap.Error(err))
+		response.FailWithMessage("获取编号规则失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(res, "获取成功", c)
+}
+
+// DeleteAlbumSerialScheme 删除相册编号规则
+// @Tags AlbumSerial
+// @Summary 删除相册编号规则，之后创建图纸需手工填写序号（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetAlbumSerialScheme true "相册ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /album/serialScheme/delete [delete]
+func (serialApi *AlbumSerialApi) DeleteAlbumSerialScheme(c *gin.Context) {
+	var req request.GetAlbumSerialScheme
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := albumSerialService.DeleteScheme(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("删除编号规则失败!", zap.Error(err))
+		response.FailWithMessage("删除编号规则失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
@@ -0,0 +1,199 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BeadInventoryApi struct{}
+
+// CreateInventory 新增库存
+// @Tags BeadInventory
+// @Summary 新增库存（色号已存在时累加）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateBeadInventory true "新增库存"
+// @Success 200 {object} response.Response{data=system.SysBeadInventory,msg=string} "创建成功"
+// @Router /inventory/create [post]
+func (inventoryApi *BeadInventoryApi) CreateInventory(c *gin.Context) {
+	var req request.CreateBeadInventory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	inventory, err := beadInventoryService.CreateInventory(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("新增库存失败!", zap.Error(err))
+		response.FailWithMessage("新增库存失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithData(inventory, c)
+}
+
+// UpdateInventory 更新库存
+// @Tags BeadInventory
+// @Summary 更新库存数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateBeadInventory true "更新库存"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /inventory/update [put]
+func (inventoryApi *BeadInventoryApi) UpdateInventory(c *gin.Context) {
+	var req request.UpdateBeadInventory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := beadInventoryService.UpdateInventory(req, userUUID); err != nil {
+		global.GVA_LOG.Error("更新库存失败!", zap.Error(err))
+		response.FailWithMessage("更新库存失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteInventory 删除库存
+// @Tags BeadInventory
+// @Summary 删除库存
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DeleteBeadInventory true "删除库存"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /inventory/delete [delete]
+func (inventoryApi *BeadInventoryApi) DeleteInventory(c *gin.Context) {
+	var req request.DeleteBeadInventory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := beadInventoryService.DeleteInventory(req, userUUID); err != nil {
+		global.GVA_LOG.Error("删除库存失败!", zap.Error(err))
+		response.FailWithMessage("删除库存失败", c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetInventoryList 获取库存列表
+// @Tags BeadInventory
+// @Summary 获取当前用户库存列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetBeadInventoryList true "获取库存列表"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /inventory/list [post]
+func (inventoryApi *BeadInventoryApi) GetInventoryList(c *gin.Context) {
+	var req request.GetBeadInventoryList
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := beadInventoryService.GetInventoryList(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取库存列表失败!", zap.Error(err))
+		response.FailWithMessage("获取库存列表失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// ImportInventory 从CSV导入库存
+// @Tags BeadInventory
+// @Summary 从CSV导入库存（每行：色号,数量）
+// @Security ApiKeyAuth
+// @accept multipart/form-data
+// @Produce application/json
+// @Param file formData file true "CSV文件"
+// @Param replace formData bool false "是否覆盖原有库存"
+// @Success 200 {object} response.Response{data=response.ImportInventoryResult,msg=string} "导入成功"
+// @Router /inventory/import [post]
+func (inventoryApi *BeadInventoryApi) ImportInventory(c *gin.Context) {
+	_, header, err := c.Request.FormFile("file")
+	if err != nil {
+		global.GVA_LOG.Error("接收文件失败!", zap.Error(err))
+		response.FailWithMessage("接收文件失败", c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+	replace := c.DefaultPostForm("replace", "false") == "true"
+
+	result, err := beadInventoryService.ImportInventoryCSV(header, replace, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("导入库存失败!", zap.Error(err))
+		response.FailWithMessage("导入库存失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(result, "导入成功", c)
+}
+
+// MatchDrawings 库存匹配图纸
+// @Tags BeadInventory
+// @Summary 查询当前库存可完成的图纸及缺口
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.MatchDrawings true "库存匹配"
+// @Success 200 {object} response.Response{data=response.DrawingMatchListResponse,msg=string} "获取成功"
+// @Router /inventory/match [post]
+func (inventoryApi *BeadInventoryApi) MatchDrawings(c *gin.Context) {
+	var req request.MatchDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	result, err := beadInventoryService.MatchDrawings(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("库存匹配失败!", zap.Error(err))
+		response.FailWithMessage("库存匹配失败", c)
+		return
+	}
+	response.OkWithData(result, c)
+}
@@ -0,0 +1,103 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type DownloadAnalyticsApi struct{}
+
+// GetDownloadSeries 获取下载量时间序列
+// @Tags DownloadAnalytics
+// @Summary 按天、周或月统计下载量，可按图纸、用户或相册筛选（未配置为全站统计角色的用户需指定自己管理的相册）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetDownloadSeries true "日期范围、筛选条件与分桶粒度"
+// @Success 200 {object} response.Response{data=[]response.DownloadBucket,msg=string} "获取成功"
+// @Router /analytics/downloads/series [post]
+func (analyticsApi *DownloadAnalyticsApi) GetDownloadSeries(c *gin.Context) {
+	var req request.GetDownloadSeries
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	series, err := downloadAnalyticsService.GetSeries(req, utils.GetUserID(c), userUUID, utils.GetUserAuthorityId(c))
+	if err != nil {
+		global.GVA_LOG.Error("获取下载统计失败!", zap.Error(err))
+		response.FailWithMessage("获取下载统计失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(series, "获取成功", c)
+}
+
+// GetTopDrawings 获取下载量排行
+// @Tags DownloadAnalytics
+// @Summary 统计日期范围内下载量最高的图纸（未配置为全站统计角色的用户需指定自己管理的相册）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetTopDrawings true "日期范围、相册与数量"
+// @Success 200 {object} response.Response{data=[]response.TopDrawing,msg=string} "获取成功"
+// @Router /analytics/downloads/top [post]
+func (analyticsApi *DownloadAnalyticsApi) GetTopDrawings(c *gin.Context) {
+	var req request.GetTopDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	top, err := downloadAnalyticsService.GetTopDrawings(req, utils.GetUserID(c), userUUID, utils.GetUserAuthorityId(c))
+	if err != nil {
+		global.GVA_LOG.Error("获取下载排行失败!", zap.Error(err))
+		response.FailWithMessage("获取下载排行失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(top, "获取成功", c)
+}
+
+// GetDownloadSummary 获取下载概况
+// @Tags DownloadAnalytics
+// @Summary 统计日期范围内的下载次数、去重下载人数与带水印下载占比（未配置为全站统计角色的用户需指定自己管理的相册）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetDownloadSummary true "日期范围与相册"
+// @Success 200 {object} response.Response{data=response.DownloadSummary,msg=string} "获取成功"
+// @Router /analytics/downloads/summary [post]
+func (analyticsApi *DownloadAnalyticsApi) GetDownloadSummary(c *gin.Context) {
+	var req request.GetDownloadSummary
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	summary, err := downloadAnalyticsService.GetSummary(req, utils.GetUserID(c), userUUID, utils.GetUserAuthorityId(c))
+	if err != nil {
+		global.GVA_LOG.Error("获取下载概况失败!", zap.Error(err))
+		response.FailWithMessage("获取下载概况失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(summary, "获取成功", c)
+}
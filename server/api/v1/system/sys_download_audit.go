@@ -0,0 +1,41 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type DownloadAuditApi struct{}
+
+// SearchDownloadAudits 检索下载审计记录
+// @Tags DownloadAudit
+// @Summary 按用户、图纸、渠道、客户端IP、请求ID、水印文字或哈希、文件等条件检索下载记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SearchDownloadAudits true "检索条件与分页参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /downloadAudit/search [post]
+func (auditApi *DownloadAuditApi) SearchDownloadAudits(c *gin.Context) {
+	var req request.SearchDownloadAudits
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	list, total, err := downloadAuditService.SearchDownloadAudits(req)
+	if err != nil {
+		global.GVA_LOG.Error("检索下载记录失败!", zap.Error(err))
+		response.FailWithMessage("检索下载记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
@@ -0,0 +1,60 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type DownloadQuotaApi struct{}
+
+// GetDownloadQuotaUsage 查看用户下载额度用量
+// @Tags DownloadQuota
+// @Summary 查看用户在各额度规则当前周期的下载用量与重置时间
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DownloadQuotaUser true "用户UUID与相册ID"
+// @Success 200 {object} response.Response{data=[]response.DownloadQuotaUsage,msg=string} "获取成功"
+// @Router /downloadQuota/usage [post]
+func (quotaApi *DownloadQuotaApi) GetDownloadQuotaUsage(c *gin.Context) {
+	var req request.DownloadQuotaUser
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	list, err := downloadQuotaService.GetUsage(req.UserUUID, req.AlbumID)
+	if err != nil {
+		global.GVA_LOG.Error("获取下载额度用量失败!", zap.Error(err))
+		response.FailWithMessage("获取下载额度用量失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// ResetDownloadQuotaUsage 重置用户下载额度用量
+// @Tags DownloadQuota
+// @Summary 清空用户的下载额度计数，指定相册时只清空该相册的按相册计数
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DownloadQuotaUser true "用户UUID与相册ID"
+// @Success 200 {object} response.Response{msg=string} "重置成功"
+// @Router /downloadQuota/reset [post]
+func (quotaApi *DownloadQuotaApi) ResetDownloadQuotaUsage(c *gin.Context) {
+	var req request.DownloadQuotaUser
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	if err := downloadQuotaService.ResetUsage(req.UserUUID, req.AlbumID); err != nil {
+		global.GVA_LOG.Error("重置下载额度失败!", zap.Error(err))
+		response.FailWithMessage("重置下载额度失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("重置成功", c)
+}
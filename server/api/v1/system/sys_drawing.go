@@ -1,9 +1,11 @@
 package system
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
@@ -35,7 +37,13 @@ func (drawingApi *DrawingApi) CreateDrawing(c *gin.Context) {
 		return
 	}
 
-	drawing, err := drawingService.CreateDrawing(drawingReq)
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	drawing, duplicates, err := drawingService.CreateDrawing(drawingReq, utils.GetUserID(c), userUUID)
 	if err != nil {
 		global.GVA_LOG.Error("创建图纸失败!", zap.Error(err))
 		response.FailWithMessage("创建图纸失败："+err.Error(), c)
@@ -43,12 +51,52 @@ func (drawingApi *DrawingApi) CreateDrawing(c *gin.Context) {
 	}
 
 	drawingResponse := systemRes.ToDrawingResponse(drawing)
+	if len(duplicates) > 0 {
+		drawingResponse.Duplicates = duplicates
+		response.OkWithDetailed(drawingResponse, fmt.Sprintf("创建成功，发现%d张疑似重复图纸", len(duplicates)), c)
+		return
+	}
 	response.OkWithData(drawingResponse, c)
 }
 
+// SearchSimilarDrawings 以图搜图
+// @Tags Drawing
+// @Summary 上传图片查找相似图纸（仅返回当前用户可访问的图纸）
+// @Security ApiKeyAuth
+// @accept multipart/form-data
+// @Produce application/json
+// @Param file formData file true "查询图片"
+// @Param maxDistance formData int false "最大汉明距离，默认12"
+// @Param limit formData int false "返回数量，默认10"
+// @Success 200 {object} response.Response{data=[]response.SimilarDrawing,msg=string} "获取成功"
+// @Router /drawing/similar [post]
+func (drawingApi *DrawingApi) SearchSimilarDrawings(c *gin.Context) {
+	_, header, err := c.Request.FormFile("file")
+	if err != nil {
+		global.GVA_LOG.Error("接收文件失败!", zap.Error(err))
+		response.FailWithMessage("接收文件失败", c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+	maxDistance, _ := strconv.Atoi(c.DefaultPostForm("maxDistance", "0"))
+	limit, _ := strconv.Atoi(c.DefaultPostForm("limit", "0"))
+
+	list, err := drawingService.SearchSimilarDrawings(header, userUUID, maxDistance, limit)
+	if err != nil {
+		global.GVA_LOG.Error("以图搜图失败!", zap.Error(err))
+		response.FailWithMessage("以图搜图失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithData(list, c)
+}
+
 // UpdateDrawing 更新图纸
 // @Tags Drawing
-// @Summary 更新图纸
+// @Summary 更新图纸（不能更换相册，更换相册请使用 /drawing/move）
 // @Security ApiKeyAuth
 // @accept application/json
 // @Produce application/json
@@ -117,7 +165,7 @@ func (drawingApi *DrawingApi) GetDrawingByID(c *gin.Context) {
 		return
 	}
 
-	drawing, err := drawingService.GetDrawingByID(drawingReq)
+	drawing, err := drawingService.GetDrawingByID(drawingReq, utils.GetUserID(c), utils.GetUserUuid(c))
 	if err != nil {
 		global.GVA_LOG.Error("获取图纸失败!", zap.Error(err))
 		response.FailWithMessage("获取图纸失败", c)
@@ -145,7 +193,7 @@ func (drawingApi *DrawingApi) GetDrawingList(c *gin.Context) {
 		return
 	}
 
-	list, total, err := drawingService.GetDrawingList(pageInfo)
+	list, total, err := drawingService.GetDrawingList(pageInfo, utils.GetUserID(c), utils.GetUserUuid(c))
 	if err != nil {
 		global.GVA_LOG.Error("获取图纸列表失败!", zap.Error(err))
 		response.FailWithMessage("获取图纸列表失败", c)
@@ -156,6 +204,37 @@ func (drawingApi *DrawingApi) GetDrawingList(c *gin.Context) {
 	response.OkWithData(drawingListResponse, c)
 }
 
+// downloadClient 提取下载审计所需的客户端信息，请求未携带 X-Request-Id 时生成一个并回写到响应头
+func downloadClient(c *gin.Context) request.DownloadClient {
+	requestID := c.GetHeader("X-Request-Id")
+	if requestID == "" || len(requestID) > 64 {
+		requestID = uuid.New().String()
+	}
+	c.Header("X-Request-Id", requestID)
+	return request.DownloadClient{
+		ClientIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: requestID,
+	}
+}
+
+// downloadQuotaFailed 下载额度用完时返回重试信息并设置 Retry-After 响应头
+func downloadQuotaFailed(err error, c *gin.Context) bool {
+	var quotaErr *systemService.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+	retryAfter := quotaErr.RetryAfter()
+	c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	response.FailWithDetailed(systemRes.DownloadQuotaExceeded{
+		Rule:       quotaErr.Rule,
+		AlbumID:    quotaErr.AlbumID,
+		RetryAfter: retryAfter,
+		ResetAt:    quotaErr.ResetAt,
+	}, quotaErr.Error(), c)
+	return true
+}
+
 // DownloadDrawing 下载图纸
 // @Tags Drawing
 // @Summary 下载图纸
@@ -180,8 +259,11 @@ func (drawingApi *DrawingApi) DownloadDrawing(c *gin.Context) {
 		return
 	}
 
-	downloadResponse, err := drawingService.DownloadDrawing(downloadReq, userUUID)
+	downloadResponse, err := drawingService.DownloadDrawing(downloadReq, userUUID, downloadClient(c))
 	if err != nil {
+		if downloadQuotaFailed(err, c) {
+			return
+		}
 		global.GVA_LOG.Error("下载图纸失败!", zap.Error(err))
 		response.FailWithMessage("下载图纸失败", c)
 		return
@@ -214,8 +296,11 @@ func (drawingApi *DrawingApi) BatchDownloadDrawings(c *gin.Context) {
 		return
 	}
 
-	downloadResponse, err := drawingService.BatchDownloadDrawings(batchDownloadReq, userUUID)
+	downloadResponse, err := drawingService.BatchDownloadDrawings(batchDownloadReq, userUUID, downloadClient(c))
 	if err != nil {
+		if downloadQuotaFailed(err, c) {
+			return
+		}
 		global.GVA_LOG.Error("批量下载图纸失败!", zap.Error(err))
 		response.FailWithMessage("批量下载图纸失败", c)
 		return
@@ -244,9 +329,9 @@ func (drawingApi *DrawingApi) RecordDownload(c *gin.Context) {
 		response.FailWithMessage("用户身份验证失败", c)
 		return
 	}
-	if err := drawingService.RecordDownload(recordReq, userUUID); err != nil {
+	if err := drawingService.RecordDownload(recordReq, userUUID, downloadClient(c)); err != nil {
 		global.GVA_LOG.Error("记录下载失败!", zap.Error(err))
-		response.FailWithMessage("记录下载失败", c)
+		response.FailWithMessage("记录下载失败："+err.Error(), c)
 		return
 	}
 	response.OkWithMessage("记录成功", c)
@@ -361,9 +446,9 @@ func (drawingApi *DrawingApi) GetWatermarkFile(c *gin.Context) {
 	c.File(filePath)
 }
 
-// GetMyDrawings 获取当前用户可下载的图纸列表
+// GetMyDrawings 获取当前用户可查看的图纸列表
 // @Tags Drawing
-// @Summary 获取当前用户可下载的图纸列表
+// @Summary 获取当前用户可查看的图纸列表（含相册查看者成员）
 // @Security ApiKeyAuth
 // @accept application/json
 // @Produce application/json
@@ -519,3 +604,92 @@ func (drawingApi *DrawingApi) GetDrawingFile(c *gin.Context) {
 	// 返回文件
 	c.File(filePath)
 }
+
+// MoveDrawings 移动图纸到其他相册
+// @Tags Drawing
+// @Summary 批量移动图纸到目标相册，可选择序号冲突处理策略（fail/renumber/suffix）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.TransferDrawings true "图纸ID列表、目标相册与冲突策略"
+// @Success 200 {object} response.Response{data=[]response.TransferredDrawing,msg=string} "移动成功"
+// @Router /drawing/move [post]
+func (drawingApi *DrawingApi) MoveDrawings(c *gin.Context) {
+	var req request.TransferDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	result, err := drawingService.MoveDrawings(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("移动图纸失败!", zap.Error(err))
+		response.FailWithMessage("移动图纸失败："+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(result, "移动成功", c)
+}
+
+// CopyDrawings 复制图纸到其他相册
+// @Tags Drawing
+// @Summary 批量复制图纸到目标相册，可选择序号冲突处理策略（fail/renumber/suffix）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.TransferDrawings true "图纸ID列表、目标相册与冲突策略"
+// @Success 200 {object} response.Response{data=[]response.TransferredDrawing,msg=string} "复制成功"
+// @Router /drawing/copy [post]
+func (drawingApi *DrawingApi) CopyDrawings(c *gin.Context) {
+	var req request.TransferDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	result, err := drawingService.CopyDrawings(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("复制图纸失败!", zap.Error(err))
+		response.FailWithMessage("复制图纸失败："+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(result, "复制成功", c)
+}
+
+// ReorderDrawings 调整相册内图纸顺序
+// @Tags Drawing
+// @Summary 按给定的图纸ID顺序设置相册内排序位置，未包含的已排序图纸顺延到其后（相册创建者、管理员或编辑）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ReorderDrawings true "相册ID与按目标顺序排列的图纸ID"
+// @Success 200 {object} response.Response{msg=string} "排序成功"
+// @Router /drawing/reorder [put]
+func (drawingApi *DrawingApi) ReorderDrawings(c *gin.Context) {
+	var req request.ReorderDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingService.ReorderDrawings(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("调整图纸顺序失败!", zap.Error(err))
+		response.FailWithMessage("调整图纸顺序失败："+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("排序成功", c)
+}
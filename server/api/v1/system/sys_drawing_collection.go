@@ -0,0 +1,370 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type DrawingCollectionApi struct{}
+
+// CreateCollection 创建收藏夹
+// @Tags DrawingCollection
+// @Summary 创建收藏夹
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateDrawingCollection true "名称与描述"
+// @Success 200 {object} response.Response{data=system.SysDrawingCollection,msg=string} "创建成功"
+// @Router /collection/create [post]
+func (collectionApi *DrawingCollectionApi) CreateCollection(c *gin.Context) {
+	var req request.CreateDrawingCollection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	collection, err := drawingCollectionService.CreateCollection(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("创建收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("创建收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(collection, "创建成功", c)
+}
+
+// UpdateCollection 更新收藏夹
+// @Tags DrawingCollection
+// @Summary 更新收藏夹名称与描述（仅所有者）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateDrawingCollection true "收藏夹ID、名称与描述"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /collection/update [put]
+func (collectionApi *DrawingCollectionApi) UpdateCollection(c *gin.Context) {
+	var req request.UpdateDrawingCollection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingCollectionService.UpdateCollection(req, userUUID); err != nil {
+		global.GVA_LOG.Error("更新收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("更新收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteCollection 删除收藏夹
+// @Tags DrawingCollection
+// @Summary 删除收藏夹（仅所有者，默认收藏夹不能删除）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DeleteDrawingCollection true "收藏夹ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /collection/delete [delete]
+func (collectionApi *DrawingCollectionApi) DeleteCollection(c *gin.Context) {
+	var req request.DeleteDrawingCollection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingCollectionService.DeleteCollection(req, userUUID); err != nil {
+		global.GVA_LOG.Error("删除收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("删除收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetCollections 获取收藏夹列表
+// @Tags DrawingCollection
+// @Summary 获取当前用户的收藏夹与他人共享给自己的收藏夹
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]response.DrawingCollectionResponse,msg=string} "获取成功"
+// @Router /collection/list [post]
+func (collectionApi *DrawingCollectionApi) GetCollections(c *gin.Context) {
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, err := drawingCollectionService.GetCollections(userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取收藏夹列表失败!", zap.Error(err))
+		response.FailWithMessage("获取收藏夹列表失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// GetCollectionDrawings 获取收藏夹中的图纸
+// @Tags DrawingCollection
+// @Summary 分页获取收藏夹中当前用户可访问的图纸，访问权限已被撤销的图纸不显示
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetCollectionDrawings true "收藏夹ID与分页"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /collection/drawings [post]
+func (collectionApi *DrawingCollectionApi) GetCollectionDrawings(c *gin.Context) {
+	var req request.GetCollectionDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := drawingCollectionService.GetCollectionDrawings(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取收藏夹图纸失败!", zap.Error(err))
+		response.FailWithMessage("获取收藏夹图纸失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// AddCollectionDrawings 将图纸加入收藏夹
+// @Tags DrawingCollection
+// @Summary 将图纸加入收藏夹（仅所有者），只能加入自己可访问的图纸
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CollectionDrawings true "收藏夹ID与图纸ID列表"
+// @Success 200 {object} response.Response{data=int,msg=string} "添加成功"
+// @Router /collection/drawings/add [post]
+func (collectionApi *DrawingCollectionApi) AddCollectionDrawings(c *gin.Context) {
+	var req request.CollectionDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	added, err := drawingCollectionService.AddDrawings(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("加入收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("加入收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(added, "添加成功", c)
+}
+
+// RemoveCollectionDrawings 将图纸移出收藏夹
+// @Tags DrawingCollection
+// @Summary 将图纸移出收藏夹（仅所有者）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CollectionDrawings true "收藏夹ID与图纸ID列表"
+// @Success 200 {object} response.Response{data=int64,msg=string} "移除成功"
+// @Router /collection/drawings/remove [delete]
+func (collectionApi *DrawingCollectionApi) RemoveCollectionDrawings(c *gin.Context) {
+	var req request.CollectionDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	removed, err := drawingCollectionService.RemoveDrawings(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("移出收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("移出收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(removed, "移除成功", c)
+}
+
+// ReorderCollectionDrawings 调整收藏夹中图纸的顺序
+// @Tags DrawingCollection
+// @Summary 按给定顺序排列收藏夹中的图纸，未包含的图纸保持原相对顺序排在其后（仅所有者）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CollectionDrawings true "收藏夹ID与按目标顺序排列的图纸ID"
+// @Success 200 {object} response.Response{msg=string} "排序成功"
+// @Router /collection/drawings/reorder [put]
+func (collectionApi *DrawingCollectionApi) ReorderCollectionDrawings(c *gin.Context) {
+	var req request.CollectionDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingCollectionService.ReorderDrawings(req, userUUID); err != nil {
+		global.GVA_LOG.Error("调整收藏夹顺序失败!", zap.Error(err))
+		response.FailWithMessage("调整收藏夹顺序失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("排序成功", c)
+}
+
+// ShareCollection 共享收藏夹
+// @Tags DrawingCollection
+// @Summary 将收藏夹只读共享给其他用户（仅所有者），被共享用户只能看到自己有权访问的图纸
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ShareDrawingCollection true "收藏夹ID与用户ID列表"
+// @Success 200 {object} response.Response{data=int,msg=string} "共享成功"
+// @Router /collection/share [post]
+func (collectionApi *DrawingCollectionApi) ShareCollection(c *gin.Context) {
+	var req request.ShareDrawingCollection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	shared, err := drawingCollectionService.ShareCollection(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("共享收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("共享收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(shared, "共享成功", c)
+}
+
+// UnshareCollection 取消共享收藏夹
+// @Tags DrawingCollection
+// @Summary 取消收藏夹对指定用户的共享（仅所有者）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ShareDrawingCollection true "收藏夹ID与用户ID列表"
+// @Success 200 {object} response.Response{data=int64,msg=string} "取消成功"
+// @Router /collection/unshare [delete]
+func (collectionApi *DrawingCollectionApi) UnshareCollection(c *gin.Context) {
+	var req request.ShareDrawingCollection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	removed, err := drawingCollectionService.UnshareCollection(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("取消共享收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("取消共享收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(removed, "取消成功", c)
+}
+
+// SetDrawingFavorite 收藏或取消收藏图纸
+// @Tags DrawingCollection
+// @Summary 将图纸加入或移出默认收藏夹，默认收藏夹不存在时自动创建
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SetDrawingFavorite true "图纸ID与是否收藏"
+// @Success 200 {object} response.Response{msg=string} "操作成功"
+// @Router /collection/favorite [post]
+func (collectionApi *DrawingCollectionApi) SetDrawingFavorite(c *gin.Context) {
+	var req request.SetDrawingFavorite
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingCollectionService.SetFavorite(req, userUUID); err != nil {
+		global.GVA_LOG.Error("收藏图纸失败!", zap.Error(err))
+		response.FailWithMessage("收藏图纸失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("操作成功", c)
+}
+
+// DownloadCollection 批量下载收藏夹
+// @Tags DrawingCollection
+// @Summary 通过批量下载流程下载收藏夹中当前用户可访问的全部图纸，计入下载额度与下载历史
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DownloadDrawingCollection true "收藏夹ID与水印设置"
+// @Success 200 {object} response.Response{data=response.DownloadResponse,msg=string} "下载成功"
+// @Router /collection/download [post]
+func (collectionApi *DrawingCollectionApi) DownloadCollection(c *gin.Context) {
+	var req request.DownloadDrawingCollection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	downloadResponse, err := drawingCollectionService.DownloadCollection(req, userUUID, downloadClient(c))
+	if err != nil {
+		if downloadQuotaFailed(err, c) {
+			return
+		}
+		global.GVA_LOG.Error("下载收藏夹失败!", zap.Error(err))
+		response.FailWithMessage("下载收藏夹失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithData(downloadResponse, c)
+}
@@ -0,0 +1,212 @@
+package system
+
+import (
+	"strconv"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type DrawingCompletionApi struct{}
+
+// CreateCompletion 提交完成记录
+// @Tags DrawingCompletion
+// @Summary 标记图纸已完成并上传成品照片
+// @Security ApiKeyAuth
+// @accept multipart/form-data
+// @Produce application/json
+// @Param file formData file true "成品照片（jpg/png，不超过10MB）"
+// @Param drawingId formData int true "图纸ID"
+// @Param note formData string false "备注"
+// @Param completedAt formData string false "完成日期 YYYY-MM-DD，默认当天"
+// @Success 200 {object} response.Response{data=response.CompletionResponse,msg=string} "提交成功"
+// @Router /completion/create [post]
+func (completionApi *DrawingCompletionApi) CreateCompletion(c *gin.Context) {
+	_, header, err := c.Request.FormFile("file")
+	if err != nil {
+		global.GVA_LOG.Error("接收文件失败!", zap.Error(err))
+		response.FailWithMessage("接收文件失败", c)
+		return
+	}
+	drawingID, err := strconv.ParseUint(c.PostForm("drawingId"), 10, 64)
+	if err != nil || drawingID == 0 {
+		response.FailWithMessage("图纸ID格式错误", c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	completion, err := drawingCompletionService.CreateCompletion(header, uint(drawingID), c.PostForm("note"), c.PostForm("completedAt"), utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("提交完成记录失败!", zap.Error(err))
+		response.FailWithMessage("提交完成记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.ToCompletionResponse(completion), "提交成功", c)
+}
+
+// UpdateCompletion 修改完成记录
+// @Tags DrawingCompletion
+// @Summary 修改自己的完成记录备注与完成日期
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.UpdateCompletion true "完成记录"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /completion/update [put]
+func (completionApi *DrawingCompletionApi) UpdateCompletion(c *gin.Context) {
+	var req request.UpdateCompletion
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingCompletionService.UpdateCompletion(req, userUUID); err != nil {
+		global.GVA_LOG.Error("更新完成记录失败!", zap.Error(err))
+		response.FailWithMessage("更新完成记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteCompletion 删除完成记录
+// @Tags DrawingCompletion
+// @Summary 删除完成记录（本人或相册创建者/管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DeleteCompletion true "完成记录ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /completion/delete [delete]
+func (completionApi *DrawingCompletionApi) DeleteCompletion(c *gin.Context) {
+	var req request.DeleteCompletion
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingCompletionService.DeleteCompletion(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("删除完成记录失败!", zap.Error(err))
+		response.FailWithMessage("删除完成记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// ModerateCompletions 审核完成记录
+// @Tags DrawingCompletion
+// @Summary 批量设置完成记录审核状态（仅相册创建者或管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ModerateCompletion true "审核参数"
+// @Success 200 {object} response.Response{msg=string} "审核成功"
+// @Router /completion/moderate [put]
+func (completionApi *DrawingCompletionApi) ModerateCompletions(c *gin.Context) {
+	var req request.ModerateCompletion
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingCompletionService.ModerateCompletions(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("审核完成记录失败!", zap.Error(err))
+		response.FailWithMessage("审核完成记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("审核成功", c)
+}
+
+// GetCompletionGallery 获取图纸成品墙
+// @Tags DrawingCompletion
+// @Summary 获取图纸的完成记录列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetCompletionGallery true "查询参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /completion/gallery [post]
+func (completionApi *DrawingCompletionApi) GetCompletionGallery(c *gin.Context) {
+	var req request.GetCompletionGallery
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := drawingCompletionService.GetCompletionGallery(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取成品墙失败!", zap.Error(err))
+		response.FailWithMessage("获取成品墙失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     systemRes.ToCompletionResponses(list),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// GetMyCompletions 获取我的完成记录
+// @Tags DrawingCompletion
+// @Summary 获取当前用户的完成记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetMyCompletions true "分页参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /completion/my [post]
+func (completionApi *DrawingCompletionApi) GetMyCompletions(c *gin.Context) {
+	var req request.GetMyCompletions
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := drawingCompletionService.GetMyCompletions(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取完成记录失败!", zap.Error(err))
+		response.FailWithMessage("获取完成记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     systemRes.ToCompletionResponses(list),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
@@ -0,0 +1,43 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type DrawingRelationApi struct{}
+
+// GetRelatedDrawings 获取关联推荐图纸
+// @Tags DrawingRelation
+// @Summary 获取下载过该图纸的成员也下载了的图纸，只返回当前用户可访问的图纸
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetRelatedDrawings true "图纸ID与返回数量"
+// @Success 200 {object} response.Response{data=[]response.RelatedDrawing,msg=string} "获取成功"
+// @Router /drawing/related [post]
+func (relationApi *DrawingRelationApi) GetRelatedDrawings(c *gin.Context) {
+	var req request.GetRelatedDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, err := drawingRelationService.GetRelatedDrawings(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取关联推荐图纸失败!", zap.Error(err))
+		response.FailWithMessage("获取关联推荐图纸失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
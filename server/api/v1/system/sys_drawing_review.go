@@ -0,0 +1,161 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type DrawingReviewApi struct{}
+
+// SubmitDrawings 提交图纸审核
+// @Tags DrawingReview
+// @Summary 将草稿状态的图纸提交审核（图纸创建者或相册审核人）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SubmitDrawings true "图纸ID列表与提交说明"
+// @Success 200 {object} response.Response{msg=string} "提交成功"
+// @Router /drawing/review/submit [put]
+func (reviewApi *DrawingReviewApi) SubmitDrawings(c *gin.Context) {
+	var req request.SubmitDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingReviewService.SubmitDrawings(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("提交审核失败!", zap.Error(err))
+		response.FailWithMessage("提交审核失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("提交成功", c)
+}
+
+// ReviewDrawings 审核图纸
+// @Tags DrawingReview
+// @Summary 通过或驳回待审核的图纸，通过时可指定定时发布时间，驳回需填写审核意见（相册创建者、管理员或编辑）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ReviewDrawings true "图纸ID列表、审核结果与意见"
+// @Success 200 {object} response.Response{msg=string} "审核成功"
+// @Router /drawing/review/decide [put]
+func (reviewApi *DrawingReviewApi) ReviewDrawings(c *gin.Context) {
+	var req request.ReviewDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingReviewService.ReviewDrawings(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("审核图纸失败!", zap.Error(err))
+		response.FailWithMessage("审核图纸失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("审核成功", c)
+}
+
+// ArchiveDrawings 归档或取消归档图纸
+// @Tags DrawingReview
+// @Summary 归档已发布的图纸使成员不可见，或将已归档的图纸重新发布（相册创建者、管理员或编辑）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.ArchiveDrawings true "图纸ID列表与操作"
+// @Success 200 {object} response.Response{msg=string} "操作成功"
+// @Router /drawing/review/archive [put]
+func (reviewApi *DrawingReviewApi) ArchiveDrawings(c *gin.Context) {
+	var req request.ArchiveDrawings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingReviewService.ArchiveDrawings(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("归档图纸失败!", zap.Error(err))
+		response.FailWithMessage("操作失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("操作成功", c)
+}
+
+// GetReviewQueue 获取待审核图纸
+// @Tags DrawingReview
+// @Summary 获取相册中待审核（或指定状态）的图纸（相册创建者、管理员或编辑）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetDrawingReviewQueue true "相册ID、状态与分页参数"
+// @Success 200 {object} response.Response{data=response.DrawingListResponse,msg=string} "获取成功"
+// @Router /drawing/review/queue [post]
+func (reviewApi *DrawingReviewApi) GetReviewQueue(c *gin.Context) {
+	var req request.GetDrawingReviewQueue
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := drawingReviewService.GetReviewQueue(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取待审核图纸失败!", zap.Error(err))
+		response.FailWithMessage("获取待审核图纸失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.ToDrawingListResponse(list, total), "获取成功", c)
+}
+
+// GetDrawingReviews 获取图纸审核记录
+// @Tags DrawingReview
+// @Summary 获取图纸的提交、审核、发布与归档记录（图纸创建者或相册审核人）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetDrawingReviews true "图纸ID"
+// @Success 200 {object} response.Response{data=[]system.SysDrawingReview,msg=string} "获取成功"
+// @Router /drawing/review/history [post]
+func (reviewApi *DrawingReviewApi) GetDrawingReviews(c *gin.Context) {
+	var req request.GetDrawingReviews
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	reviews, err := drawingReviewService.GetReviews(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取审核记录失败!", zap.Error(err))
+		response.FailWithMessage("获取审核记录失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(reviews, "获取成功", c)
+}
@@ -0,0 +1,138 @@
+package system
+
+import (
+	"errors"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type DrawingShareApi struct{}
+
+// CreateDrawingShare 创建图纸分享链接
+// @Tags DrawingShare
+// @Summary 创建图纸公开分享链接，可设置访问密码与过期时间（图纸创建者或相册创建者/管理员）
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CreateDrawingShare true "图纸ID、访问密码与过期时间"
+// @Success 200 {object} response.Response{data=systemRes.DrawingShareResponse,msg=string} "创建成功"
+// @Router /drawing/shares/create [post]
+func (shareApi *DrawingShareApi) CreateDrawingShare(c *gin.Context) {
+	var req request.CreateDrawingShare
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	share, err := drawingShareService.CreateShare(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("创建分享链接失败!", zap.Error(err))
+		response.FailWithMessage("创建分享链接失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.ToDrawingShareResponse(share), "创建成功", c)
+}
+
+// DeleteDrawingShares 删除图纸分享链接
+// @Tags DrawingShare
+// @Summary 批量删除图纸分享链接，删除后链接立即失效
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.DeleteDrawingShares true "分享ID列表"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /drawing/shares/delete [delete]
+func (shareApi *DrawingShareApi) DeleteDrawingShares(c *gin.Context) {
+	var req request.DeleteDrawingShares
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := drawingShareService.DeleteShares(req, utils.GetUserID(c), userUUID); err != nil {
+		global.GVA_LOG.Error("删除分享链接失败!", zap.Error(err))
+		response.FailWithMessage("删除分享链接失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetDrawingShares 获取图纸分享链接列表
+// @Tags DrawingShare
+// @Summary 获取图纸的分享链接及查看次数
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetDrawingShares true "图纸ID"
+// @Success 200 {object} response.Response{data=[]systemRes.DrawingShareResponse,msg=string} "获取成功"
+// @Router /drawing/shares/list [post]
+func (shareApi *DrawingShareApi) GetDrawingShares(c *gin.Context) {
+	var req request.GetDrawingShares
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, err := drawingShareService.GetShares(req, utils.GetUserID(c), userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取分享链接失败!", zap.Error(err))
+		response.FailWithMessage("获取分享链接失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.ToDrawingShareResponses(list), "获取成功", c)
+}
+
+// ViewDrawingShare 查看图纸公开分享页
+// @Tags DrawingShare
+// @Summary 免登录查看分享的图纸海报信息，设置了密码的分享需通过POST提交密码
+// @accept application/json
+// @Produce application/json
+// @Param token path string true "分享令牌"
+// @Param data body request.ViewDrawingShare false "访问密码"
+// @Success 200 {object} response.Response{data=systemRes.DrawingShareView,msg=string} "获取成功"
+// @Router /share/drawing/{token} [get]
+// @Router /share/drawing/{token} [post]
+func (shareApi *DrawingShareApi) ViewDrawingShare(c *gin.Context) {
+	var req request.ViewDrawingShare
+	if c.Request.Method == "POST" {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.FailWithMessage(err.Error(), c)
+			return
+		}
+	}
+
+	share, err := drawingShareService.ViewShare(c.Param("token"), req.Password)
+	if err != nil {
+		if errors.Is(err, systemService.ErrSharePasswordRequired) {
+			response.FailWithDetailed(gin.H{"needPassword": true}, err.Error(), c)
+			return
+		}
+		global.GVA_LOG.Warn("查看分享链接失败!", zap.Error(err))
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.ToDrawingShareView(share), "获取成功", c)
+}
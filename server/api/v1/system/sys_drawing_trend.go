@@ -0,0 +1,43 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type DrawingTrendApi struct{}
+
+// GetDrawingFeed 获取个性化图纸推荐流
+// @Tags DrawingTrend
+// @Summary 获取当前用户可访问相册中的热门与新发布图纸，排除自己创建和已下载过的图纸
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetDrawingFeed true "热门与新发布数量、新发布天数范围"
+// @Success 200 {object} response.Response{data=response.DrawingFeed,msg=string} "获取成功"
+// @Router /drawing/feed [post]
+func (trendApi *DrawingTrendApi) GetDrawingFeed(c *gin.Context) {
+	var req request.GetDrawingFeed
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	feed, err := drawingTrendService.GetFeed(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取推荐图纸失败!", zap.Error(err))
+		response.FailWithMessage("获取推荐图纸失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(feed, "获取成功", c)
+}
@@ -0,0 +1,57 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ImageProxyApi struct{}
+
+// defaultImageMaxAge 未配置时的浏览器缓存时间（30天）
+const defaultImageMaxAge = 30 * 24 * 3600
+
+// GetImage 图片实时缩放
+// @Tags ImageProxy
+// @Summary 按白名单尺寸实时缩放存储中的图片
+// @Produce image/jpeg,image/png
+// @Param key path string true "存储key"
+// @Param w query int false "宽度"
+// @Param h query int false "高度"
+// @Param fit query string false "缩放方式 contain/cover/fill"
+// @Param q query int false "JPEG质量"
+// @Success 200 {file} file "缩放后的图片"
+// @Router /img/{key} [get]
+func (proxyApi *ImageProxyApi) GetImage(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	result, err := imageProxyService.GetResizedImage(key, c.Query("w"), c.Query("h"), c.Query("fit"), c.Query("q"))
+	if err != nil {
+		if errors.Is(err, systemService.ErrImageNotFound) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		global.GVA_LOG.Warn("图片缩放失败!", zap.String("key", key), zap.Error(err))
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	maxAge := global.GVA_CONFIG.ImageProxy.MaxAge
+	if maxAge <= 0 {
+		maxAge = defaultImageMaxAge
+	}
+	etag := `"` + result.ETag + `"`
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", maxAge))
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, result.ContentType, result.Data)
+}
@@ -0,0 +1,100 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type NotificationApi struct{}
+
+// GetNotifications 获取站内通知
+// @Tags Notification
+// @Summary 获取当前用户的站内通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetNotifications true "查询参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /notification/list [post]
+func (notificationApi *NotificationApi) GetNotifications(c *gin.Context) {
+	var req request.GetNotifications
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	list, total, err := notificationService.GetNotifications(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取站内通知失败!", zap.Error(err))
+		response.FailWithMessage("获取站内通知失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// MarkNotificationsRead 标记通知已读
+// @Tags Notification
+// @Summary 标记通知已读，不传ID时全部标记已读
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.MarkNotificationsRead true "通知ID列表"
+// @Success 200 {object} response.Response{msg=string} "操作成功"
+// @Router /notification/read [put]
+func (notificationApi *NotificationApi) MarkNotificationsRead(c *gin.Context) {
+	var req request.MarkNotificationsRead
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	if err := notificationService.MarkRead(req, userUUID); err != nil {
+		global.GVA_LOG.Error("标记通知已读失败!", zap.Error(err))
+		response.FailWithMessage("标记通知已读失败", c)
+		return
+	}
+	response.OkWithMessage("操作成功", c)
+}
+
+// GetUnreadNotificationCount 获取未读通知数量
+// @Tags Notification
+// @Summary 获取当前用户的未读通知数量
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=int64,msg=string} "获取成功"
+// @Router /notification/unreadCount [get]
+func (notificationApi *NotificationApi) GetUnreadNotificationCount(c *gin.Context) {
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	count, err := notificationService.GetUnreadCount(userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("获取未读通知数量失败!", zap.Error(err))
+		response.FailWithMessage("获取未读通知数量失败", c)
+		return
+	}
+	response.OkWithData(count, c)
+}
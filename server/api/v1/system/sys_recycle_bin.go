@@ -0,0 +1,89 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RecycleBinApi struct{}
+
+// GetRecycleBinItems 获取回收站条目
+// @Tags RecycleBin
+// @Summary 获取回收站中已删除的相册或单独删除的图纸，相册条目包含随其删除的图纸数
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetRecycleBin true "条目类型、关键字与分页参数"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /recycleBin/list [post]
+func (recycleApi *RecycleBinApi) GetRecycleBinItems(c *gin.Context) {
+	var req request.GetRecycleBin
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	list, total, err := recycleBinService.GetItems(req)
+	if err != nil {
+		global.GVA_LOG.Error("获取回收站失败!", zap.Error(err))
+		response.FailWithMessage("获取回收站失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// RestoreRecycleBinItems 恢复回收站条目
+// @Tags RecycleBin
+// @Summary 恢复相册（连同随其删除的图纸，成员与授权保持不变）或单独删除的图纸
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RecycleBinItems true "条目类型与ID列表"
+// @Success 200 {object} response.Response{msg=string} "恢复成功"
+// @Router /recycleBin/restore [put]
+func (recycleApi *RecycleBinApi) RestoreRecycleBinItems(c *gin.Context) {
+	var req request.RecycleBinItems
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	if err := recycleBinService.RestoreItems(req); err != nil {
+		global.GVA_LOG.Error("恢复失败!", zap.Error(err))
+		response.FailWithMessage("恢复失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("恢复成功", c)
+}
+
+// PurgeRecycleBinItems 彻底删除回收站条目
+// @Tags RecycleBin
+// @Summary 立即彻底删除回收站中的相册或图纸及其关联数据与存储文件，不可恢复
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.RecycleBinItems true "条目类型与ID列表"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /recycleBin/purge [delete]
+func (recycleApi *RecycleBinApi) PurgeRecycleBinItems(c *gin.Context) {
+	var req request.RecycleBinItems
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+
+	if err := recycleBinService.PurgeItems(req); err != nil {
+		global.GVA_LOG.Error("彻底删除失败!", zap.Error(err))
+		response.FailWithMessage("彻底删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
@@ -0,0 +1,60 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SearchApi struct{}
+
+// Search 全文检索图纸与相册
+// @Tags Search
+// @Summary 全文检索图纸与相册，只返回当前用户可访问的结果，附带高亮片段与按相册、创建者的分面统计
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SearchQuery true "检索关键字、结果类型、筛选条件与分页"
+// @Success 200 {object} response.Response{data=response.SearchResult,msg=string} "检索成功"
+// @Router /search/query [post]
+func (searchApi *SearchApi) Search(c *gin.Context) {
+	var req request.SearchQuery
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userUUID := utils.GetUserUuid(c)
+	if userUUID == uuid.Nil {
+		response.FailWithMessage("用户身份验证失败", c)
+		return
+	}
+
+	result, err := searchService.Search(req, userUUID)
+	if err != nil {
+		global.GVA_LOG.Error("检索失败!", zap.Error(err))
+		response.FailWithMessage("检索失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(result, "检索成功", c)
+}
+
+// RebuildIndex 从数据库重建检索索引
+// @Tags Search
+// @Summary 从数据库全量重建检索索引
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=map[string]int,msg=string} "重建成功"
+// @Router /search/rebuild [post]
+func (searchApi *SearchApi) RebuildIndex(c *gin.Context) {
+	count, err := searchService.RebuildIndex()
+	if err != nil {
+		global.GVA_LOG.Error("重建检索索引失败!", zap.Error(err))
+		response.FailWithMessage("重建检索索引失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(gin.H{"documents": count}, "重建成功", c)
+}
@@ -511,12 +511,13 @@ func (b *BaseApi) GetUserDetail(c *gin.Context) {
 
 // GetUserDrawings
 // @Tags      SysUser
-// @Summary   获取用户图纸列表
+// @Summary   分页获取用户可下载的图纸台账（含获得时间、最后下载时间与完成情况）
 // @Security  ApiKeyAuth
 // @accept    application/json
 // @Produce   application/json
 // @Param     id   path      int                           true  "用户ID"
-// @Success   200  {object}  response.Response{data=[]map[string]interface{},msg=string}  "获取用户图纸列表"
+// @Param     data query     request.GetUserDrawingLedger  true  "分页、排序与筛选参数"
+// @Success   200  {object}  response.Response{data=response.PageResult{list=[]systemRes.UserDrawingLedgerItem},msg=string}  "获取用户图纸列表"
 // @Router    /user/getUserDrawings/{id} [get]
 func (b *BaseApi) GetUserDrawings(c *gin.Context) {
 	id := c.Param("id")
@@ -525,6 +526,11 @@ func (b *BaseApi) GetUserDrawings(c *gin.Context) {
 		response.FailWithMessage("用户ID格式错误", c)
 		return
 	}
+	var req systemReq.GetUserDrawingLedger
+	if err = c.ShouldBindQuery(&req); err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
 
 	// 获取用户UUID
 	var user system.SysUser
@@ -535,16 +541,54 @@ func (b *BaseApi) GetUserDrawings(c *gin.Context) {
 		return
 	}
 
-	// 使用下载历史服务获取用户图纸列表
 	downloadHistoryService := &systemService.DownloadHistoryService{}
-	drawings, err := downloadHistoryService.GetUserDrawingsWithDownloadInfo(user.UUID)
+	list, total, err := downloadHistoryService.GetUserDrawingLedger(user.UUID, req)
 	if err != nil {
 		global.GVA_LOG.Error("获取用户图纸列表失败!", zap.Error(err))
-		response.FailWithMessage("获取用户图纸列表失败", c)
+		response.FailWithMessage("获取用户图纸列表失败:"+err.Error(), c)
+		return
+	}
+
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, "获取成功", c)
+}
+
+// GetUserCompletions
+// @Tags      SysUser
+// @Summary   获取用户的图纸完成记录
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     id   path      int                           true  "用户ID"
+// @Success   200  {object}  response.Response{data=[]systemRes.CompletionResponse,msg=string}  "获取用户完成记录"
+// @Router    /user/getUserCompletions/{id} [get]
+func (b *BaseApi) GetUserCompletions(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.FailWithMessage("用户ID格式错误", c)
+		return
+	}
+
+	var user system.SysUser
+	err = global.GVA_DB.Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		global.GVA_LOG.Error("获取用户信息失败!", zap.Error(err))
+		response.FailWithMessage("获取用户信息失败", c)
+		return
+	}
+
+	completions, err := drawingCompletionService.GetUserCompletions(user.UUID, utils.GetUserID(c), utils.GetUserUuid(c))
+	if err != nil {
+		global.GVA_LOG.Error("获取用户完成记录失败!", zap.Error(err))
+		response.FailWithMessage("获取用户完成记录失败", c)
 		return
 	}
 
-	response.OkWithDetailed(drawings, "获取成功", c)
+	response.OkWithDetailed(systemRes.ToCompletionResponses(completions), "获取成功", c)
 }
 
 // GetAdminUsers
@@ -0,0 +1,6 @@
+package config
+
+// AccessGrant 限时授权配置
+type AccessGrant struct {
+	RemindDays int `mapstructure:"remind-days" json:"remind-days" yaml:"remind-days"` // 到期前多少天提醒，0 表示不提醒
+}
@@ -30,6 +30,27 @@ type Server struct {
 
 	Excel Excel `mapstructure:"excel" json:"excel" yaml:"excel"`
 
+	// 图片缩放代理
+	ImageProxy ImageProxy `mapstructure:"image-proxy" json:"image-proxy" yaml:"image-proxy"`
+
+	// 限时授权
+	AccessGrant AccessGrant `mapstructure:"access-grant" json:"access-grant" yaml:"access-grant"`
+
+	// 图纸公开分享
+	DrawingShare DrawingShare `mapstructure:"drawing-share" json:"drawing-share" yaml:"drawing-share"`
+
+	// 回收站
+	RecycleBin RecycleBin `mapstructure:"recycle-bin" json:"recycle-bin" yaml:"recycle-bin"`
+
+	// 下载历史保留
+	DownloadHistory DownloadHistory `mapstructure:"download-history" json:"download-history" yaml:"download-history"`
+
+	// 下载统计
+	DownloadAnalytics DownloadAnalytics `mapstructure:"download-analytics" json:"download-analytics" yaml:"download-analytics"`
+
+	// 下载额度
+	DownloadQuota DownloadQuota `mapstructure:"download-quota" json:"download-quota" yaml:"download-quota"`
+
 	DiskList []DiskList `mapstructure:"disk-list" json:"disk-list" yaml:"disk-list"`
 
 	// 跨域配置
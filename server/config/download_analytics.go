@@ -0,0 +1,6 @@
+package config
+
+// DownloadAnalytics 下载统计配置
+type DownloadAnalytics struct {
+	GlobalAuthorities []uint `mapstructure:"global-authorities" json:"global-authorities" yaml:"global-authorities"` // 可查看全站下载统计的角色ID，其他用户只能查看自己管理的相册
+}
@@ -0,0 +1,8 @@
+package config
+
+// DownloadHistory 下载历史保留配置
+type DownloadHistory struct {
+	RetentionDays int    `mapstructure:"retention-days" json:"retention-days" yaml:"retention-days"` // 原始下载记录保留天数，超期记录压缩为用户-图纸汇总后删除；0 表示不清理
+	Archive       bool   `mapstructure:"archive" json:"archive" yaml:"archive"`                      // 删除前是否归档为 gzip 压缩的 JSON Lines 文件
+	ArchivePath   string `mapstructure:"archive-path" json:"archive-path" yaml:"archive-path"`       // 归档文件目录，须为不对外提供访问的私有目录（不能位于 uploads 或本地存储目录下）
+}
@@ -0,0 +1,17 @@
+package config
+
+// DownloadQuota 下载额度配置，用户在任一规则下超出额度即拒绝下载；未配置规则时不限制（规则示例见 config.docker.yaml）
+type DownloadQuota struct {
+	Rules             []DownloadQuotaRule `mapstructure:"rules" json:"rules" yaml:"rules"`                                        // 额度规则
+	ExemptAuthorities []uint              `mapstructure:"exempt-authorities" json:"exempt-authorities" yaml:"exempt-authorities"` // 不受额度限制的角色ID
+}
+
+// DownloadQuotaRule 下载额度规则，按自然小时或自然日计数
+type DownloadQuotaRule struct {
+	Name         string `mapstructure:"name" json:"name" yaml:"name"`                            // 规则名称，需唯一且不含冒号
+	Window       string `mapstructure:"window" json:"window" yaml:"window"`                      // 计数周期 hour/day
+	MaxDownloads int64  `mapstructure:"max-downloads" json:"max-downloads" yaml:"max-downloads"` // 周期内最多下载图纸数，0 表示不限
+	MaxBytes     int64  `mapstructure:"max-bytes" json:"max-bytes" yaml:"max-bytes"`             // 周期内最多下载字节数，0 表示不限
+	PerAlbum     bool   `mapstructure:"per-album" json:"per-album" yaml:"per-album"`             // 是否按相册分别计数
+	AlbumID      uint   `mapstructure:"album-id" json:"album-id" yaml:"album-id"`                // 仅对该相册生效，0 表示所有相册
+}
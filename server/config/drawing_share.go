@@ -0,0 +1,7 @@
+package config
+
+// DrawingShare 图纸公开分享配置
+type DrawingShare struct {
+	LimitCount int `mapstructure:"limit-count" json:"limit-count" yaml:"limit-count"` // 每个IP在周期内允许的访问次数
+	LimitTime  int `mapstructure:"limit-time" json:"limit-time" yaml:"limit-time"`    // 限流周期（秒）
+}
@@ -0,0 +1,10 @@
+package config
+
+// ImageProxy 图片实时缩放代理配置
+type ImageProxy struct {
+	CacheDir     string   `mapstructure:"cache-dir" json:"cache-dir" yaml:"cache-dir"`                // 缩放结果缓存目录
+	MaxCacheSize int64    `mapstructure:"max-cache-size" json:"max-cache-size" yaml:"max-cache-size"` // 缓存容量上限(MB)
+	MaxAge       int      `mapstructure:"max-age" json:"max-age" yaml:"max-age"`                      // 浏览器缓存时间(秒)
+	Sizes        []string `mapstructure:"sizes" json:"sizes" yaml:"sizes"`                            // 允许的尺寸白名单，格式 宽x高，0 表示按比例
+	Qualities    []int    `mapstructure:"qualities" json:"qualities" yaml:"qualities"`                // 允许的JPEG质量
+}
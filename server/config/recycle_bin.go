@@ -0,0 +1,6 @@
+package config
+
+// RecycleBin 回收站配置
+type RecycleBin struct {
+	RetentionDays int `mapstructure:"retention-days" json:"retention-days" yaml:"retention-days"` // 删除后保留天数，到期彻底删除；0 表示不自动清理
+}
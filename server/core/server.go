@@ -24,9 +24,10 @@ func RunServer() {
 			zap.L().Error(fmt.Sprintf("%+v", err))
 		}
 	}
-	// 从db加载jwt数据
+	// 从db加载jwt数据，并异步构建检索索引
 	if global.GVA_DB != nil {
 		system.LoadAll()
+		system.SearchServiceApp.QueueRebuild()
 	}
 
 	Router := initialize.Routers()
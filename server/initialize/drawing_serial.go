@@ -0,0 +1,83 @@
+package initialize
+
+import (
+	"fmt"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// dedupeDrawingSerials 创建相册内序号唯一索引前，为历史重复序号追加图纸ID后缀，避免建索引失败
+func dedupeDrawingSerials(db *gorm.DB) {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&system.SysDrawing{}) || migrator.HasIndex(&system.SysDrawing{}, "idx_album_serial") {
+		return
+	}
+	// 先查出重复的序号分组，再逐组加载图纸（行值 IN 子查询在 SQL Server 上不可用）
+	type serialGroup struct {
+		AlbumID      uint
+		SerialNumber string
+	}
+	var groups []serialGroup
+	err := db.Unscoped().Model(&system.SysDrawing{}).
+		Select("album_id, serial_number").
+		Group("album_id, serial_number").Having("COUNT(*) > 1").
+		Scan(&groups).Error
+	if err != nil {
+		global.GVA_LOG.Error("检查重复图纸序号失败", zap.Error(err))
+		return
+	}
+	for _, group := range groups {
+		var drawings []system.SysDrawing
+		err = db.Unscoped().Select("id", "album_id", "serial_number").
+			Where("album_id = ? AND serial_number = ?", group.AlbumID, group.SerialNumber).
+			Order("id ASC").Find(&drawings).Error
+		if err != nil {
+			global.GVA_LOG.Error("检查重复图纸序号失败", zap.Uint("album_id", group.AlbumID), zap.Error(err))
+			continue
+		}
+		if len(drawings) < 2 {
+			continue
+		}
+		// 保留最早的图纸原序号，其余追加图纸ID后缀
+		for _, drawing := range drawings[1:] {
+			serial := fmt.Sprintf("%s-%d", drawing.SerialNumber, drawing.ID)
+			if err = db.Unscoped().Model(&drawing).Update("serial_number", serial).Error; err != nil {
+				global.GVA_LOG.Error("修正重复图纸序号失败", zap.Uint("drawing_id", drawing.ID), zap.Error(err))
+				continue
+			}
+			global.GVA_LOG.Warn("图纸序号重复，已追加图纸ID后缀",
+				zap.Uint("drawing_id", drawing.ID),
+				zap.String("old", drawing.SerialNumber),
+				zap.String("new", serial))
+		}
+	}
+}
+
+// backfillDrawingSerialSortKeys 为历史图纸补齐序号自然排序键
+func backfillDrawingSerialSortKeys(db *gorm.DB) {
+	for {
+		var drawings []system.SysDrawing
+		err := db.Unscoped().Select("id", "serial_number").
+			Where("serial_sort_key = '' OR serial_sort_key IS NULL").
+			Where("serial_number <> ''").
+			Limit(500).Find(&drawings).Error
+		if err != nil {
+			global.GVA_LOG.Error("补齐图纸序号排序键失败", zap.Error(err))
+			return
+		}
+		if len(drawings) == 0 {
+			return
+		}
+		for _, drawing := range drawings {
+			key := utils.NaturalSortKey(drawing.SerialNumber, 255)
+			if err = db.Unscoped().Model(&drawing).UpdateColumn("serial_sort_key", key).Error; err != nil {
+				global.GVA_LOG.Error("补齐图纸序号排序键失败", zap.Uint("drawing_id", drawing.ID), zap.Error(err))
+				return
+			}
+		}
+	}
+}
@@ -36,6 +36,7 @@ func Gorm() *gorm.DB {
 
 func RegisterTables() {
 	db := global.GVA_DB
+	dedupeDrawingSerials(db)
 	err := db.AutoMigrate(
 
 		system.SysApi{},
@@ -62,6 +63,34 @@ func RegisterTables() {
 		system.SysDrawing{},
 		system.SysDownloadHistory{},
 		system.SysMustRead{},
+		system.SysBeadInventory{},
+		system.SysDrawingColor{},
+		system.SysDrawingImageHash{},
+		system.SysImageRendition{},
+		system.SysAlbumField{},
+		system.SysDrawingFieldValue{},
+		system.SysDrawingCompletion{},
+		system.SysAlbumMember{},
+		system.SysDrawingGrant{},
+		system.SysAccessGrantLog{},
+		system.SysNotification{},
+		system.SysAccessRequest{},
+		system.SysAlbumInvite{},
+		system.SysAlbumInviteRedemption{},
+		system.SysDrawingShare{},
+		system.SysAlbumSerialScheme{},
+		system.SysAlbumSerialSequence{},
+		system.SysDrawingReview{},
+		system.SysDownloadDailyDrawing{},
+		system.SysDownloadDailyUser{},
+		system.SysDownloadRollupCursor{},
+		system.SysDownloadSummary{},
+		system.SysDownloadArchive{},
+		system.SysDrawingTrend{},
+		system.SysDrawingRelation{},
+		system.SysDrawingCollection{},
+		system.SysDrawingCollectionItem{},
+		system.SysDrawingCollectionShare{},
 
 		example.ExaFile{},
 		example.ExaCustomer{},
@@ -73,6 +102,7 @@ func RegisterTables() {
 		global.GVA_LOG.Error("register table failed", zap.Error(err))
 		os.Exit(0)
 	}
+	backfillDrawingSerialSortKeys(db)
 
 	err = bizModel()
 
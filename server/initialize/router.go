@@ -105,7 +105,18 @@ func Routers() *gin.Engine {
 		systemRouter.InitSysExportTemplateRouter(PrivateGroup, PublicGroup) // 导出模板
 		systemRouter.InitSysParamsRouter(PrivateGroup, PublicGroup)         // 参数管理
 		systemRouter.InitAlbumRouter(PrivateGroup, PublicGroup)             // 相册路由
-		systemRouter.InitMustReadRouter(PrivateGroup, PublicGroup)           // 必读路由
+		systemRouter.InitMustReadRouter(PrivateGroup, PublicGroup)          // 必读路由
+		systemRouter.InitBeadInventoryRouter(PrivateGroup)                  // 拼豆库存路由
+		systemRouter.InitImageProxyRouter(PublicGroup)                      // 图片缩放路由
+		systemRouter.InitDrawingCompletionRouter(PrivateGroup)              // 图纸完成记录路由
+		systemRouter.InitNotificationRouter(PrivateGroup)                   // 站内通知路由
+		systemRouter.InitAccessRequestRouter(PrivateGroup)                  // 访问申请路由
+		systemRouter.InitRecycleBinRouter(PrivateGroup)                     // 回收站路由
+		systemRouter.InitDownloadAnalyticsRouter(PrivateGroup)              // 下载统计路由
+		systemRouter.InitDownloadAuditRouter(PrivateGroup)                  // 下载审计
+		systemRouter.InitDownloadQuotaRouter(PrivateGroup)                  // 下载额度
+		systemRouter.InitSearchRouter(PrivateGroup)                         // 全文检索
+		systemRouter.InitDrawingCollectionRouter(PrivateGroup)              // 图纸收藏夹
 		exampleRouter.InitCustomerRouter(PrivateGroup)                      // 客户路由
 		exampleRouter.InitFileUploadAndDownloadRouter(PrivateGroup)         // 文件上传下载功能路由
 		exampleRouter.InitAttachmentCategoryRouterRouter(PrivateGroup)      // 文件上传下载分类
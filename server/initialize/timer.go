@@ -24,6 +24,72 @@ func Timer() {
 			fmt.Println("add timer error:", err)
 		}
 
+		// 补齐历史图片衍生图
+		_, err = global.GVA_Timer.AddTaskByFunc("ImageRenditionBackfill", "0 30 */6 * * *", task.BackfillImageRenditions, "定时补齐相册封面与图纸图片的衍生图", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 补齐历史图纸的图片感知哈希
+		_, err = global.GVA_Timer.AddTaskByFunc("DrawingHashBackfill", "0 15 */6 * * *", task.BackfillDrawingHashes, "定时为历史图纸补齐海报与图纸图片的感知哈希", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 回收过期的限时授权
+		_, err = global.GVA_Timer.AddTaskByFunc("AccessGrantExpiry", "0 */10 * * * *", task.RevokeExpiredAccessGrants, "定时回收过期的相册成员与图纸授权", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 限时授权到期提醒
+		_, err = global.GVA_Timer.AddTaskByFunc("AccessGrantReminder", "0 0 9 * * *", task.RemindExpiringAccessGrants, "授权到期前按配置天数提醒用户", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 回收站超过保留期的相册与图纸彻底删除
+		_, err = global.GVA_Timer.AddTaskByFunc("RecycleBinPurge", "0 0 3 * * *", task.PurgeRecycleBin, "彻底删除回收站中超过保留期的相册、图纸及其存储文件", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 发布已到定时发布时间的图纸
+		_, err = global.GVA_Timer.AddTaskByFunc("DrawingScheduledPublish", "0 * * * * *", task.PublishScheduledDrawings, "按计划发布审核通过的图纸", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 下载历史增量汇总到每日统计表
+		_, err = global.GVA_Timer.AddTaskByFunc("DownloadRollup", "30 * * * * *", task.RollupDownloads, "将新增下载历史汇总到每日下载统计表", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 超期下载历史压缩归档
+		_, err = global.GVA_Timer.AddTaskByFunc("DownloadHistoryCompact", "0 30 4 * * *", task.CompactDownloadHistories, "将超过保留期的下载历史压缩为用户-图纸汇总并归档删除", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 重新计算图纸热度
+		_, err = global.GVA_Timer.AddTaskByFunc("DrawingTrendRecompute", "0 */15 * * * *", task.RecomputeDrawingTrends, "根据每日下载汇总按时间衰减重新计算图纸热度", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 重新计算图纸关联推荐
+		_, err = global.GVA_Timer.AddTaskByFunc("DrawingRelationRebuild", "0 45 */6 * * *", task.RebuildDrawingRelations, "根据下载共现重新计算图纸关联推荐", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 全量重建检索索引
+		_, err = global.GVA_Timer.AddTaskByFunc("SearchIndexRebuild", "0 50 3 * * *", task.RebuildSearchIndex, "从数据库全量重建图纸与相册检索索引", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
 		// 其他定时任务定在这里 参考上方使用方法
 
 		//_, err := global.GVA_Timer.AddTaskByFunc("定时任务标识", "corn表达式", func() {
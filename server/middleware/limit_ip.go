@@ -90,3 +90,34 @@ func SetLimitWithTime(key string, limit int, expiration time.Duration) error {
 		}
 	}
 }
+
+// LocalCheckOrMark 优先使用redis计数，未开启redis时退化为本地缓存计数
+func LocalCheckOrMark(key string, expire int, limit int) error {
+	if global.GVA_REDIS != nil {
+		return DefaultCheckOrMark(key, expire, limit)
+	}
+	if limit <= 0 {
+		return nil
+	}
+	v, ok := global.BlackCache.Get(key)
+	if !ok {
+		global.BlackCache.Set(key, 1, time.Duration(expire)*time.Second)
+		return nil
+	}
+	if times, _ := v.(int); times >= limit {
+		return errors.New("请求太过频繁，请稍后再试")
+	}
+	return global.BlackCache.Increment(key, 1)
+}
+
+// ShareLimit 图纸公开分享接口按IP限流
+func ShareLimit() gin.HandlerFunc {
+	return LimitConfig{
+		GenerationKey: func(c *gin.Context) string {
+			return "GVA_ShareLimit" + c.ClientIP()
+		},
+		CheckOrMark: LocalCheckOrMark,
+		Expire:      global.GVA_CONFIG.DrawingShare.LimitTime,
+		Limit:       global.GVA_CONFIG.DrawingShare.LimitCount,
+	}.LimitWithTime()
+}
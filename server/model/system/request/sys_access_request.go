@@ -0,0 +1,35 @@
+package request
+
+import "time"
+
+// CreateAccessRequest 提交访问申请请求
+type CreateAccessRequest struct {
+	TargetType string `json:"targetType" binding:"required"` // 申请对象类型 album/drawing
+	TargetID   uint   `json:"targetId" binding:"required"`   // 相册ID或图纸ID
+	Role       string `json:"role"`                          // 申请相册时的角色 viewer/downloader/editor，默认 downloader
+	Message    string `json:"message"`                       // 申请留言
+}
+
+// GetMyAccessRequests 获取我的访问申请请求
+type GetMyAccessRequests struct {
+	Status   string `json:"status"`   // 按状态筛选
+	Page     int    `json:"page"`     // 页码
+	PageSize int    `json:"pageSize"` // 每页大小
+}
+
+// GetAccessRequests 获取待处理访问申请请求（相册创建者或管理员）
+type GetAccessRequests struct {
+	AlbumID  uint   `json:"albumId"`  // 按相册筛选，为空表示自己管理的全部相册
+	Status   string `json:"status"`   // 按状态筛选
+	Page     int    `json:"page"`     // 页码
+	PageSize int    `json:"pageSize"` // 每页大小
+}
+
+// DecideAccessRequests 处理访问申请请求
+type DecideAccessRequests struct {
+	IDs       []uint     `json:"ids" binding:"required"`    // 申请ID列表
+	Status    string     `json:"status" binding:"required"` // 处理结果 approved/rejected
+	Note      string     `json:"note"`                      // 处理备注
+	Role      string     `json:"role"`                      // 通过相册申请时授予的角色，为空时使用申请角色；高于申请角色仅限相册创建者或相册管理员
+	ExpiresAt *time.Time `json:"expiresAt"`                 // 通过时授权的过期时间，为空表示永久
+}
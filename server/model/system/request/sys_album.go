@@ -30,6 +30,7 @@ type GetAlbumList struct {
 	Title       string    `json:"title" form:"title" example:"相册标题"`
 	CreatorUUID uuid.UUID `json:"creatorUUID" form:"creatorUUID" example:"创建者UUID"`
 	Status      int       `json:"status" form:"status" example:"相册状态"`
+	Sort        string    `json:"sort" form:"sort" example:"排序方式 position/name/created/popularity，默认 position"`
 }
 
 // GetAlbumByID 根据ID获取相册请求结构
@@ -41,3 +42,8 @@ type GetAlbumByID struct {
 type DeleteAlbum struct {
 	ID uint `json:"id" binding:"required" example:"相册ID"`
 }
+
+// ReorderAlbums 调整相册顺序请求结构
+type ReorderAlbums struct {
+	AlbumIDs []uint `json:"albumIds" binding:"required,min=1" example:"按目标顺序排列的相册ID"`
+}
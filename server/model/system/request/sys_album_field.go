@@ -0,0 +1,32 @@
+package request
+
+// AlbumFieldItem 相册自定义字段定义
+type AlbumFieldItem struct {
+	Key      string   `json:"key" binding:"required"`   // 字段标识，字母开头，仅含字母数字下划线
+	Label    string   `json:"label" binding:"required"` // 字段名称
+	Type     string   `json:"type" binding:"required"`  // 字段类型 text/number/integer/boolean/enum/date
+	Required bool     `json:"required"`                 // 是否必填
+	Options  []string `json:"options"`                  // 枚举选项（枚举类型必填）
+	Min      *float64 `json:"min"`                      // 最小值（数字）或最小长度（文本）
+	Max      *float64 `json:"max"`                      // 最大值（数字）或最大长度（文本）
+	Pattern  string   `json:"pattern"`                  // 文本校验正则
+}
+
+// SaveAlbumFields 保存相册自定义字段（整体替换）
+type SaveAlbumFields struct {
+	AlbumID uint             `json:"albumId" binding:"required"` // 相册ID
+	Fields  []AlbumFieldItem `json:"fields"`                     // 字段定义列表，按顺序排序
+}
+
+// GetAlbumFields 获取相册自定义字段
+type GetAlbumFields struct {
+	AlbumID uint `json:"albumId" binding:"required"` // 相册ID
+}
+
+// DrawingFieldFilter 图纸自定义字段筛选条件
+type DrawingFieldFilter struct {
+	Key    string        `json:"key" binding:"required"` // 字段标识
+	Op     string        `json:"op"`                     // 比较方式 eq/ne/gt/gte/lt/lte/in/like，默认 eq
+	Value  interface{}   `json:"value"`                  // 比较值
+	Values []interface{} `json:"values"`                 // in 比较的取值列表
+}
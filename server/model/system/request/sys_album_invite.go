@@ -0,0 +1,36 @@
+package request
+
+import "time"
+
+// CreateAlbumInvite 创建相册邀请链接请求
+type CreateAlbumInvite struct {
+	AlbumID        uint       `json:"albumId" binding:"required"` // 相册ID
+	Role           string     `json:"role" binding:"required"`    // 加入后的角色 viewer/downloader/editor/admin
+	MaxUses        int        `json:"maxUses"`                    // 最大使用次数，0表示不限
+	ExpiresAt      *time.Time `json:"expiresAt"`                  // 过期时间，为空表示永不过期
+	AllowedDomains []string   `json:"allowedDomains"`             // 允许的邮箱域名，如 example.com
+}
+
+// GetAlbumInvites 获取相册邀请链接列表请求
+type GetAlbumInvites struct {
+	AlbumID  uint `json:"albumId" binding:"required"` // 相册ID
+	Page     int  `json:"page"`                       // 页码
+	PageSize int  `json:"pageSize"`                   // 每页大小
+}
+
+// RevokeAlbumInvite 撤销相册邀请链接请求
+type RevokeAlbumInvite struct {
+	ID uint `json:"id" binding:"required"` // 邀请ID
+}
+
+// GetInviteRedemptions 获取邀请链接兑换记录请求
+type GetInviteRedemptions struct {
+	InviteID uint `json:"inviteId" binding:"required"` // 邀请ID
+	Page     int  `json:"page"`                        // 页码
+	PageSize int  `json:"pageSize"`                    // 每页大小
+}
+
+// RedeemAlbumInvite 兑换相册邀请链接请求
+type RedeemAlbumInvite struct {
+	Token string `json:"token" binding:"required"` // 邀请令牌
+}
@@ -0,0 +1,52 @@
+package request
+
+import "time"
+
+// AddAlbumMembers 批量添加相册成员请求，已是成员的用户更新角色与过期时间
+type AddAlbumMembers struct {
+	AlbumID   uint       `json:"albumId" binding:"required"` // 相册ID
+	UserIDs   []uint     `json:"userIds" binding:"required"` // 用户ID列表
+	Role      string     `json:"role" binding:"required"`    // 角色 viewer/downloader/editor/admin
+	StartsAt  *time.Time `json:"startsAt"`                   // 生效时间，为空表示立即生效
+	ExpiresAt *time.Time `json:"expiresAt"`                  // 过期时间，为空表示永久
+}
+
+// RemoveAlbumMembers 批量移除相册成员请求
+type RemoveAlbumMembers struct {
+	AlbumID uint   `json:"albumId" binding:"required"` // 相册ID
+	UserIDs []uint `json:"userIds" binding:"required"` // 用户ID列表
+}
+
+// GetAlbumMembers 获取相册成员列表请求
+type GetAlbumMembers struct {
+	AlbumID  uint   `json:"albumId" binding:"required"` // 相册ID
+	Role     string `json:"role"`                       // 按角色筛选
+	Page     int    `json:"page"`                       // 页码
+	PageSize int    `json:"pageSize"`                   // 每页大小
+}
+
+// ExplainDrawingAccess 查询图纸访问权限来源请求
+type ExplainDrawingAccess struct {
+	DrawingID uint `json:"drawingId" binding:"required"` // 图纸ID
+}
+
+// AddDrawingGrants 批量授予图纸访问权限请求
+type AddDrawingGrants struct {
+	DrawingIDs []uint     `json:"drawingIds" binding:"required"` // 图纸ID列表
+	UserIDs    []uint     `json:"userIds" binding:"required"`    // 用户ID列表
+	StartsAt   *time.Time `json:"startsAt"`                      // 生效时间，为空表示立即生效
+	ExpiresAt  *time.Time `json:"expiresAt"`                     // 过期时间，为空表示永久
+}
+
+// RemoveDrawingGrants 批量撤销图纸授权请求
+type RemoveDrawingGrants struct {
+	DrawingIDs []uint `json:"drawingIds" binding:"required"` // 图纸ID列表
+	UserIDs    []uint `json:"userIds" binding:"required"`    // 用户ID列表
+}
+
+// GetDrawingGrants 获取图纸授权列表请求
+type GetDrawingGrants struct {
+	DrawingID uint `json:"drawingId" binding:"required"` // 图纸ID
+	Page      int  `json:"page"`                         // 页码
+	PageSize  int  `json:"pageSize"`                     // 每页大小
+}
@@ -0,0 +1,15 @@
+package request
+
+// SaveAlbumSerialScheme 保存相册编号规则请求
+type SaveAlbumSerialScheme struct {
+	AlbumID   uint   `json:"albumId" binding:"required"` // 相册ID
+	Prefix    string `json:"prefix"`                     // 序号前缀，如 A-
+	DateToken string `json:"dateToken"`                  // 日期占位 空/yyyy/yyyymm/yyyymmdd
+	Separator string `json:"separator"`                  // 日期与流水号之间的分隔符
+	Padding   int    `json:"padding"`                    // 流水号补零位数，默认3
+}
+
+// GetAlbumSerialScheme 获取或删除相册编号规则请求
+type GetAlbumSerialScheme struct {
+	AlbumID uint `json:"albumId" binding:"required"` // 相册ID
+}
@@ -0,0 +1,34 @@
+package request
+
+// CreateBeadInventory 新增库存请求
+type CreateBeadInventory struct {
+	ColorCode string `json:"colorCode" binding:"required"` // 色号
+	Quantity  int    `json:"quantity"`                     // 数量
+}
+
+// UpdateBeadInventory 更新库存请求
+type UpdateBeadInventory struct {
+	ID       uint `json:"id" binding:"required"` // 库存ID
+	Quantity int  `json:"quantity"`              // 数量
+}
+
+// DeleteBeadInventory 删除库存请求
+type DeleteBeadInventory struct {
+	ID uint `json:"id" binding:"required"` // 库存ID
+}
+
+// GetBeadInventoryList 获取库存列表请求
+type GetBeadInventoryList struct {
+	Page     int    `json:"page"`     // 页码
+	PageSize int    `json:"pageSize"` // 每页大小
+	Keyword  string `json:"keyword"`  // 色号关键词
+}
+
+// MatchDrawings 库存匹配图纸请求
+type MatchDrawings struct {
+	Page     int    `json:"page"`     // 页码
+	PageSize int    `json:"pageSize"` // 每页大小
+	Keyword  string `json:"keyword"`  // 搜索关键词
+	Sort     string `json:"sort"`     // 排序方式 missing:缺豆最少优先 默认按创建时间倒序
+	OnlyMake bool   `json:"onlyMake"` // 仅返回库存足够的图纸
+}
@@ -0,0 +1,29 @@
+package request
+
+import "github.com/google/uuid"
+
+// DownloadAnalyticsRange 下载统计的日期范围与相册范围
+type DownloadAnalyticsRange struct {
+	AlbumID   uint   `json:"albumId"`                      // 相册ID，为空表示全站（仅超级管理员）
+	StartDate string `json:"startDate" binding:"required"` // 开始日期 YYYY-MM-DD（含）
+	EndDate   string `json:"endDate" binding:"required"`   // 结束日期 YYYY-MM-DD（含）
+}
+
+// GetDownloadSeries 获取下载量时间序列请求
+type GetDownloadSeries struct {
+	DownloadAnalyticsRange
+	DrawingID uint      `json:"drawingId"` // 按图纸统计
+	UserUUID  uuid.UUID `json:"userUUID"`  // 按用户统计
+	Bucket    string    `json:"bucket"`    // 分桶粒度 day/week/month，默认 day
+}
+
+// GetTopDrawings 获取下载量排行请求
+type GetTopDrawings struct {
+	DownloadAnalyticsRange
+	Limit int `json:"limit"` // 返回数量，默认10，最多100
+}
+
+// GetDownloadSummary 获取下载概况请求
+type GetDownloadSummary struct {
+	DownloadAnalyticsRange
+}
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/google/uuid"
+
+// SearchDownloadAudits 下载审计检索请求，各条件之间为“且”关系
+type SearchDownloadAudits struct {
+	UserUUID      uuid.UUID `json:"userUUID"`      // 下载用户
+	DrawingID     uint      `json:"drawingId"`     // 图纸ID
+	AlbumID       uint      `json:"albumId"`       // 相册ID
+	Channel       string    `json:"channel"`       // 下载渠道 single/batch/client
+	ClientIP      string    `json:"clientIP"`      // 客户端IP（精确匹配）
+	RequestID     string    `json:"requestId"`     // 请求ID（精确匹配）
+	Watermarked   *bool     `json:"watermarked"`   // 是否带水印，为空表示不限
+	WatermarkHash string    `json:"watermarkHash"` // 水印文字与策略哈希（精确匹配）
+	WatermarkText string    `json:"watermarkText"` // 水印文字关键字
+	File          string    `json:"file"`          // 文件路径关键字
+	UserAgent     string    `json:"userAgent"`     // 客户端UA关键字
+	StartTime     int64     `json:"startTime"`     // 下载时间起（Unix秒，含）
+	EndTime       int64     `json:"endTime"`       // 下载时间止（Unix秒，含）
+	Page          int       `json:"page"`          // 页码
+	PageSize      int       `json:"pageSize"`      // 每页大小
+}
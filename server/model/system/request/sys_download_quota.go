@@ -0,0 +1,9 @@
+package request
+
+import "github.com/google/uuid"
+
+// DownloadQuotaUser 查看或重置用户下载额度请求
+type DownloadQuotaUser struct {
+	UserUUID uuid.UUID `json:"userUUID" binding:"required"` // 用户UUID
+	AlbumID  uint      `json:"albumId"`                     // 相册ID，0 表示全部
+}
@@ -1,31 +1,45 @@
 package request
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 // CreateDrawing 创建图纸请求
 type CreateDrawing struct {
-	AlbumID            uint      `json:"albumId" binding:"required"`        // 相册ID
-	SerialNumber       string    `json:"serialNumber" binding:"required"`   // 图纸序号
-	Name               string    `json:"name" binding:"required"`           // 图纸名称
-	BeanQuantity       *int      `json:"beanQuantity"`                      // 豆量
-	PosterImageURL     string    `json:"posterImageURL" binding:"required"` // 海报图URL
-	DrawingURLs        []string  `json:"drawingURLs" binding:"required"`    // 图纸文件URLs
-	CreatorUUID        uuid.UUID `json:"creatorUUID" binding:"required"`    // 创建者UUID
-	AllowedMemberUUIDs []string  `json:"allowedMemberUUIDs"`                // 允许下载的成员UUIDs
+	AlbumID            uint                   `json:"albumId" binding:"required"`        // 相册ID
+	SerialNumber       string                 `json:"serialNumber"`                      // 图纸序号，为空时按相册编号规则自动分配
+	Name               string                 `json:"name" binding:"required"`           // 图纸名称
+	BeanQuantity       *int                   `json:"beanQuantity"`                      // 豆量
+	PosterImageURL     string                 `json:"posterImageURL" binding:"required"` // 海报图URL
+	DrawingURLs        []string               `json:"drawingURLs" binding:"required"`    // 图纸文件URLs
+	CreatorUUID        uuid.UUID              `json:"creatorUUID"`                       // 创建者UUID（已忽略，以当前登录用户为准）
+	AllowedMemberUUIDs []string               `json:"allowedMemberUUIDs"`                // 允许下载的成员UUIDs
+	Colors             []DrawingColor         `json:"colors"`                            // 色号用量清单
+	Fields             map[string]interface{} `json:"fields"`                            // 自定义字段值
+	Status             string                 `json:"status"`                            // 初始状态 draft/in_review/published，直接发布需审核权限；为空时有审核权限的直接发布，否则提交审核
+	PublishAt          *time.Time             `json:"publishAt"`                         // 定时发布时间，仅直接发布时有效
 }
 
 // UpdateDrawing 更新图纸请求
 type UpdateDrawing struct {
-	ID                 uint     `json:"id" binding:"required"`             // 图纸ID
-	AlbumID            uint     `json:"albumId" binding:"required"`        // 相册ID
-	SerialNumber       string   `json:"serialNumber" binding:"required"`   // 图纸序号
-	Name               string   `json:"name" binding:"required"`           // 图纸名称
-	BeanQuantity       *int     `json:"beanQuantity"`                      // 豆量
-	PosterImageURL     string   `json:"posterImageURL" binding:"required"` // 海报图URL
-	DrawingURLs        []string `json:"drawingURLs" binding:"required"`    // 图纸文件URLs
-	AllowedMemberUUIDs []string `json:"allowedMemberUUIDs"`                // 允许下载的成员UUIDs
+	ID                 uint                   `json:"id" binding:"required"`             // 图纸ID
+	AlbumID            uint                   `json:"albumId" binding:"required"`        // 相册ID（须与图纸当前相册一致，更换相册请使用移动图纸接口）
+	SerialNumber       string                 `json:"serialNumber" binding:"required"`   // 图纸序号
+	Name               string                 `json:"name" binding:"required"`           // 图纸名称
+	BeanQuantity       *int                   `json:"beanQuantity"`                      // 豆量
+	PosterImageURL     string                 `json:"posterImageURL" binding:"required"` // 海报图URL
+	DrawingURLs        []string               `json:"drawingURLs" binding:"required"`    // 图纸文件URLs
+	AllowedMemberUUIDs []string               `json:"allowedMemberUUIDs"`                // 允许下载的成员UUIDs
+	Colors             []DrawingColor         `json:"colors"`                            // 色号用量清单（为空时不修改）
+	Fields             map[string]interface{} `json:"fields"`                            // 自定义字段值（为空时不修改）
+}
+
+// DrawingColor 图纸色号用量
+type DrawingColor struct {
+	ColorCode string `json:"colorCode" binding:"required"` // 色号
+	Quantity  int    `json:"quantity"`                     // 用量
 }
 
 // DeleteDrawing 删除图纸请求
@@ -40,11 +54,15 @@ type GetDrawingByID struct {
 
 // GetDrawingList 获取图纸列表请求
 type GetDrawingList struct {
-	AlbumID   uint   `json:"albumId" binding:"required"` // 相册ID
-	Page      int    `json:"page"`                       // 页码
-	PageSize  int    `json:"pageSize"`                   // 每页大小
-	Keyword   string `json:"keyword"`                    // 搜索关键词
-	CreatorID uint   `json:"creatorId"`                  // 创建者ID
+	AlbumID   uint                 `json:"albumId" binding:"required"` // 相册ID
+	Page      int                  `json:"page"`                       // 页码
+	PageSize  int                  `json:"pageSize"`                   // 每页大小
+	Keyword   string               `json:"keyword"`                    // 搜索关键词
+	CreatorID uint                 `json:"creatorId"`                  // 创建者ID
+	Status    string               `json:"status"`                     // 按发布状态筛选
+	Filters   []DrawingFieldFilter `json:"filters"`                    // 自定义字段筛选条件
+	Sort      string               `json:"sort"`                       // 排序方式 position/serial/name/created/popularity/trending，或按字段排序 serialNumber/beanQuantity/createdAt/自定义字段标识；默认 position
+	SortOrder string               `json:"sortOrder"`                  // 按字段排序时的方向 asc/desc，默认 desc
 }
 
 // GetMyDrawings 获取当前用户可下载的图纸列表请求
@@ -52,6 +70,7 @@ type GetMyDrawings struct {
 	Page     int    `json:"page"`     // 页码
 	PageSize int    `json:"pageSize"` // 每页大小
 	Keyword  string `json:"keyword"`  // 搜索关键词
+	Sort     string `json:"sort"`     // 排序方式 position/serial/name/created/popularity/trending，默认 created
 	UserID   uint   `json:"-"`        // 当前用户ID（从JWT中获取）
 	UserUUID string `json:"-"`        // 当前用户UUID（从JWT中获取）
 }
@@ -75,10 +94,30 @@ type BatchDownloadDrawings struct {
 // RecordDownload 记录下载请求
 type RecordDownload struct {
 	DrawingID uint `json:"drawingId" binding:"required"` // 图纸ID
-	AlbumID   uint `json:"albumId" binding:"required"`   // 相册ID
+	AlbumID   uint `json:"albumId"`                      // 相册ID（已忽略，以图纸所在相册为准）
 }
 
 // DownloadStatusRequest 批量查询下载状态
 type DownloadStatusRequest struct {
 	DrawingIDs []uint `json:"drawingIds" binding:"required"`
 }
+
+// TransferDrawings 移动或复制图纸到其他相册请求
+type TransferDrawings struct {
+	DrawingIDs       []uint `json:"drawingIds" binding:"required,min=1"` // 图纸ID列表
+	TargetAlbumID    uint   `json:"targetAlbumId" binding:"required"`    // 目标相册ID
+	ConflictStrategy string `json:"conflictStrategy"`                    // 序号冲突处理策略 fail/renumber/suffix，默认 fail
+}
+
+// ReorderDrawings 调整相册内图纸顺序请求
+type ReorderDrawings struct {
+	AlbumID    uint   `json:"albumId" binding:"required"`          // 相册ID
+	DrawingIDs []uint `json:"drawingIds" binding:"required,min=1"` // 按目标顺序排列的图纸ID
+}
+
+// DownloadClient 下载请求的客户端信息，由接口层从请求中提取
+type DownloadClient struct {
+	ClientIP  string // 客户端IP
+	UserAgent string // 客户端UA
+	RequestID string // 请求ID
+}
@@ -0,0 +1,51 @@
+package request
+
+// CreateDrawingCollection 创建收藏夹请求
+type CreateDrawingCollection struct {
+	Name        string `json:"name" binding:"required,max=64"` // 名称
+	Description string `json:"description" binding:"max=500"`  // 描述
+}
+
+// UpdateDrawingCollection 更新收藏夹请求
+type UpdateDrawingCollection struct {
+	ID          uint   `json:"id" binding:"required"`          // 收藏夹ID
+	Name        string `json:"name" binding:"required,max=64"` // 名称
+	Description string `json:"description" binding:"max=500"`  // 描述
+}
+
+// DeleteDrawingCollection 删除收藏夹请求
+type DeleteDrawingCollection struct {
+	ID uint `json:"id" binding:"required"` // 收藏夹ID
+}
+
+// GetCollectionDrawings 获取收藏夹图纸请求
+type GetCollectionDrawings struct {
+	CollectionID uint `json:"collectionId" binding:"required"` // 收藏夹ID
+	Page         int  `json:"page"`                            // 页码
+	PageSize     int  `json:"pageSize"`                        // 每页大小
+}
+
+// CollectionDrawings 收藏夹加入、移除或排序图纸请求
+type CollectionDrawings struct {
+	CollectionID uint   `json:"collectionId" binding:"required"`     // 收藏夹ID
+	DrawingIDs   []uint `json:"drawingIds" binding:"required,min=1"` // 图纸ID列表，排序时按目标顺序排列
+}
+
+// ShareDrawingCollection 共享或取消共享收藏夹请求
+type ShareDrawingCollection struct {
+	CollectionID uint   `json:"collectionId" binding:"required"`  // 收藏夹ID
+	UserIDs      []uint `json:"userIds" binding:"required,min=1"` // 用户ID列表
+}
+
+// SetDrawingFavorite 收藏或取消收藏图纸请求
+type SetDrawingFavorite struct {
+	DrawingID uint `json:"drawingId" binding:"required"` // 图纸ID
+	Favorite  bool `json:"favorite"`                     // true 加入默认收藏夹，false 移出
+}
+
+// DownloadDrawingCollection 批量下载收藏夹请求
+type DownloadDrawingCollection struct {
+	CollectionID  uint   `json:"collectionId" binding:"required"` // 收藏夹ID
+	AddWatermark  bool   `json:"addWatermark"`                    // 是否添加水印
+	WatermarkText string `json:"watermarkText"`                   // 水印文字
+}
@@ -0,0 +1,33 @@
+package request
+
+// UpdateCompletion 更新完成记录请求
+type UpdateCompletion struct {
+	ID          uint   `json:"id" binding:"required"` // 完成记录ID
+	Note        string `json:"note"`                  // 备注
+	CompletedAt string `json:"completedAt"`           // 完成日期 YYYY-MM-DD
+}
+
+// DeleteCompletion 删除完成记录请求
+type DeleteCompletion struct {
+	ID uint `json:"id" binding:"required"` // 完成记录ID
+}
+
+// ModerateCompletion 审核完成记录请求
+type ModerateCompletion struct {
+	IDs    []uint `json:"ids" binding:"required"`    // 完成记录ID列表
+	Status string `json:"status" binding:"required"` // 审核状态 pending/approved/hidden
+}
+
+// GetCompletionGallery 获取图纸成品墙请求
+type GetCompletionGallery struct {
+	DrawingID uint   `json:"drawingId" binding:"required"` // 图纸ID
+	Status    string `json:"status"`                       // 按审核状态筛选（仅相册创建者或管理员有效）
+	Page      int    `json:"page"`                         // 页码
+	PageSize  int    `json:"pageSize"`                     // 每页大小
+}
+
+// GetMyCompletions 获取我的完成记录请求
+type GetMyCompletions struct {
+	Page     int `json:"page"`     // 页码
+	PageSize int `json:"pageSize"` // 每页大小
+}
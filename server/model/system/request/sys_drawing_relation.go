@@ -0,0 +1,7 @@
+package request
+
+// GetRelatedDrawings 获取关联推荐图纸请求
+type GetRelatedDrawings struct {
+	DrawingID uint `json:"drawingId" binding:"required"` // 图纸ID
+	Limit     int  `json:"limit"`                        // 返回数量，默认10，最多20
+}
@@ -0,0 +1,37 @@
+package request
+
+import "time"
+
+// SubmitDrawings 提交图纸审核请求
+type SubmitDrawings struct {
+	DrawingIDs []uint `json:"drawingIds" binding:"required,min=1"` // 图纸ID列表
+	Comment    string `json:"comment"`                             // 提交说明
+}
+
+// ReviewDrawings 审核图纸请求
+type ReviewDrawings struct {
+	DrawingIDs []uint     `json:"drawingIds" binding:"required,min=1"` // 图纸ID列表
+	Approve    bool       `json:"approve"`                             // 是否通过，驳回时需填写审核意见
+	Comment    string     `json:"comment"`                             // 审核意见
+	PublishAt  *time.Time `json:"publishAt"`                           // 定时发布时间，为空或已过去时立即发布
+}
+
+// ArchiveDrawings 归档或取消归档图纸请求
+type ArchiveDrawings struct {
+	DrawingIDs []uint `json:"drawingIds" binding:"required,min=1"` // 图纸ID列表
+	Archive    bool   `json:"archive"`                             // true 归档，false 取消归档并重新发布
+	Comment    string `json:"comment"`                             // 备注
+}
+
+// GetDrawingReviewQueue 获取相册待审核图纸请求
+type GetDrawingReviewQueue struct {
+	AlbumID  uint   `json:"albumId" binding:"required"` // 相册ID
+	Status   string `json:"status"`                     // 按状态筛选，默认 in_review
+	Page     int    `json:"page"`                       // 页码
+	PageSize int    `json:"pageSize"`                   // 每页大小
+}
+
+// GetDrawingReviews 获取图纸审核记录请求
+type GetDrawingReviews struct {
+	DrawingID uint `json:"drawingId" binding:"required"` // 图纸ID
+}
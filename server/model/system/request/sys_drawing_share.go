@@ -0,0 +1,25 @@
+package request
+
+import "time"
+
+// CreateDrawingShare 创建图纸分享链接请求
+type CreateDrawingShare struct {
+	DrawingID uint       `json:"drawingId" binding:"required"` // 图纸ID
+	Password  string     `json:"password"`                     // 访问密码，为空表示无需密码
+	ExpiresAt *time.Time `json:"expiresAt"`                    // 过期时间，为空表示永不过期
+}
+
+// DeleteDrawingShares 删除图纸分享链接请求
+type DeleteDrawingShares struct {
+	IDs []uint `json:"ids" binding:"required,min=1"` // 分享ID列表
+}
+
+// GetDrawingShares 获取图纸分享链接列表请求
+type GetDrawingShares struct {
+	DrawingID uint `json:"drawingId" binding:"required"` // 图纸ID
+}
+
+// ViewDrawingShare 查看图纸分享请求
+type ViewDrawingShare struct {
+	Password string `json:"password"` // 访问密码
+}
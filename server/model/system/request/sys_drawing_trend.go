@@ -0,0 +1,8 @@
+package request
+
+// GetDrawingFeed 获取个性化图纸推荐流请求
+type GetDrawingFeed struct {
+	TrendingLimit int `json:"trendingLimit"` // 热门图纸数量，默认10，最多50
+	NewLimit      int `json:"newLimit"`      // 新发布图纸数量，默认10，最多50
+	NewDays       int `json:"newDays"`       // 新发布的天数范围，默认14，最多90
+}
@@ -0,0 +1,13 @@
+package request
+
+// GetNotifications 获取站内通知列表请求
+type GetNotifications struct {
+	UnreadOnly bool `json:"unreadOnly"` // 仅未读
+	Page       int  `json:"page"`       // 页码
+	PageSize   int  `json:"pageSize"`   // 每页大小
+}
+
+// MarkNotificationsRead 标记通知已读请求
+type MarkNotificationsRead struct {
+	IDs []uint `json:"ids"` // 通知ID列表，为空表示全部标记已读
+}
@@ -0,0 +1,15 @@
+package request
+
+// GetRecycleBin 获取回收站列表请求
+type GetRecycleBin struct {
+	Type     string `json:"type" binding:"required,oneof=album drawing"` // 条目类型 album/drawing
+	Keyword  string `json:"keyword"`                                     // 按相册标题或图纸名称/序号搜索
+	Page     int    `json:"page"`                                        // 页码
+	PageSize int    `json:"pageSize"`                                    // 每页大小
+}
+
+// RecycleBinItems 恢复或彻底删除回收站条目请求
+type RecycleBinItems struct {
+	Type string `json:"type" binding:"required,oneof=album drawing"` // 条目类型 album/drawing
+	IDs  []uint `json:"ids" binding:"required,min=1"`                // 相册或图纸ID列表
+}
@@ -0,0 +1,13 @@
+package request
+
+import "github.com/google/uuid"
+
+// SearchQuery 全文检索请求
+type SearchQuery struct {
+	Keyword     string    `json:"keyword" binding:"required"` // 检索关键字，中文按相邻两字切分，多个词需全部命中
+	Type        string    `json:"type"`                       // 结果类型 drawing/album，为空时都返回
+	AlbumID     uint      `json:"albumId"`                    // 按相册筛选
+	CreatorUUID uuid.UUID `json:"creatorUUID"`                // 按创建者筛选
+	Page        int       `json:"page"`                       // 页码
+	PageSize    int       `json:"pageSize"`                   // 每页大小，默认20，最多100
+}
@@ -0,0 +1,12 @@
+package request
+
+// GetUserDrawingLedger 获取用户图纸台账请求
+type GetUserDrawingLedger struct {
+	Page            int    `json:"page" form:"page"`                       // 页码
+	PageSize        int    `json:"pageSize" form:"pageSize"`               // 每页大小
+	Sort            string `json:"sort" form:"sort"`                       // 排序方式 acquired/lastDownload/name/album，默认 acquired
+	SortOrder       string `json:"sortOrder" form:"sortOrder"`             // 排序方向 asc/desc，时间默认 desc，名称与相册默认 asc
+	AlbumID         uint   `json:"albumId" form:"albumId"`                 // 按相册筛选
+	Keyword         string `json:"keyword" form:"keyword"`                 // 按序号或名称搜索
+	NeverDownloaded bool   `json:"neverDownloaded" form:"neverDownloaded"` // 只看从未下载过的图纸
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/google/uuid"
+)
+
+// AccessRequestResponse 访问申请响应结构
+type AccessRequestResponse struct {
+	ID           uint       `json:"id"`           // 申请ID
+	TargetType   string     `json:"targetType"`   // 申请对象类型
+	AlbumID      uint       `json:"albumId"`      // 相册ID
+	AlbumTitle   string     `json:"albumTitle"`   // 相册标题
+	DrawingID    uint       `json:"drawingId"`    // 图纸ID
+	DrawingName  string     `json:"drawingName"`  // 图纸名称
+	SerialNumber string     `json:"serialNumber"` // 图纸序号
+	User         UserInfo   `json:"user"`         // 申请人
+	Role         string     `json:"role"`         // 申请角色
+	Message      string     `json:"message"`      // 申请留言
+	Status       string     `json:"status"`       // 状态
+	DecidedBy    *uuid.UUID `json:"decidedBy"`    // 处理人UUID
+	DecidedAt    *time.Time `json:"decidedAt"`    // 处理时间
+	DecisionNote string     `json:"decisionNote"` // 处理备注
+	CreatedAt    time.Time  `json:"createdAt"`    // 申请时间
+}
+
+// ToAccessRequestResponses 批量转换访问申请
+func ToAccessRequestResponses(requests []system.SysAccessRequest) []AccessRequestResponse {
+	result := make([]AccessRequestResponse, 0, len(requests))
+	for _, r := range requests {
+		result = append(result, AccessRequestResponse{
+			ID:           r.ID,
+			TargetType:   r.TargetType,
+			AlbumID:      r.AlbumID,
+			AlbumTitle:   r.Album.Title,
+			DrawingID:    r.DrawingID,
+			DrawingName:  r.Drawing.Name,
+			SerialNumber: r.Drawing.SerialNumber,
+			User:         ToUserInfo(r.User),
+			Role:         r.Role,
+			Message:      r.Message,
+			Status:       r.Status,
+			DecidedBy:    r.DecidedBy,
+			DecidedAt:    r.DecidedAt,
+			DecisionNote: r.DecisionNote,
+			CreatedAt:    r.CreatedAt,
+		})
+	}
+	return result
+}
@@ -10,18 +10,21 @@ import (
 
 // AlbumResponse 相册响应结构
 type AlbumResponse struct {
-	ID            uint       `json:"id" example:"相册ID"`
-	CreatorUUID   uuid.UUID  `json:"creatorUUID" example:"创建者UUID"`
-	Title         string     `json:"title" example:"相册标题"`
-	CoverImageURL string     `json:"coverImageURL" example:"封面图URL"`
-	Description   string     `json:"description" example:"相册描述"`
-	Status        int        `json:"status" example:"相册状态"`
-	CreatedAt     time.Time  `json:"createdAt" example:"创建时间"`
-	UpdatedAt     time.Time  `json:"updatedAt" example:"更新时间"`
-	Creator       UserInfo   `json:"creator" example:"创建者信息"`
-	AdminUsers    []UserInfo `json:"adminUsers" example:"管理员列表"`
-	Progress      int        `json:"progress" example:"可下载图纸数"`
-	Total         int        `json:"total" example:"图纸总数"`
+	ID            uint                 `json:"id" example:"相册ID"`
+	CreatorUUID   uuid.UUID            `json:"creatorUUID" example:"创建者UUID"`
+	Title         string               `json:"title" example:"相册标题"`
+	CoverImageURL string               `json:"coverImageURL" example:"封面图URL"`
+	Description   string               `json:"description" example:"相册描述"`
+	Status        int                  `json:"status" example:"相册状态"`
+	Position      int                  `json:"position" example:"排序位置"`
+	CreatedAt     time.Time            `json:"createdAt" example:"创建时间"`
+	UpdatedAt     time.Time            `json:"updatedAt" example:"更新时间"`
+	Creator       UserInfo             `json:"creator" example:"创建者信息"`
+	AdminUsers    []UserInfo           `json:"adminUsers" example:"管理员列表"`
+	Progress      int                  `json:"progress" example:"可下载图纸数"`
+	Total         int                  `json:"total" example:"图纸总数"`
+	Renditions    RenditionSet         `json:"renditions" example:"封面衍生图"`
+	Fields        []AlbumFieldResponse `json:"fields" example:"自定义字段定义"`
 }
 
 // UserInfo 用户信息响应结构
@@ -48,8 +51,11 @@ func ToAlbumResponse(album system.SysAlbum) AlbumResponse {
 		CoverImageURL: album.CoverImageURL,
 		Description:   album.Description,
 		Status:        album.Status,
+		Position:      album.Position,
 		CreatedAt:     album.CreatedAt,
 		UpdatedAt:     album.UpdatedAt,
+		Renditions:    BuildRenditionSet(album.Renditions, system.RenditionKindCover, album.CoverImageURL),
+		Fields:        ToAlbumFieldResponses(album.Fields),
 	}
 
 	// 转换创建者信息
@@ -0,0 +1,65 @@
+package response
+
+import (
+	"encoding/json"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+// AlbumFieldResponse 相册自定义字段定义
+type AlbumFieldResponse struct {
+	ID       uint     `json:"id"`       // 字段ID
+	Key      string   `json:"key"`      // 字段标识
+	Label    string   `json:"label"`    // 字段名称
+	Type     string   `json:"type"`     // 字段类型
+	Required bool     `json:"required"` // 是否必填
+	Options  []string `json:"options"`  // 枚举选项
+	Min      *float64 `json:"min"`      // 最小值或最小长度
+	Max      *float64 `json:"max"`      // 最大值或最大长度
+	Pattern  string   `json:"pattern"`  // 文本校验正则
+	Sort     int      `json:"sort"`     // 排序
+}
+
+// ToAlbumFieldResponses 转换相册自定义字段定义
+func ToAlbumFieldResponses(fields []system.SysAlbumField) []AlbumFieldResponse {
+	result := make([]AlbumFieldResponse, 0, len(fields))
+	for _, field := range fields {
+		item := AlbumFieldResponse{
+			ID:       field.ID,
+			Key:      field.Key,
+			Label:    field.Label,
+			Type:     field.Type,
+			Required: field.Required,
+			Min:      field.Min,
+			Max:      field.Max,
+			Pattern:  field.Pattern,
+			Sort:     field.Sort,
+		}
+		if field.Options != "" {
+			_ = json.Unmarshal([]byte(field.Options), &item.Options)
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// BuildFieldValues 将图纸自定义字段值转换为 字段标识 -> 值，需预加载 FieldValues.Field
+func BuildFieldValues(values []system.SysDrawingFieldValue) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		if value.Field.Key == "" {
+			continue
+		}
+		switch value.Field.Type {
+		case system.AlbumFieldTypeBoolean:
+			result[value.Field.Key] = value.NumberValue != nil && *value.NumberValue != 0
+		case system.AlbumFieldTypeNumber, system.AlbumFieldTypeInteger:
+			if value.NumberValue != nil {
+				result[value.Field.Key] = *value.NumberValue
+			}
+		default:
+			result[value.Field.Key] = value.TextValue
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package response
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+// CreateAlbumInviteResponse 创建邀请链接响应，令牌明文仅返回一次
+type CreateAlbumInviteResponse struct {
+	Invite system.SysAlbumInvite `json:"invite"` // 邀请信息
+	Token  string                `json:"token"`  // 邀请令牌
+}
+
+// RedeemAlbumInviteResponse 兑换邀请链接响应
+type RedeemAlbumInviteResponse struct {
+	AlbumID    uint   `json:"albumId"`    // 相册ID
+	AlbumTitle string `json:"albumTitle"` // 相册标题
+	Role       string `json:"role"`       // 获得的角色
+}
+
+// InviteRedemptionResponse 邀请链接兑换记录响应结构
+type InviteRedemptionResponse struct {
+	ID        uint      `json:"id"`        // 记录ID
+	InviteID  uint      `json:"inviteId"`  // 邀请ID
+	User      UserInfo  `json:"user"`      // 兑换用户
+	Email     string    `json:"email"`     // 兑换时的邮箱
+	IP        string    `json:"ip"`        // 兑换IP
+	Role      string    `json:"role"`      // 授予的角色
+	CreatedAt time.Time `json:"createdAt"` // 兑换时间
+}
+
+// ToInviteRedemptionResponses 批量转换兑换记录
+func ToInviteRedemptionResponses(list []system.SysAlbumInviteRedemption) []InviteRedemptionResponse {
+	result := make([]InviteRedemptionResponse, 0, len(list))
+	for _, r := range list {
+		result = append(result, InviteRedemptionResponse{
+			ID:        r.ID,
+			InviteID:  r.InviteID,
+			User:      ToUserInfo(r.User),
+			Email:     r.Email,
+			IP:        r.IP,
+			Role:      r.Role,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return result
+}
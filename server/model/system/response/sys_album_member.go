@@ -0,0 +1,108 @@
+package response
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/google/uuid"
+)
+
+// 访问权限来源
+const (
+	AccessSourceDrawingCreator = "drawing_creator" // 图纸创建者
+	AccessSourceAlbumCreator   = "album_creator"   // 相册创建者
+	AccessSourceAlbumAdmin     = "album_admin"     // 相册管理员
+	AccessSourceAlbumMember    = "album_member"    // 相册成员
+	AccessSourceDrawingGrant   = "drawing_grant"   // 图纸单独授权
+)
+
+// AlbumMemberResponse 相册成员响应结构
+type AlbumMemberResponse struct {
+	ID        uint       `json:"id"`        // 成员记录ID
+	AlbumID   uint       `json:"albumId"`   // 相册ID
+	User      UserInfo   `json:"user"`      // 成员信息
+	Role      string     `json:"role"`      // 角色
+	StartsAt  *time.Time `json:"startsAt"`  // 生效时间
+	ExpiresAt *time.Time `json:"expiresAt"` // 过期时间
+	Expired   bool       `json:"expired"`   // 是否已过期
+	GrantedBy uuid.UUID  `json:"grantedBy"` // 授权人UUID
+	CreatedAt time.Time  `json:"createdAt"` // 加入时间
+}
+
+// ToAlbumMemberResponses 批量转换相册成员
+func ToAlbumMemberResponses(members []system.SysAlbumMember) []AlbumMemberResponse {
+	now := time.Now()
+	result := make([]AlbumMemberResponse, 0, len(members))
+	for _, member := range members {
+		result = append(result, AlbumMemberResponse{
+			ID:        member.ID,
+			AlbumID:   member.AlbumID,
+			User:      ToUserInfo(member.User),
+			Role:      member.Role,
+			StartsAt:  member.StartsAt,
+			ExpiresAt: member.ExpiresAt,
+			Expired:   !member.Active(now),
+			GrantedBy: member.GrantedBy,
+			CreatedAt: member.CreatedAt,
+		})
+	}
+	return result
+}
+
+// AccessReason 单条访问权限来源
+type AccessReason struct {
+	Source    string     `json:"source"`    // 来源 drawing_creator/album_creator/album_admin/album_member/drawing_grant
+	Role      string     `json:"role"`      // 该来源授予的角色
+	StartsAt  *time.Time `json:"startsAt"`  // 生效时间
+	ExpiresAt *time.Time `json:"expiresAt"` // 过期时间
+	Active    bool       `json:"active"`    // 当前是否有效
+}
+
+// DrawingAccessEntry 用户对图纸的访问权限及其来源
+type DrawingAccessEntry struct {
+	User          UserInfo       `json:"user"`          // 用户信息
+	EffectiveRole string         `json:"effectiveRole"` // 生效角色（各有效来源中的最高角色），为空表示无有效权限
+	Reasons       []AccessReason `json:"reasons"`       // 权限来源
+}
+
+// ToUserInfo 转换用户信息
+func ToUserInfo(user system.SysUser) UserInfo {
+	return UserInfo{
+		ID:        user.ID,
+		UUID:      user.UUID,
+		Username:  user.Username,
+		NickName:  user.NickName,
+		HeaderImg: user.HeaderImg,
+	}
+}
+
+// DrawingGrantResponse 图纸授权响应结构
+type DrawingGrantResponse struct {
+	ID        uint       `json:"id"`        // 授权记录ID
+	DrawingID uint       `json:"drawingId"` // 图纸ID
+	User      UserInfo   `json:"user"`      // 被授权用户
+	StartsAt  *time.Time `json:"startsAt"`  // 生效时间
+	ExpiresAt *time.Time `json:"expiresAt"` // 过期时间
+	Active    bool       `json:"active"`    // 当前是否有效
+	GrantedBy uuid.UUID  `json:"grantedBy"` // 授权人UUID
+	CreatedAt time.Time  `json:"createdAt"` // 授权时间
+}
+
+// ToDrawingGrantResponses 批量转换图纸授权
+func ToDrawingGrantResponses(grants []system.SysDrawingGrant) []DrawingGrantResponse {
+	now := time.Now()
+	result := make([]DrawingGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		result = append(result, DrawingGrantResponse{
+			ID:        grant.ID,
+			DrawingID: grant.DrawingID,
+			User:      ToUserInfo(grant.User),
+			StartsAt:  grant.StartsAt,
+			ExpiresAt: grant.ExpiresAt,
+			Active:    grant.Active(now),
+			GrantedBy: grant.GrantedBy,
+			CreatedAt: grant.CreatedAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,9 @@
+package response
+
+import "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+
+// AlbumSerialSchemeResponse 相册编号规则响应，未配置时 Scheme 为空
+type AlbumSerialSchemeResponse struct {
+	Scheme *system.SysAlbumSerialScheme `json:"scheme"` // 编号规则
+	Next   string                       `json:"next"`   // 下一个将分配的序号（仅预览，不占用）
+}
@@ -0,0 +1,36 @@
+package response
+
+// ColorShortfall 单个色号的缺口
+type ColorShortfall struct {
+	ColorCode string `json:"colorCode"` // 色号
+	Required  int    `json:"required"`  // 图纸用量
+	Owned     int    `json:"owned"`     // 库存数量
+	Missing   int    `json:"missing"`   // 缺少数量
+}
+
+// DrawingMatchResult 图纸库存匹配结果
+type DrawingMatchResult struct {
+	DrawingID      uint             `json:"drawingId"`      // 图纸ID
+	AlbumID        uint             `json:"albumId"`        // 相册ID
+	AlbumTitle     string           `json:"albumTitle"`     // 相册标题
+	SerialNumber   string           `json:"serialNumber"`   // 图纸序号
+	Name           string           `json:"name"`           // 图纸名称
+	PosterImageURL string           `json:"posterImageURL"` // 海报图URL
+	HasBOM         bool             `json:"hasBOM"`         // 是否录入了色号用量
+	CanMake        bool             `json:"canMake"`        // 库存是否足够
+	MissingTotal   int              `json:"missingTotal"`   // 缺少豆子总数
+	Shortfalls     []ColorShortfall `json:"shortfalls"`     // 缺口明细
+}
+
+// DrawingMatchListResponse 图纸库存匹配列表
+type DrawingMatchListResponse struct {
+	List  []DrawingMatchResult `json:"list"`  // 匹配结果
+	Total int64                `json:"total"` // 总数
+}
+
+// ImportInventoryResult CSV导入结果
+type ImportInventoryResult struct {
+	Imported int      `json:"imported"` // 成功导入行数
+	Skipped  int      `json:"skipped"`  // 跳过行数
+	Errors   []string `json:"errors"`   // 跳过原因
+}
@@ -0,0 +1,27 @@
+package response
+
+// DownloadBucket 下载量时间分桶
+type DownloadBucket struct {
+	Bucket      string `json:"bucket"`      // 分桶标识：日期、周一日期或月份
+	Downloads   int64  `json:"downloads"`   // 下载次数
+	Watermarked int64  `json:"watermarked"` // 带水印下载次数
+}
+
+// TopDrawing 下载量排行中的图纸
+type TopDrawing struct {
+	DrawingID    uint   `json:"drawingId"`    // 图纸ID
+	AlbumID      uint   `json:"albumId"`      // 相册ID
+	SerialNumber string `json:"serialNumber"` // 图纸序号
+	Name         string `json:"name"`         // 图纸名称
+	Downloads    int64  `json:"downloads"`    // 下载次数
+	Watermarked  int64  `json:"watermarked"`  // 带水印下载次数
+}
+
+// DownloadSummary 下载概况
+type DownloadSummary struct {
+	Downloads         int64   `json:"downloads"`         // 下载次数
+	Watermarked       int64   `json:"watermarked"`       // 带水印下载次数
+	Raw               int64   `json:"raw"`               // 原图下载次数
+	WatermarkRatio    float64 `json:"watermarkRatio"`    // 带水印下载占比
+	UniqueDownloaders int64   `json:"uniqueDownloaders"` // 去重下载人数
+}
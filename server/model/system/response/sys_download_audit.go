@@ -0,0 +1,24 @@
+package response
+
+import "github.com/google/uuid"
+
+// DownloadAudit 下载审计记录
+type DownloadAudit struct {
+	ID            uint      `json:"id"`            // 记录ID
+	DownloadAt    int64     `json:"downloadAt"`    // 下载时间戳
+	UserUUID      uuid.UUID `json:"userUUID"`      // 下载用户UUID
+	Username      string    `json:"username"`      // 下载用户名
+	NickName      string    `json:"nickName"`      // 下载用户昵称
+	DrawingID     uint      `json:"drawingId"`     // 图纸ID
+	DrawingName   string    `json:"drawingName"`   // 图纸名称
+	SerialNumber  string    `json:"serialNumber"`  // 图纸序号
+	AlbumID       uint      `json:"albumId"`       // 相册ID
+	Channel       string    `json:"channel"`       // 下载渠道
+	Files         []string  `json:"files"`         // 实际提供的文件列表
+	Watermarked   bool      `json:"watermarked"`   // 是否带水印
+	WatermarkText string    `json:"watermarkText"` // 水印文字
+	WatermarkHash string    `json:"watermarkHash"` // 水印文字与策略哈希
+	ClientIP      string    `json:"clientIP"`      // 客户端IP
+	UserAgent     string    `json:"userAgent"`     // 客户端UA
+	RequestID     string    `json:"requestId"`     // 请求ID
+}
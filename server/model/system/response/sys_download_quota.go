@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+// DownloadQuotaUsage 用户在某条额度规则当前周期的用量
+type DownloadQuotaUsage struct {
+	Rule         string    `json:"rule"`         // 规则名称
+	Window       string    `json:"window"`       // 计数周期 hour/day
+	AlbumID      uint      `json:"albumId"`      // 按相册计数时的相册ID，0 表示全部相册合计
+	Downloads    int64     `json:"downloads"`    // 已下载图纸数
+	Bytes        int64     `json:"bytes"`        // 已下载字节数
+	MaxDownloads int64     `json:"maxDownloads"` // 图纸数上限，0 表示不限
+	MaxBytes     int64     `json:"maxBytes"`     // 字节数上限，0 表示不限
+	ResetAt      time.Time `json:"resetAt"`      // 额度重置时间
+}
+
+// DownloadQuotaExceeded 下载额度用完时返回的重试信息
+type DownloadQuotaExceeded struct {
+	Rule       string    `json:"rule"`       // 触发限制的规则名称
+	AlbumID    uint      `json:"albumId"`    // 按相册计数时的相册ID
+	RetryAfter int64     `json:"retryAfter"` // 距离额度重置的秒数
+	ResetAt    time.Time `json:"resetAt"`    // 额度重置时间
+}
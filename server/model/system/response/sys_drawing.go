@@ -2,6 +2,7 @@ package response
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/google/uuid"
@@ -9,17 +10,24 @@ import (
 
 // DrawingResponse 图纸响应结构体
 type DrawingResponse struct {
-	ID                 uint      `json:"id"`                 // 图纸ID
-	AlbumID            uint      `json:"albumId"`            // 相册ID
-	SerialNumber       string    `json:"serialNumber"`       // 图纸序号
-	Name               string    `json:"name"`               // 图纸名称
-	BeanQuantity       *int      `json:"beanQuantity"`       // 豆量
-	PosterImageURL     string    `json:"posterImageURL"`     // 海报图URL
-	DrawingURLs        []string  `json:"drawingURLs"`        // 图纸文件URLs
-	CreatorUUID        uuid.UUID `json:"creatorUUID"`        // 创建者UUID
-	AllowedMemberUUIDs []string  `json:"allowedMemberUUIDs"` // 允许下载的成员UUIDs
-	CreatedAt          string    `json:"createdAt"`          // 创建时间
-	UpdatedAt          string    `json:"updatedAt"`          // 更新时间
+	ID                 uint                     `json:"id"`                 // 图纸ID
+	AlbumID            uint                     `json:"albumId"`            // 相册ID
+	SerialNumber       string                   `json:"serialNumber"`       // 图纸序号
+	Name               string                   `json:"name"`               // 图纸名称
+	BeanQuantity       *int                     `json:"beanQuantity"`       // 豆量
+	PosterImageURL     string                   `json:"posterImageURL"`     // 海报图URL
+	DrawingURLs        []string                 `json:"drawingURLs"`        // 图纸文件URLs
+	CreatorUUID        uuid.UUID                `json:"creatorUUID"`        // 创建者UUID
+	AllowedMemberUUIDs []string                 `json:"allowedMemberUUIDs"` // 允许下载的成员UUIDs
+	Colors             []system.SysDrawingColor `json:"colors"`             // 色号用量清单
+	Renditions         RenditionSet             `json:"renditions"`         // 海报衍生图
+	PatternRenditions  map[string]RenditionSet  `json:"patternRenditions"`  // 图纸文件衍生图（按原图URL）
+	Fields             map[string]interface{}   `json:"fields"`             // 自定义字段值
+	Status             string                   `json:"status"`             // 发布状态
+	PublishAt          *time.Time               `json:"publishAt"`          // 发布时间
+	Position           int                      `json:"position"`           // 相册内排序位置
+	CreatedAt          string                   `json:"createdAt"`          // 创建时间
+	UpdatedAt          string                   `json:"updatedAt"`          // 更新时间
 	Album              struct {
 		ID    uint   `json:"id"`    // 相册ID
 		Title string `json:"title"` // 相册标题
@@ -29,6 +37,18 @@ type DrawingResponse struct {
 		Username string    `json:"username"` // 用户名
 		NickName string    `json:"nickName"` // 昵称
 	} `json:"creator"` // 创建者信息
+	Duplicates []SimilarDrawing `json:"duplicates,omitempty"` // 疑似重复图纸（仅创建时返回）
+}
+
+// SimilarDrawing 相似图纸
+type SimilarDrawing struct {
+	DrawingID      uint   `json:"drawingId"`      // 图纸ID
+	AlbumID        uint   `json:"albumId"`        // 相册ID
+	AlbumTitle     string `json:"albumTitle"`     // 相册标题
+	SerialNumber   string `json:"serialNumber"`   // 图纸序号
+	Name           string `json:"name"`           // 图纸名称
+	PosterImageURL string `json:"posterImageURL"` // 海报图URL
+	Distance       int    `json:"distance"`       // 汉明距离，越小越相似
 }
 
 // DrawingListResponse 图纸列表响应结构体
@@ -70,6 +90,13 @@ func ToDrawingResponse(drawing *system.SysDrawing) DrawingResponse {
 		DrawingURLs:        drawingURLs,
 		CreatorUUID:        drawing.CreatorUUID,
 		AllowedMemberUUIDs: allowedMemberUUIDs,
+		Colors:             drawing.Colors,
+		Renditions:         BuildRenditionSet(drawing.Renditions, system.RenditionKindPoster, drawing.PosterImageURL),
+		PatternRenditions:  BuildPatternRenditions(drawing.Renditions, drawingURLs),
+		Fields:             BuildFieldValues(drawing.FieldValues),
+		Status:             drawing.Status,
+		PublishAt:          drawing.PublishAt,
+		Position:           drawing.Position,
 		CreatedAt:          drawing.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:          drawing.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
@@ -102,3 +129,12 @@ func ToDrawingListResponse(drawings []*system.SysDrawing, total int64) DrawingLi
 		Total:    total,
 	}
 }
+
+// TransferredDrawing 移动或复制后的图纸序号变化
+type TransferredDrawing struct {
+	SourceID       uint   `json:"sourceId"`       // 原图纸ID
+	DrawingID      uint   `json:"drawingId"`      // 目标图纸ID（移动时与原图纸ID相同）
+	SerialNumber   string `json:"serialNumber"`   // 目标相册中的序号
+	OriginalSerial string `json:"originalSerial"` // 原序号
+	Renamed        bool   `json:"renamed"`        // 是否因冲突更换了序号
+}
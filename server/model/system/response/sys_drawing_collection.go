@@ -0,0 +1,22 @@
+package response
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+// DrawingCollectionResponse 收藏夹
+type DrawingCollectionResponse struct {
+	system.SysDrawingCollection
+	DrawingCount int64      `json:"drawingCount"` // 当前用户可访问的图纸数
+	Shared       bool       `json:"shared"`       // 是否为他人共享给当前用户的收藏夹（只读）
+	SharedWith   []UserInfo `json:"sharedWith"`   // 共享对象，仅所有者可见
+}
+
+// CollectionDrawing 收藏夹中的图纸
+type CollectionDrawing struct {
+	DrawingResponse
+	Position int       `json:"position"` // 收藏夹内排序位置
+	AddedAt  time.Time `json:"addedAt"`  // 加入收藏夹时间
+}
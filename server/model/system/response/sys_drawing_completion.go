@@ -0,0 +1,54 @@
+package response
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/google/uuid"
+)
+
+// CompletionResponse 完成记录响应结构
+type CompletionResponse struct {
+	ID           uint      `json:"id"`           // 完成记录ID
+	DrawingID    uint      `json:"drawingId"`    // 图纸ID
+	AlbumID      uint      `json:"albumId"`      // 相册ID
+	SerialNumber string    `json:"serialNumber"` // 图纸序号
+	DrawingName  string    `json:"drawingName"`  // 图纸名称
+	UserUUID     uuid.UUID `json:"userUUID"`     // 用户UUID
+	User         UserInfo  `json:"user"`         // 用户信息
+	PhotoURL     string    `json:"photoURL"`     // 成品照片URL
+	Note         string    `json:"note"`         // 备注
+	CompletedAt  string    `json:"completedAt"`  // 完成日期
+	Status       string    `json:"status"`       // 审核状态
+	CreatedAt    time.Time `json:"createdAt"`    // 创建时间
+}
+
+// ToCompletionResponse 转换完成记录
+func ToCompletionResponse(completion system.SysDrawingCompletion) CompletionResponse {
+	response := CompletionResponse{
+		ID:           completion.ID,
+		DrawingID:    completion.DrawingID,
+		AlbumID:      completion.AlbumID,
+		SerialNumber: completion.Drawing.SerialNumber,
+		DrawingName:  completion.Drawing.Name,
+		UserUUID:     completion.UserUUID,
+		PhotoURL:     completion.PhotoURL,
+		Note:         completion.Note,
+		CompletedAt:  completion.CompletedAt.Format("2006-01-02"),
+		Status:       completion.Status,
+		CreatedAt:    completion.CreatedAt,
+	}
+	if completion.User.ID != 0 {
+		response.User = ToUserInfo(completion.User)
+	}
+	return response
+}
+
+// ToCompletionResponses 批量转换完成记录
+func ToCompletionResponses(completions []system.SysDrawingCompletion) []CompletionResponse {
+	result := make([]CompletionResponse, 0, len(completions))
+	for _, completion := range completions {
+		result = append(result, ToCompletionResponse(completion))
+	}
+	return result
+}
@@ -0,0 +1,8 @@
+package response
+
+// RelatedDrawing 关联推荐图纸
+type RelatedDrawing struct {
+	DrawingResponse
+	Score       float64 `json:"score"`       // 余弦相似度
+	CoDownloads int64   `json:"coDownloads"` // 同时下载两张图纸的用户数
+}
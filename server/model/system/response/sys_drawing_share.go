@@ -0,0 +1,69 @@
+package response
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+// DrawingShareResponse 图纸分享链接响应结构
+type DrawingShareResponse struct {
+	ID           uint       `json:"id"`           // 分享ID
+	DrawingID    uint       `json:"drawingId"`    // 图纸ID
+	Token        string     `json:"token"`        // 分享令牌
+	HasPassword  bool       `json:"hasPassword"`  // 是否需要密码
+	ExpiresAt    *time.Time `json:"expiresAt"`    // 过期时间
+	Expired      bool       `json:"expired"`      // 是否已过期
+	ViewCount    int64      `json:"viewCount"`    // 查看次数
+	LastViewedAt *time.Time `json:"lastViewedAt"` // 最后查看时间
+	CreatedAt    time.Time  `json:"createdAt"`    // 创建时间
+}
+
+// ToDrawingShareResponse 转换图纸分享链接
+func ToDrawingShareResponse(share system.SysDrawingShare) DrawingShareResponse {
+	return DrawingShareResponse{
+		ID:           share.ID,
+		DrawingID:    share.DrawingID,
+		Token:        share.Token,
+		HasPassword:  share.PasswordHash != "",
+		ExpiresAt:    share.ExpiresAt,
+		Expired:      share.ExpiresAt != nil && !share.ExpiresAt.After(time.Now()),
+		ViewCount:    share.ViewCount,
+		LastViewedAt: share.LastViewedAt,
+		CreatedAt:    share.CreatedAt,
+	}
+}
+
+// ToDrawingShareResponses 批量转换图纸分享链接
+func ToDrawingShareResponses(list []system.SysDrawingShare) []DrawingShareResponse {
+	result := make([]DrawingShareResponse, 0, len(list))
+	for _, share := range list {
+		result = append(result, ToDrawingShareResponse(share))
+	}
+	return result
+}
+
+// DrawingShareView 图纸公开分享页数据，仅包含海报信息，不含图纸文件
+type DrawingShareView struct {
+	Name           string       `json:"name"`           // 图纸名称
+	SerialNumber   string       `json:"serialNumber"`   // 图纸序号
+	BeanQuantity   *int         `json:"beanQuantity"`   // 豆量
+	PosterImageURL string       `json:"posterImageURL"` // 海报图URL
+	Poster         RenditionSet `json:"poster"`         // 海报衍生图
+	AlbumTitle     string       `json:"albumTitle"`     // 相册标题
+	ViewCount      int64        `json:"viewCount"`      // 查看次数
+}
+
+// ToDrawingShareView 转换为公开分享页数据
+func ToDrawingShareView(share system.SysDrawingShare) DrawingShareView {
+	drawing := share.Drawing
+	return DrawingShareView{
+		Name:           drawing.Name,
+		SerialNumber:   drawing.SerialNumber,
+		BeanQuantity:   drawing.BeanQuantity,
+		PosterImageURL: drawing.PosterImageURL,
+		Poster:         BuildRenditionSet(drawing.Renditions, system.RenditionKindPoster, drawing.PosterImageURL),
+		AlbumTitle:     drawing.Album.Title,
+		ViewCount:      share.ViewCount,
+	}
+}
@@ -0,0 +1,14 @@
+package response
+
+// TrendingDrawing 热门图纸
+type TrendingDrawing struct {
+	DrawingResponse
+	Score     float64 `json:"score"`     // 热度分
+	Downloads int64   `json:"downloads"` // 统计窗口内下载次数
+}
+
+// DrawingFeed 个性化图纸推荐流，均为用户可访问且尚未下载过的图纸
+type DrawingFeed struct {
+	Trending []TrendingDrawing `json:"trending"` // 热门图纸
+	Newest   []DrawingResponse `json:"newest"`   // 新发布图纸（不含已在热门中出现的）
+}
@@ -0,0 +1,35 @@
+package response
+
+import "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+
+// RenditionSet 衍生图地址，规格 -> 格式 -> URL，如 renditions["thumb"]["jpeg"]
+type RenditionSet map[string]map[string]string
+
+// BuildRenditionSet 汇总指定来源图片的衍生图，来源已变更的旧衍生图会被忽略
+func BuildRenditionSet(renditions []system.SysImageRendition, kind string, sourceURL string) RenditionSet {
+	set := RenditionSet{}
+	if sourceURL == "" {
+		return set
+	}
+	for _, r := range renditions {
+		if r.Kind != kind || r.SourceURL != sourceURL {
+			continue
+		}
+		if set[r.Size] == nil {
+			set[r.Size] = map[string]string{}
+		}
+		set[r.Size][r.Format] = r.URL
+	}
+	return set
+}
+
+// BuildPatternRenditions 按图纸文件URL汇总衍生图
+func BuildPatternRenditions(renditions []system.SysImageRendition, drawingURLs []string) map[string]RenditionSet {
+	sets := make(map[string]RenditionSet)
+	for _, u := range drawingURLs {
+		if set := BuildRenditionSet(renditions, system.RenditionKindPattern, u); len(set) > 0 {
+			sets[u] = set
+		}
+	}
+	return sets
+}
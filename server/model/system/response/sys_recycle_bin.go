@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+// RecycleBinItem 回收站条目
+type RecycleBinItem struct {
+	ID           uint       `json:"id"`                     // 相册或图纸ID
+	Type         string     `json:"type"`                   // 条目类型 album/drawing
+	Title        string     `json:"title"`                  // 相册标题或图纸名称
+	SerialNumber string     `json:"serialNumber,omitempty"` // 图纸序号
+	AlbumID      uint       `json:"albumId"`                // 相册ID
+	AlbumTitle   string     `json:"albumTitle"`             // 相册标题
+	DrawingCount int64      `json:"drawingCount"`           // 随相册一起删除的图纸数
+	DeletedAt    time.Time  `json:"deletedAt"`              // 删除时间
+	PurgeAt      *time.Time `json:"purgeAt"`                // 预计彻底删除时间，为空表示不自动清理
+}
@@ -0,0 +1,34 @@
+package response
+
+// SearchHit 全文检索命中项
+type SearchHit struct {
+	Type       string            `json:"type"`              // 结果类型 drawing/album
+	ID         uint              `json:"id"`                // 图纸ID或相册ID
+	Score      float64           `json:"score"`             // 相关度得分
+	Highlights map[string]string `json:"highlights"`        // 命中字段的高亮片段，命中部分以 <em> 包裹，其余内容已做 HTML 转义
+	Drawing    *DrawingResponse  `json:"drawing,omitempty"` // 图纸信息
+	Album      *AlbumResponse    `json:"album,omitempty"`   // 相册信息
+}
+
+// SearchFacet 分面统计项
+type SearchFacet struct {
+	Value string `json:"value"` // 相册ID或创建者UUID
+	Label string `json:"label"` // 相册标题或创建者昵称
+	Count int    `json:"count"` // 命中数
+}
+
+// SearchFacets 检索结果按相册与创建者的分面统计
+// 相册分面不受相册筛选影响、创建者分面不受创建者筛选影响，便于切换筛选条件
+type SearchFacets struct {
+	Albums   []SearchFacet `json:"albums"`
+	Creators []SearchFacet `json:"creators"`
+}
+
+// SearchResult 全文检索结果
+type SearchResult struct {
+	List     []SearchHit  `json:"list"`
+	Total    int64        `json:"total"`
+	Page     int          `json:"page"`
+	PageSize int          `json:"pageSize"`
+	Facets   SearchFacets `json:"facets"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// UserDrawingLedgerItem 用户图纸台账条目
+type UserDrawingLedgerItem struct {
+	ID               uint       `json:"id"`               // 图纸ID
+	SerialNumber     string     `json:"serialNumber"`     // 图纸序号
+	Name             string     `json:"name"`             // 图纸名称
+	AlbumID          uint       `json:"albumId"`          // 相册ID
+	AlbumTitle       string     `json:"albumTitle"`       // 相册标题
+	AcquisitionTime  time.Time  `json:"acquisitionTime"`  // 获得时间：首次下载时间，未下载时为图纸创建时间
+	LastDownloadTime *time.Time `json:"lastDownloadTime"` // 最后下载时间，未下载时为空
+	Completed        bool       `json:"completed"`        // 是否已完成
+	CompletionCount  int        `json:"completionCount"`  // 完成次数
+	LastCompletedAt  string     `json:"lastCompletedAt"`  // 最近完成日期
+}
@@ -0,0 +1,39 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+)
+
+// 访问申请状态
+const (
+	AccessRequestStatusPending  = "pending"  // 待处理
+	AccessRequestStatusApproved = "approved" // 已通过
+	AccessRequestStatusRejected = "rejected" // 已拒绝
+)
+
+// SysAccessRequest 图纸或相册访问申请表
+type SysAccessRequest struct {
+	global.GVA_MODEL
+	UserID       uint       `json:"userId" gorm:"index;comment:申请人ID"`                                                // 申请人ID
+	UserUUID     uuid.UUID  `json:"userUUID" gorm:"index;comment:申请人UUID"`                                            // 申请人UUID
+	TargetType   string     `json:"targetType" gorm:"size:16;comment:申请对象类型 album/drawing"`                           // 申请对象类型
+	AlbumID      uint       `json:"albumId" gorm:"index;comment:相册ID"`                                                // 相册ID（申请图纸时为图纸所在相册）
+	DrawingID    uint       `json:"drawingId" gorm:"index;comment:图纸ID"`                                              // 图纸ID（申请相册时为0）
+	Role         string     `json:"role" gorm:"size:16;comment:申请角色"`                                                 // 申请角色（相册）
+	Message      string     `json:"message" gorm:"size:500;comment:申请留言"`                                             // 申请留言
+	Status       string     `json:"status" gorm:"size:16;index;default:pending;comment:状态 pending/approved/rejected"` // 状态
+	DecidedBy    *uuid.UUID `json:"decidedBy" gorm:"comment:处理人UUID"`                                                 // 处理人UUID
+	DecidedAt    *time.Time `json:"decidedAt" gorm:"comment:处理时间"`                                                    // 处理时间
+	DecisionNote string     `json:"decisionNote" gorm:"size:500;comment:处理备注"`                                        // 处理备注
+	User         SysUser    `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:申请人信息"`                    // 申请人信息
+	Album        SysAlbum   `json:"album" gorm:"foreignKey:AlbumID;references:ID;comment:相册信息"`                       // 相册信息
+	Drawing      SysDrawing `json:"drawing" gorm:"foreignKey:DrawingID;references:ID;comment:图纸信息"`                   // 图纸信息
+}
+
+// TableName 访问申请表名
+func (SysAccessRequest) TableName() string {
+	return "sys_access_requests"
+}
@@ -5,17 +5,26 @@ import (
 	"github.com/google/uuid"
 )
 
+// 回收站条目类型
+const (
+	RecycleTypeAlbum   = "album"   // 相册（连同一起删除的图纸）
+	RecycleTypeDrawing = "drawing" // 单独删除的图纸
+)
+
 // SysAlbum 相册表
 type SysAlbum struct {
 	global.GVA_MODEL
-	CreatorUUID   uuid.UUID `json:"creatorUUID" gorm:"index;comment:创建者UUID"`                                                  // 创建者UUID
-	Title         string    `json:"title" gorm:"comment:相册标题"`                                                                 // 相册标题
-	CoverImageURL string    `json:"coverImageURL" gorm:"comment:相册封面图URL"`                                                     // 相册封面图URL
-	Description   string    `json:"description" gorm:"comment:相册描述"`                                                           // 相册描述
-	Status        int       `json:"status" gorm:"default:1;comment:相册状态 1:正常 2:禁用"`                                            // 相册状态
-	Creator       SysUser   `json:"creator" gorm:"foreignKey:CreatorUUID;references:UUID;comment:创建者信息"`                       // 创建者信息
-	AdminUserIDs  []uint    `json:"adminUserIDs" gorm:"-"`                                                                     // 管理员ID列表（用于接收前端数据）
-	AdminUsers    []SysUser `json:"adminUsers" gorm:"many2many:sys_album_admin;joinForeignKey:AlbumID;joinReferences:UserID;"` // 管理员列表
+	CreatorUUID   uuid.UUID           `json:"creatorUUID" gorm:"index;comment:创建者UUID"`                                                  // 创建者UUID
+	Title         string              `json:"title" gorm:"comment:相册标题"`                                                                 // 相册标题
+	CoverImageURL string              `json:"coverImageURL" gorm:"comment:相册封面图URL"`                                                     // 相册封面图URL
+	Description   string              `json:"description" gorm:"comment:相册描述"`                                                           // 相册描述
+	Status        int                 `json:"status" gorm:"default:1;comment:相册状态 1:正常 2:禁用"`                                            // 相册状态
+	Position      int                 `json:"position" gorm:"index;default:0;comment:排序位置"`                                              // 排序位置，0 表示未排序
+	Creator       SysUser             `json:"creator" gorm:"foreignKey:CreatorUUID;references:UUID;comment:创建者信息"`                       // 创建者信息
+	AdminUserIDs  []uint              `json:"adminUserIDs" gorm:"-"`                                                                     // 管理员ID列表（用于接收前端数据）
+	AdminUsers    []SysUser           `json:"adminUsers" gorm:"many2many:sys_album_admin;joinForeignKey:AlbumID;joinReferences:UserID;"` // 管理员列表
+	Renditions    []SysImageRendition `json:"-" gorm:"polymorphic:Owner;"`                                                               // 封面衍生图
+	Fields        []SysAlbumField     `json:"fields" gorm:"foreignKey:AlbumID;references:ID"`                                            // 自定义字段定义
 }
 
 // SysAlbumAdmin 相册管理员关联表
@@ -0,0 +1,50 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// 自定义字段类型
+const (
+	AlbumFieldTypeText    = "text"    // 文本
+	AlbumFieldTypeNumber  = "number"  // 数字
+	AlbumFieldTypeInteger = "integer" // 整数
+	AlbumFieldTypeBoolean = "boolean" // 布尔
+	AlbumFieldTypeEnum    = "enum"    // 枚举
+	AlbumFieldTypeDate    = "date"    // 日期 YYYY-MM-DD
+)
+
+// SysAlbumField 相册自定义字段定义表
+type SysAlbumField struct {
+	global.GVA_MODEL
+	AlbumID  uint     `json:"albumId" gorm:"uniqueIndex:idx_album_field_key;comment:相册ID"`            // 相册ID
+	Key      string   `json:"key" gorm:"uniqueIndex:idx_album_field_key;size:64;comment:字段标识"`        // 字段标识
+	Label    string   `json:"label" gorm:"size:64;comment:字段名称"`                                      // 字段名称
+	Type     string   `json:"type" gorm:"size:16;comment:字段类型 text/number/integer/boolean/enum/date"` // 字段类型
+	Required bool     `json:"required" gorm:"comment:是否必填"`                                           // 是否必填
+	Options  string   `json:"-" gorm:"type:text;comment:枚举选项"`                                        // 枚举选项 (JSON格式)
+	Min      *float64 `json:"min" gorm:"comment:最小值或最小长度"`                                            // 最小值（数字）或最小长度（文本）
+	Max      *float64 `json:"max" gorm:"comment:最大值或最大长度"`                                            // 最大值（数字）或最大长度（文本）
+	Pattern  string   `json:"pattern" gorm:"size:255;comment:文本校验正则"`                                 // 文本校验正则
+	Sort     int      `json:"sort" gorm:"comment:排序"`                                                 // 排序
+}
+
+// SysDrawingFieldValue 图纸自定义字段值表
+// 文本、枚举、日期写入 TextValue；数字、整数、布尔写入 NumberValue，便于按字段筛选与排序
+type SysDrawingFieldValue struct {
+	DrawingID   uint          `json:"drawingId" gorm:"primaryKey;comment:图纸ID"`                                                                             // 图纸ID
+	FieldID     uint          `json:"fieldId" gorm:"primaryKey;index:idx_field_value_text,priority:1;index:idx_field_value_number,priority:1;comment:字段ID"` // 字段ID
+	TextValue   string        `json:"textValue" gorm:"size:255;index:idx_field_value_text,priority:2;comment:文本值"`                                          // 文本值
+	NumberValue *float64      `json:"numberValue" gorm:"index:idx_field_value_number,priority:2;comment:数值"`                                                // 数值
+	Field       SysAlbumField `json:"-" gorm:"foreignKey:FieldID;references:ID"`                                                                            // 字段定义
+}
+
+// TableName 相册自定义字段表名
+func (SysAlbumField) TableName() string {
+	return "sys_album_fields"
+}
+
+// TableName 图纸自定义字段值表名
+func (SysDrawingFieldValue) TableName() string {
+	return "sys_drawing_field_values"
+}
@@ -0,0 +1,49 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+)
+
+// SysAlbumInvite 相册邀请链接表，兑换后以指定角色加入相册
+// 令牌只保存哈希，明文仅在创建时返回一次
+type SysAlbumInvite struct {
+	global.GVA_MODEL
+	AlbumID        uint       `json:"albumId" gorm:"index;comment:相册ID"`                          // 相册ID
+	TokenHash      string     `json:"-" gorm:"uniqueIndex;size:64;comment:令牌哈希"`                  // 令牌哈希
+	TokenPrefix    string     `json:"tokenPrefix" gorm:"size:8;comment:令牌前缀"`                     // 令牌前缀，用于辨认
+	Role           string     `json:"role" gorm:"size:16;comment:加入后的角色"`                         // 加入后的角色
+	MaxUses        int        `json:"maxUses" gorm:"comment:最大使用次数，0表示不限"`                        // 最大使用次数
+	UsedCount      int        `json:"usedCount" gorm:"default:0;comment:已使用次数"`                   // 已使用次数
+	ExpiresAt      *time.Time `json:"expiresAt" gorm:"comment:过期时间，为空表示永不过期"`                     // 过期时间
+	AllowedDomains string     `json:"allowedDomains" gorm:"size:500;comment:允许的邮箱域名，逗号分隔，为空表示不限"` // 允许的邮箱域名
+	CreatorUUID    uuid.UUID  `json:"creatorUUID" gorm:"comment:创建人UUID"`                         // 创建人UUID
+	RevokedAt      *time.Time `json:"revokedAt" gorm:"comment:撤销时间"`                              // 撤销时间
+	RevokedBy      *uuid.UUID `json:"revokedBy" gorm:"comment:撤销人UUID"`                           // 撤销人UUID
+}
+
+// TableName 相册邀请链接表名
+func (SysAlbumInvite) TableName() string {
+	return "sys_album_invites"
+}
+
+// SysAlbumInviteRedemption 邀请链接兑换记录表
+type SysAlbumInviteRedemption struct {
+	ID        uint      `json:"id" gorm:"primarykey"`                                             // 主键ID
+	CreatedAt time.Time `json:"createdAt"`                                                        // 兑换时间
+	InviteID  uint      `json:"inviteId" gorm:"uniqueIndex:idx_invite_redemption;comment:邀请ID"`   // 邀请ID
+	AlbumID   uint      `json:"albumId" gorm:"index;comment:相册ID"`                                // 相册ID
+	UserID    uint      `json:"userId" gorm:"comment:用户ID"`                                       // 用户ID
+	UserUUID  uuid.UUID `json:"userUUID" gorm:"uniqueIndex:idx_invite_redemption;comment:用户UUID"` // 用户UUID
+	Email     string    `json:"email" gorm:"comment:兑换时的邮箱"`                                      // 兑换时的邮箱
+	IP        string    `json:"ip" gorm:"size:64;comment:兑换IP"`                                   // 兑换IP
+	Role      string    `json:"role" gorm:"size:16;comment:授予的角色"`                                // 授予的角色
+	User      SysUser   `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:用户信息"`     // 用户信息
+}
+
+// TableName 邀请链接兑换记录表名
+func (SysAlbumInviteRedemption) TableName() string {
+	return "sys_album_invite_redemptions"
+}
@@ -0,0 +1,70 @@
+package system
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 相册成员角色，权限依次递增
+const (
+	AlbumRoleViewer     = "viewer"     // 查看
+	AlbumRoleDownloader = "downloader" // 下载
+	AlbumRoleEditor     = "editor"     // 编辑
+	AlbumRoleAdmin      = "admin"      // 管理
+)
+
+// albumRoleRanks 角色权限等级
+var albumRoleRanks = map[string]int{
+	AlbumRoleViewer:     1,
+	AlbumRoleDownloader: 2,
+	AlbumRoleEditor:     3,
+	AlbumRoleAdmin:      4,
+}
+
+// AlbumRoleRank 返回角色权限等级，未知角色返回 0
+func AlbumRoleRank(role string) int {
+	return albumRoleRanks[role]
+}
+
+// AlbumRolesAtLeast 返回不低于指定角色的所有角色
+func AlbumRolesAtLeast(role string) []string {
+	roles := make([]string, 0, len(albumRoleRanks))
+	for _, r := range []string{AlbumRoleViewer, AlbumRoleDownloader, AlbumRoleEditor, AlbumRoleAdmin} {
+		if albumRoleRanks[r] >= albumRoleRanks[role] {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// SysAlbumMember 相册成员表，成员权限作用于相册下全部图纸
+type SysAlbumMember struct {
+	ID         uint       `json:"id" gorm:"primarykey"`                                              // 主键ID
+	CreatedAt  time.Time  `json:"createdAt"`                                                         // 创建时间
+	UpdatedAt  time.Time  `json:"updatedAt"`                                                         // 更新时间
+	AlbumID    uint       `json:"albumId" gorm:"uniqueIndex:idx_album_member;comment:相册ID"`          // 相册ID
+	UserID     uint       `json:"userId" gorm:"index;comment:用户ID"`                                  // 用户ID
+	UserUUID   uuid.UUID  `json:"userUUID" gorm:"uniqueIndex:idx_album_member;index;comment:用户UUID"` // 用户UUID
+	Role       string     `json:"role" gorm:"size:16;comment:角色 viewer/downloader/editor/admin"`     // 角色
+	StartsAt   *time.Time `json:"startsAt" gorm:"comment:生效时间，为空表示立即生效"`                             // 生效时间
+	ExpiresAt  *time.Time `json:"expiresAt" gorm:"index;comment:过期时间，为空表示永久"`                        // 过期时间
+	RemindedAt *time.Time `json:"-" gorm:"comment:到期提醒时间"`                                           // 到期提醒时间
+	GrantedBy  uuid.UUID  `json:"grantedBy" gorm:"comment:授权人UUID"`                                  // 授权人UUID
+	User       SysUser    `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:成员信息"`      // 成员信息
+}
+
+// TableName 相册成员表名
+func (SysAlbumMember) TableName() string {
+	return "sys_album_members"
+}
+
+// Active 成员资格在指定时间是否有效
+func (m SysAlbumMember) Active(now time.Time) bool {
+	return grantActive(m.StartsAt, m.ExpiresAt, now)
+}
+
+// grantActive 判断授权在指定时间是否处于生效窗口内
+func grantActive(startsAt, expiresAt *time.Time, now time.Time) bool {
+	return (startsAt == nil || !startsAt.After(now)) && (expiresAt == nil || expiresAt.After(now))
+}
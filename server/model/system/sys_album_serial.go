@@ -0,0 +1,86 @@
+package system
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// 编号规则中的日期占位
+const (
+	SerialDateNone  = ""         // 不含日期
+	SerialDateYear  = "yyyy"     // 年，如 2026
+	SerialDateMonth = "yyyymm"   // 年月，如 202610
+	SerialDateDay   = "yyyymmdd" // 年月日，如 20261019
+)
+
+// 流水号位数
+const (
+	DefaultSerialPadding = 3  // 默认补零位数
+	MaxSerialPadding     = 10 // 最大补零位数
+)
+
+// serialDateLayouts 日期占位对应的时间格式
+var serialDateLayouts = map[string]string{
+	SerialDateNone:  "",
+	SerialDateYear:  "2006",
+	SerialDateMonth: "200601",
+	SerialDateDay:   "20060102",
+}
+
+// SysAlbumSerialScheme 相册图纸编号规则表：前缀 + 日期 + 分隔符 + 补零流水号，如 A-202610-001
+type SysAlbumSerialScheme struct {
+	global.GVA_MODEL
+	AlbumID   uint   `json:"albumId" gorm:"uniqueIndex;comment:相册ID"`                      // 相册ID
+	Prefix    string `json:"prefix" gorm:"size:32;comment:序号前缀"`                           // 序号前缀
+	DateToken string `json:"dateToken" gorm:"size:16;comment:日期占位 空/yyyy/yyyymm/yyyymmdd"` // 日期占位
+	Separator string `json:"separator" gorm:"size:8;comment:日期与流水号之间的分隔符"`                 // 日期与流水号之间的分隔符
+	Padding   int    `json:"padding" gorm:"comment:流水号补零位数"`                               // 流水号补零位数
+}
+
+// TableName 相册编号规则表名
+func (SysAlbumSerialScheme) TableName() string {
+	return "sys_album_serial_schemes"
+}
+
+// ValidDateToken 判断日期占位是否受支持
+func ValidDateToken(token string) bool {
+	_, ok := serialDateLayouts[token]
+	return ok
+}
+
+// Period 当前时间所属的编号周期，含日期占位时流水号按周期重新计数
+func (s SysAlbumSerialScheme) Period(now time.Time) string {
+	layout := serialDateLayouts[s.DateToken]
+	if layout == "" {
+		return ""
+	}
+	return now.Format(layout)
+}
+
+// Format 按规则生成序号
+func (s SysAlbumSerialScheme) Format(period string, value int64) string {
+	padding := s.Padding
+	if padding <= 0 {
+		padding = DefaultSerialPadding
+	}
+	if period == "" {
+		return fmt.Sprintf("%s%0*d", s.Prefix, padding, value)
+	}
+	return fmt.Sprintf("%s%s%s%0*d", s.Prefix, period, s.Separator, padding, value)
+}
+
+// SysAlbumSerialSequence 相册编号流水表，分配序号时行锁保证并发安全
+type SysAlbumSerialSequence struct {
+	ID        uint      `json:"id" gorm:"primarykey"`                                                          // 主键ID
+	UpdatedAt time.Time `json:"updatedAt"`                                                                     // 更新时间
+	AlbumID   uint      `json:"albumId" gorm:"uniqueIndex:idx_serial_sequence,priority:1;comment:相册ID"`        // 相册ID
+	Period    string    `json:"period" gorm:"uniqueIndex:idx_serial_sequence,priority:2;size:16;comment:编号周期"` // 编号周期
+	Value     int64     `json:"value" gorm:"default:0;comment:已分配的最大流水号"`                                      // 已分配的最大流水号
+}
+
+// TableName 相册编号流水表名
+func (SysAlbumSerialSequence) TableName() string {
+	return "sys_album_serial_sequences"
+}
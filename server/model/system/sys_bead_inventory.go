@@ -0,0 +1,19 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+)
+
+// SysBeadInventory 用户拼豆库存表
+type SysBeadInventory struct {
+	global.GVA_MODEL
+	UserUUID  uuid.UUID `json:"userUUID" gorm:"uniqueIndex:idx_bead_inventory_user_color;comment:用户UUID"`      // 用户UUID
+	ColorCode string    `json:"colorCode" gorm:"uniqueIndex:idx_bead_inventory_user_color;size:32;comment:色号"` // 色号
+	Quantity  int       `json:"quantity" gorm:"comment:数量"`                                                    // 数量
+}
+
+// TableName 拼豆库存表名
+func (SysBeadInventory) TableName() string {
+	return "sys_bead_inventories"
+}
@@ -0,0 +1,17 @@
+package system
+
+import "time"
+
+// SysDownloadArchive 下载历史归档文件记录，归档文件保存在不对外提供访问的私有目录
+type SysDownloadArchive struct {
+	ID        uint      `json:"id" gorm:"primarykey"`                      // 主键ID
+	CreatedAt time.Time `json:"createdAt"`                                 // 归档时间
+	Path      string    `json:"path" gorm:"comment:归档文件路径"`                // 归档文件路径
+	Records   int       `json:"records" gorm:"comment:归档记录条数"`             // 归档记录条数
+	Cutoff    int64     `json:"cutoff" gorm:"comment:归档截止时间戳，早于该时间的记录被归档"` // 归档截止时间戳
+}
+
+// TableName 下载历史归档文件表名
+func (SysDownloadArchive) TableName() string {
+	return "sys_download_archives"
+}
@@ -5,16 +5,31 @@ import (
 	"github.com/google/uuid"
 )
 
+// 下载渠道
+const (
+	DownloadChannelSingle = "single" // 单张下载
+	DownloadChannelBatch  = "batch"  // 批量下载
+	DownloadChannelClient = "client" // 前端上报的下载点击，服务端未发放文件，不计入下载统计
+)
+
 // SysDownloadHistory 下载历史记录结构体
 type SysDownloadHistory struct {
 	global.GVA_MODEL
-	UserUUID   uuid.UUID  `json:"userUUID" gorm:"index;comment:用户UUID"`                           // 用户UUID
-	DrawingID  uint       `json:"drawingId" gorm:"index;comment:图纸ID"`                            // 图纸ID
-	AlbumID    uint       `json:"albumId" gorm:"index;comment:相册ID"`                              // 相册ID
-	DownloadAt int64      `json:"downloadAt" gorm:"comment:下载时间戳"`                                // 下载时间戳
-	User       SysUser    `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:用户信息"`   // 用户信息
-	Drawing    SysDrawing `json:"drawing" gorm:"foreignKey:DrawingID;references:ID;comment:图纸信息"` // 图纸信息
-	Album      SysAlbum   `json:"album" gorm:"foreignKey:AlbumID;references:ID;comment:相册信息"`     // 相册信息
+	UserUUID      uuid.UUID  `json:"userUUID" gorm:"index;comment:用户UUID"`                           // 用户UUID
+	DrawingID     uint       `json:"drawingId" gorm:"index;comment:图纸ID"`                            // 图纸ID
+	AlbumID       uint       `json:"albumId" gorm:"index;comment:相册ID"`                              // 相册ID
+	DownloadAt    int64      `json:"downloadAt" gorm:"comment:下载时间戳"`                                // 下载时间戳
+	Watermarked   bool       `json:"watermarked" gorm:"default:false;comment:是否带水印"`                 // 是否带水印下载
+	WatermarkText string     `json:"watermarkText" gorm:"size:255;comment:水印文字"`                     // 水印文字
+	WatermarkHash string     `json:"watermarkHash" gorm:"size:64;index;comment:水印文字与策略哈希"`           // 水印文字与策略哈希
+	Channel       string     `json:"channel" gorm:"size:16;index;comment:下载渠道 single/batch/client"`  // 下载渠道
+	Files         string     `json:"files" gorm:"type:text;comment:实际提供的文件列表"`                       // 实际提供的文件列表 (JSON格式)
+	ClientIP      string     `json:"clientIP" gorm:"size:64;index;comment:客户端IP"`                    // 客户端IP
+	UserAgent     string     `json:"userAgent" gorm:"size:512;comment:客户端UA"`                        // 客户端UA
+	RequestID     string     `json:"requestId" gorm:"size:64;index;comment:请求ID"`                    // 请求ID
+	User          SysUser    `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:用户信息"`   // 用户信息
+	Drawing       SysDrawing `json:"drawing" gorm:"foreignKey:DrawingID;references:ID;comment:图纸信息"` // 图纸信息
+	Album         SysAlbum   `json:"album" gorm:"foreignKey:AlbumID;references:ID;comment:相册信息"`     // 相册信息
 }
 
 // TableName 下载历史记录表名
@@ -0,0 +1,54 @@
+package system
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SysDownloadDailyDrawing 图纸每日下载汇总表，由下载历史增量汇总
+type SysDownloadDailyDrawing struct {
+	Day         string `json:"day" gorm:"primaryKey;size:10;comment:日期 YYYY-MM-DD"` // 日期
+	DrawingID   uint   `json:"drawingId" gorm:"primaryKey;index;comment:图纸ID"`      // 图纸ID
+	AlbumID     uint   `json:"albumId" gorm:"index;comment:下载时所在相册ID"`              // 下载时所在相册ID
+	Downloads   int64  `json:"downloads" gorm:"default:0;comment:下载次数"`             // 下载次数
+	Watermarked int64  `json:"watermarked" gorm:"default:0;comment:带水印下载次数"`        // 带水印下载次数
+}
+
+// TableName 图纸每日下载汇总表名
+func (SysDownloadDailyDrawing) TableName() string {
+	return "sys_download_daily_drawings"
+}
+
+// SysDownloadDailyUser 用户每日下载汇总表（按相册），用于用户维度统计与去重下载人数
+type SysDownloadDailyUser struct {
+	Day         string    `json:"day" gorm:"primaryKey;size:10;comment:日期 YYYY-MM-DD"` // 日期
+	UserUUID    uuid.UUID `json:"userUUID" gorm:"primaryKey;index;comment:用户UUID"`     // 用户UUID
+	AlbumID     uint      `json:"albumId" gorm:"primaryKey;index;comment:相册ID"`        // 相册ID
+	Downloads   int64     `json:"downloads" gorm:"default:0;comment:下载次数"`             // 下载次数
+	Watermarked int64     `json:"watermarked" gorm:"default:0;comment:带水印下载次数"`        // 带水印下载次数
+}
+
+// TableName 用户每日下载汇总表名
+func (SysDownloadDailyUser) TableName() string {
+	return "sys_download_daily_users"
+}
+
+// SysDownloadRollupCursor 下载汇总进度，记录已汇总的最大下载历史ID
+type SysDownloadRollupCursor struct {
+	ID            uint      `json:"id" gorm:"primarykey"`                      // 主键ID
+	LastHistoryID uint      `json:"lastHistoryId" gorm:"comment:已汇总的最大下载历史ID"` // 已汇总的最大下载历史ID
+	UpdatedAt     time.Time `json:"updatedAt"`                                 // 更新时间
+}
+
+// TableName 下载汇总进度表名
+func (SysDownloadRollupCursor) TableName() string {
+	return "sys_download_rollup_cursors"
+}
+
+// 下载统计的时间分桶粒度
+const (
+	DownloadBucketDay   = "day"   // 按天
+	DownloadBucketWeek  = "week"  // 按周（周一为起始，以周一日期标识）
+	DownloadBucketMonth = "month" // 按月（YYYY-MM）
+)
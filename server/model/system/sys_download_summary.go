@@ -0,0 +1,23 @@
+package system
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SysDownloadSummary 用户-图纸下载汇总，超过保留期的原始下载记录压缩到此表后删除
+type SysDownloadSummary struct {
+	UserUUID        uuid.UUID `json:"userUUID" gorm:"primaryKey;comment:用户UUID"`        // 用户UUID
+	DrawingID       uint      `json:"drawingId" gorm:"primaryKey;index;comment:图纸ID"`   // 图纸ID
+	FirstDownloadAt int64     `json:"firstDownloadAt" gorm:"comment:首次下载时间戳"`           // 首次下载时间戳
+	LastDownloadAt  int64     `json:"lastDownloadAt" gorm:"comment:最后下载时间戳"`            // 最后下载时间戳
+	Downloads       int64     `json:"downloads" gorm:"default:0;comment:已压缩的下载次数"`      // 已压缩的下载次数
+	Watermarked     int64     `json:"watermarked" gorm:"default:0;comment:已压缩的带水印下载次数"` // 已压缩的带水印下载次数
+	UpdatedAt       time.Time `json:"updatedAt"`                                        // 更新时间
+}
+
+// TableName 用户-图纸下载汇总表名
+func (SysDownloadSummary) TableName() string {
+	return "sys_download_summaries"
+}
@@ -1,26 +1,81 @@
 package system
 
 import (
+	"time"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/google/uuid"
 )
 
+// 移动或复制图纸时的序号冲突处理策略
+const (
+	SerialConflictFail     = "fail"     // 存在冲突时整体失败
+	SerialConflictRenumber = "renumber" // 按目标相册中同前缀的最大编号顺延，如 A-012 -> A-031
+	SerialConflictSuffix   = "suffix"   // 在原序号后追加后缀，如 A-012 -> A-012-1
+)
+
+// 图纸发布状态：draft → in_review → published → archived，审核通过但未到定时发布时间的为 scheduled
+const (
+	DrawingStatusDraft     = "draft"     // 草稿
+	DrawingStatusInReview  = "in_review" // 待审核
+	DrawingStatusScheduled = "scheduled" // 审核通过，等待定时发布
+	DrawingStatusPublished = "published" // 已发布，成员可见
+	DrawingStatusArchived  = "archived"  // 已归档，成员不可见
+)
+
+// 相册与图纸列表的排序方式
+const (
+	ListSortPosition   = "position"   // 手动排序位置，未排序的排在已排序之后并按创建时间倒序
+	ListSortSerial     = "serial"     // 序号自然排序（仅图纸）
+	ListSortName       = "name"       // 名称
+	ListSortCreated    = "created"    // 创建时间倒序
+	ListSortPopularity = "popularity" // 下载次数倒序
+	ListSortTrending   = "trending"   // 热度倒序（仅图纸）
+)
+
+// 用户图纸台账的排序方式
+const (
+	LedgerSortAcquired     = "acquired"     // 获得时间，已下载的按首次下载时间、未下载的排在后面按创建时间
+	LedgerSortLastDownload = "lastDownload" // 最后下载时间，未下载的排在后面
+	LedgerSortName         = "name"         // 图纸名称
+	LedgerSortAlbum        = "album"        // 相册标题，同相册内按序号
+)
+
 // SysDrawing 图纸结构体
 type SysDrawing struct {
 	global.GVA_MODEL
-	AlbumID        uint      `json:"albumId" gorm:"index;comment:相册ID"`                                   // 相册ID
-	SerialNumber   string    `json:"serialNumber" gorm:"index;comment:图纸序号"`                              // 图纸序号
-	Name           string    `json:"name" gorm:"comment:图纸名称"`                                            // 图纸名称
-	BeanQuantity   *int      `json:"beanQuantity" gorm:"comment:豆量"`                                      // 豆量
-	PosterImageURL string    `json:"posterImageURL" gorm:"comment:海报图URL"`                                // 海报图URL
-	DrawingURLs    string    `json:"drawingURLs" gorm:"type:text;comment:图纸文件URLs"`                       // 图纸文件URLs (JSON格式)
-	CreatorUUID    uuid.UUID `json:"creatorUUID" gorm:"index;comment:创建者UUID"`                            // 创建者UUID
-	AllowedMembers string    `json:"allowedMembers" gorm:"type:text;comment:允许下载的成员"`                     // 允许下载的成员 (JSON格式)
-	Album          SysAlbum  `json:"album" gorm:"foreignKey:AlbumID;references:ID;comment:相册信息"`          // 相册信息
-	Creator        SysUser   `json:"creator" gorm:"foreignKey:CreatorUUID;references:UUID;comment:创建者信息"` // 创建者信息
+	AlbumID        uint                   `json:"albumId" gorm:"index;uniqueIndex:idx_album_serial,priority:1;comment:相册ID"`         // 相册ID
+	SerialNumber   string                 `json:"serialNumber" gorm:"size:191;uniqueIndex:idx_album_serial,priority:2;comment:图纸序号"` // 图纸序号（相册内唯一，含已删除图纸）
+	SerialSortKey  string                 `json:"-" gorm:"size:255;index;comment:序号自然排序键"`                                           // 序号自然排序键
+	Name           string                 `json:"name" gorm:"comment:图纸名称"`                                                          // 图纸名称
+	BeanQuantity   *int                   `json:"beanQuantity" gorm:"comment:豆量"`                                                    // 豆量
+	PosterImageURL string                 `json:"posterImageURL" gorm:"comment:海报图URL"`                                              // 海报图URL
+	DrawingURLs    string                 `json:"drawingURLs" gorm:"type:text;comment:图纸文件URLs"`                                     // 图纸文件URLs (JSON格式)
+	CreatorUUID    uuid.UUID              `json:"creatorUUID" gorm:"index;comment:创建者UUID"`                                          // 创建者UUID
+	AllowedMembers string                 `json:"allowedMembers" gorm:"type:text;comment:允许下载的成员"`                                   // 允许下载的成员 (JSON格式)
+	Status         string                 `json:"status" gorm:"size:16;index;default:published;comment:发布状态"`                        // 发布状态，仅已发布的图纸对成员可见
+	PublishAt      *time.Time             `json:"publishAt" gorm:"index;comment:发布时间"`                                               // 发布时间，定时发布时为计划时间
+	Position       int                    `json:"position" gorm:"index;default:0;comment:相册内排序位置"`                                   // 相册内排序位置，0 表示未排序
+	Album          SysAlbum               `json:"album" gorm:"foreignKey:AlbumID;references:ID;comment:相册信息"`                        // 相册信息
+	Creator        SysUser                `json:"creator" gorm:"foreignKey:CreatorUUID;references:UUID;comment:创建者信息"`               // 创建者信息
+	Colors         []SysDrawingColor      `json:"colors" gorm:"foreignKey:DrawingID;references:ID"`                                  // 色号用量清单
+	Renditions     []SysImageRendition    `json:"-" gorm:"polymorphic:Owner;"`                                                       // 海报与图纸衍生图
+	FieldValues    []SysDrawingFieldValue `json:"-" gorm:"foreignKey:DrawingID;references:ID"`                                       // 自定义字段值
+}
+
+// SysDrawingColor 图纸色号用量表（物料清单）
+type SysDrawingColor struct {
+	DrawingID uint   `json:"drawingId" gorm:"primaryKey;comment:图纸ID"`       // 图纸ID
+	ColorCode string `json:"colorCode" gorm:"primaryKey;size:32;comment:色号"` // 色号
+	Quantity  int    `json:"quantity" gorm:"comment:用量"`                     // 用量
 }
 
 // TableName 图纸表名
 func (SysDrawing) TableName() string {
 	return "sys_drawings"
 }
+
+// TableName 图纸色号用量表名
+func (SysDrawingColor) TableName() string {
+	return "sys_drawing_colors"
+}
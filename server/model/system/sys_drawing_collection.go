@@ -0,0 +1,50 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+)
+
+// SysDrawingCollection 用户的图纸收藏夹，可跨相册收藏图纸并只读共享给其他用户
+// 每个用户最多一个默认收藏夹（“我的收藏”），首次收藏图纸时自动创建，不能删除
+type SysDrawingCollection struct {
+	global.GVA_MODEL
+	OwnerUUID   uuid.UUID `json:"ownerUUID" gorm:"index;comment:所有者UUID"`                          // 所有者UUID
+	Name        string    `json:"name" gorm:"size:64;comment:名称"`                                  // 名称
+	Description string    `json:"description" gorm:"size:500;comment:描述"`                          // 描述
+	IsDefault   bool      `json:"isDefault" gorm:"default:false;comment:是否为默认收藏夹"`                 // 是否为默认收藏夹
+	Owner       SysUser   `json:"owner" gorm:"foreignKey:OwnerUUID;references:UUID;comment:所有者信息"` // 所有者信息
+}
+
+// SysDrawingCollectionItem 收藏夹中的图纸，按位置排序，新加入的排在最后
+type SysDrawingCollectionItem struct {
+	CollectionID uint      `json:"collectionId" gorm:"primaryKey;comment:收藏夹ID"`   // 收藏夹ID
+	DrawingID    uint      `json:"drawingId" gorm:"primaryKey;index;comment:图纸ID"` // 图纸ID
+	Position     int       `json:"position" gorm:"default:0;comment:排序位置"`         // 排序位置
+	CreatedAt    time.Time `json:"createdAt" gorm:"comment:加入时间"`                  // 加入时间
+}
+
+// SysDrawingCollectionShare 收藏夹只读共享
+type SysDrawingCollectionShare struct {
+	CollectionID uint      `json:"collectionId" gorm:"primaryKey;comment:收藏夹ID"`                 // 收藏夹ID
+	UserUUID     uuid.UUID `json:"userUUID" gorm:"primaryKey;index;comment:共享用户UUID"`            // 共享用户UUID
+	CreatedAt    time.Time `json:"createdAt" gorm:"comment:共享时间"`                                // 共享时间
+	User         SysUser   `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:共享用户"` // 共享用户
+}
+
+// TableName 图纸收藏夹表名
+func (SysDrawingCollection) TableName() string {
+	return "sys_drawing_collections"
+}
+
+// TableName 收藏夹图纸表名
+func (SysDrawingCollectionItem) TableName() string {
+	return "sys_drawing_collection_items"
+}
+
+// TableName 收藏夹共享表名
+func (SysDrawingCollectionShare) TableName() string {
+	return "sys_drawing_collection_shares"
+}
@@ -0,0 +1,37 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+)
+
+// 完成作品审核状态
+const (
+	CompletionStatusPending  = "pending"  // 待审核
+	CompletionStatusApproved = "approved" // 已通过，公开可见
+	CompletionStatusHidden   = "hidden"   // 已隐藏
+)
+
+// SysDrawingCompletion 图纸完成记录（成品晒图）表
+type SysDrawingCompletion struct {
+	global.GVA_MODEL
+	DrawingID     uint       `json:"drawingId" gorm:"index;comment:图纸ID"`                                              // 图纸ID
+	AlbumID       uint       `json:"albumId" gorm:"index;comment:相册ID"`                                                // 相册ID
+	UserUUID      uuid.UUID  `json:"userUUID" gorm:"index;comment:用户UUID"`                                             // 用户UUID
+	PhotoURL      string     `json:"photoURL" gorm:"comment:成品照片URL"`                                                  // 成品照片URL
+	PhotoKey      string     `json:"-" gorm:"comment:成品照片存储Key"`                                                       // 成品照片存储Key
+	Note          string     `json:"note" gorm:"size:500;comment:备注"`                                                  // 备注
+	CompletedAt   time.Time  `json:"completedAt" gorm:"comment:完成日期"`                                                  // 完成日期
+	Status        string     `json:"status" gorm:"size:16;index;default:pending;comment:审核状态 pending/approved/hidden"` // 审核状态
+	ModeratorUUID *uuid.UUID `json:"moderatorUUID" gorm:"comment:审核人UUID"`                                             // 审核人UUID
+	ModeratedAt   *time.Time `json:"moderatedAt" gorm:"comment:审核时间"`                                                  // 审核时间
+	User          SysUser    `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:用户信息"`                     // 用户信息
+	Drawing       SysDrawing `json:"drawing" gorm:"foreignKey:DrawingID;references:ID;comment:图纸信息"`                   // 图纸信息
+}
+
+// TableName 图纸完成记录表名
+func (SysDrawingCompletion) TableName() string {
+	return "sys_drawing_completions"
+}
@@ -0,0 +1,64 @@
+package system
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SysDrawingGrant 图纸单独授权表，支持生效与过期时间
+// 图纸上的 AllowedMembers 为永久授权，本表用于限时授权（如按月购买的图纸）
+type SysDrawingGrant struct {
+	ID         uint       `json:"id" gorm:"primarykey"`                                               // 主键ID
+	CreatedAt  time.Time  `json:"createdAt"`                                                          // 创建时间
+	UpdatedAt  time.Time  `json:"updatedAt"`                                                          // 更新时间
+	DrawingID  uint       `json:"drawingId" gorm:"uniqueIndex:idx_drawing_grant;comment:图纸ID"`        // 图纸ID
+	UserID     uint       `json:"userId" gorm:"index;comment:用户ID"`                                   // 用户ID
+	UserUUID   uuid.UUID  `json:"userUUID" gorm:"uniqueIndex:idx_drawing_grant;index;comment:用户UUID"` // 用户UUID
+	StartsAt   *time.Time `json:"startsAt" gorm:"comment:生效时间，为空表示立即生效"`                              // 生效时间
+	ExpiresAt  *time.Time `json:"expiresAt" gorm:"index;comment:过期时间，为空表示永久"`                         // 过期时间
+	RemindedAt *time.Time `json:"-" gorm:"comment:到期提醒时间"`                                            // 到期提醒时间
+	GrantedBy  uuid.UUID  `json:"grantedBy" gorm:"comment:授权人UUID"`                                   // 授权人UUID
+	User       SysUser    `json:"user" gorm:"foreignKey:UserUUID;references:UUID;comment:用户信息"`       // 用户信息
+	Drawing    SysDrawing `json:"drawing" gorm:"foreignKey:DrawingID;references:ID;comment:图纸信息"`     // 图纸信息
+}
+
+// TableName 图纸授权表名
+func (SysDrawingGrant) TableName() string {
+	return "sys_drawing_grants"
+}
+
+// Active 授权在指定时间是否有效
+func (g SysDrawingGrant) Active(now time.Time) bool {
+	return grantActive(g.StartsAt, g.ExpiresAt, now)
+}
+
+// 授权类型
+const (
+	AccessGrantTypeAlbum   = "album"   // 相册成员
+	AccessGrantTypeDrawing = "drawing" // 图纸授权
+)
+
+// 授权变更动作
+const (
+	AccessGrantActionExpired  = "expired"  // 到期回收
+	AccessGrantActionReminded = "reminded" // 到期提醒
+)
+
+// SysAccessGrantLog 授权变更记录表
+type SysAccessGrantLog struct {
+	ID        uint       `json:"id" gorm:"primarykey"`                              // 主键ID
+	CreatedAt time.Time  `json:"createdAt" gorm:"index"`                            // 记录时间
+	GrantType string     `json:"grantType" gorm:"size:16;comment:授权类型"`             // 授权类型 album/drawing
+	TargetID  uint       `json:"targetId" gorm:"index;comment:相册ID或图纸ID"`           // 相册ID或图纸ID
+	UserUUID  uuid.UUID  `json:"userUUID" gorm:"index;comment:用户UUID"`              // 用户UUID
+	Role      string     `json:"role" gorm:"size:16;comment:角色"`                    // 角色（相册成员）
+	StartsAt  *time.Time `json:"startsAt" gorm:"comment:生效时间"`                      // 生效时间
+	ExpiresAt *time.Time `json:"expiresAt" gorm:"comment:过期时间"`                     // 过期时间
+	Action    string     `json:"action" gorm:"size:16;comment:动作 expired/reminded"` // 动作
+}
+
+// TableName 授权变更记录表名
+func (SysAccessGrantLog) TableName() string {
+	return "sys_access_grant_logs"
+}
@@ -0,0 +1,22 @@
+package system
+
+// 图片哈希类型
+const (
+	DrawingImageKindPoster  = "poster"  // 海报图
+	DrawingImageKindPattern = "pattern" // 图纸文件
+	DrawingImageKindNone    = "none"    // 无可计算的图片，仅标记已处理
+)
+
+// SysDrawingImageHash 图纸图片感知哈希表
+type SysDrawingImageHash struct {
+	ID        uint   `json:"id" gorm:"primarykey"`                                           // 主键ID
+	DrawingID uint   `json:"drawingId" gorm:"index;comment:图纸ID"`                            // 图纸ID
+	Kind      string `json:"kind" gorm:"size:16;comment:图片类型 poster:海报 pattern:图纸 none:无图片"` // 图片类型
+	ImageURL  string `json:"imageURL" gorm:"comment:图片URL"`                                  // 图片URL
+	Hash      string `json:"hash" gorm:"size:16;index;comment:差值哈希(十六进制)，标记记录为空"`            // 差值哈希
+}
+
+// TableName 图纸图片哈希表名
+func (SysDrawingImageHash) TableName() string {
+	return "sys_drawing_image_hashes"
+}
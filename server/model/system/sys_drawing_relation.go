@@ -0,0 +1,15 @@
+package system
+
+// SysDrawingRelation 图纸关联推荐（下载过该图纸的成员也下载了），由定时任务根据下载共现离线计算
+type SysDrawingRelation struct {
+	DrawingID   uint    `json:"drawingId" gorm:"primaryKey;autoIncrement:false;comment:图纸ID"`         // 图纸ID
+	RelatedID   uint    `json:"relatedId" gorm:"primaryKey;autoIncrement:false;index;comment:关联图纸ID"` // 关联图纸ID
+	Position    int     `json:"position" gorm:"comment:关联排名，从1开始"`                                    // 关联排名，从1开始
+	Score       float64 `json:"score" gorm:"comment:余弦相似度"`                                           // 余弦相似度
+	CoDownloads int64   `json:"coDownloads" gorm:"comment:同时下载两张图纸的用户数"`                              // 同时下载两张图纸的用户数
+}
+
+// TableName 图纸关联推荐表名
+func (SysDrawingRelation) TableName() string {
+	return "sys_drawing_relations"
+}
@@ -0,0 +1,35 @@
+package system
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 图纸审核操作
+const (
+	DrawingReviewSubmit    = "submit"    // 提交审核
+	DrawingReviewApprove   = "approve"   // 审核通过
+	DrawingReviewReject    = "reject"    // 驳回
+	DrawingReviewPublish   = "publish"   // 定时发布
+	DrawingReviewArchive   = "archive"   // 归档
+	DrawingReviewUnarchive = "unarchive" // 取消归档
+)
+
+// SysDrawingReview 图纸审核记录表
+type SysDrawingReview struct {
+	ID           uint      `json:"id" gorm:"primarykey"`                                    // 主键ID
+	CreatedAt    time.Time `json:"createdAt"`                                               // 操作时间
+	DrawingID    uint      `json:"drawingId" gorm:"index;comment:图纸ID"`                     // 图纸ID
+	OperatorUUID uuid.UUID `json:"operatorUUID" gorm:"comment:操作人UUID，定时发布时为空"`             // 操作人UUID
+	Action       string    `json:"action" gorm:"size:16;comment:操作"`                        // 操作
+	FromStatus   string    `json:"fromStatus" gorm:"size:16;comment:原状态"`                   // 原状态
+	ToStatus     string    `json:"toStatus" gorm:"size:16;comment:新状态"`                     // 新状态
+	Comment      string    `json:"comment" gorm:"size:500;comment:审核意见"`                    // 审核意见
+	Operator     SysUser   `json:"operator" gorm:"foreignKey:OperatorUUID;references:UUID"` // 操作人信息
+}
+
+// TableName 图纸审核记录表名
+func (SysDrawingReview) TableName() string {
+	return "sys_drawing_reviews"
+}
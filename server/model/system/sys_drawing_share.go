@@ -0,0 +1,26 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+)
+
+// SysDrawingShare 图纸公开分享链接表，持有令牌即可免登录查看海报页
+type SysDrawingShare struct {
+	global.GVA_MODEL
+	DrawingID    uint       `json:"drawingId" gorm:"index;comment:图纸ID"`                    // 图纸ID
+	Token        string     `json:"token" gorm:"uniqueIndex;size:32;comment:分享令牌"`          // 分享令牌
+	PasswordHash string     `json:"-" gorm:"comment:访问密码哈希，为空表示无需密码"`                       // 访问密码哈希
+	ExpiresAt    *time.Time `json:"expiresAt" gorm:"comment:过期时间，为空表示永不过期"`                 // 过期时间
+	ViewCount    int64      `json:"viewCount" gorm:"default:0;comment:查看次数"`                // 查看次数
+	LastViewedAt *time.Time `json:"lastViewedAt" gorm:"comment:最后查看时间"`                     // 最后查看时间
+	CreatorUUID  uuid.UUID  `json:"creatorUUID" gorm:"index;comment:创建人UUID"`               // 创建人UUID
+	Drawing      SysDrawing `json:"-" gorm:"foreignKey:DrawingID;references:ID;comment:图纸"` // 图纸
+}
+
+// TableName 图纸分享链接表名
+func (SysDrawingShare) TableName() string {
+	return "sys_drawing_shares"
+}
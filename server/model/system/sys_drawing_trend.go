@@ -0,0 +1,16 @@
+package system
+
+import "time"
+
+// SysDrawingTrend 图纸热度，由定时任务根据每日下载汇总按时间衰减重新计算
+type SysDrawingTrend struct {
+	DrawingID  uint      `json:"drawingId" gorm:"primaryKey;autoIncrement:false;comment:图纸ID"` // 图纸ID
+	Score      float64   `json:"score" gorm:"index;comment:热度分"`                               // 热度分（按半衰期衰减的下载次数）
+	Downloads  int64     `json:"downloads" gorm:"comment:统计窗口内下载次数"`                           // 统计窗口内下载次数
+	ComputedAt time.Time `json:"computedAt" gorm:"comment:计算时间"`                               // 计算时间
+}
+
+// TableName 图纸热度表名
+func (SysDrawingTrend) TableName() string {
+	return "sys_drawing_trends"
+}
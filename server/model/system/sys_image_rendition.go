@@ -0,0 +1,31 @@
+package system
+
+import "time"
+
+// 衍生图来源类型
+const (
+	RenditionKindCover   = "cover"   // 相册封面
+	RenditionKindPoster  = "poster"  // 图纸海报
+	RenditionKindPattern = "pattern" // 图纸文件
+)
+
+// SysImageRendition 图片衍生图（缩略图/多分辨率）表
+type SysImageRendition struct {
+	ID        uint      `json:"id" gorm:"primarykey"`                                            // 主键ID
+	CreatedAt time.Time `json:"createdAt"`                                                       // 创建时间
+	OwnerID   uint      `json:"ownerId" gorm:"index:idx_rendition_owner;comment:所属记录ID"`         // 所属记录ID
+	OwnerType string    `json:"ownerType" gorm:"index:idx_rendition_owner;size:32;comment:所属类型"` // 所属类型 sys_albums/sys_drawings
+	Kind      string    `json:"kind" gorm:"size:16;comment:来源类型 cover/poster/pattern"`           // 来源类型
+	SourceURL string    `json:"sourceURL" gorm:"comment:原图URL"`                                  // 原图URL
+	Size      string    `json:"size" gorm:"size:16;comment:规格 thumb/medium/large"`               // 规格
+	Format    string    `json:"format" gorm:"size:8;comment:格式 jpeg/png"`                        // 格式
+	Width     int       `json:"width" gorm:"comment:宽度"`                                         // 宽度
+	Height    int       `json:"height" gorm:"comment:高度"`                                        // 高度
+	URL       string    `json:"url" gorm:"comment:衍生图URL"`                                       // 衍生图URL
+	Key       string    `json:"key" gorm:"comment:存储Key"`                                        // 存储Key
+}
+
+// TableName 衍生图表名
+func (SysImageRendition) TableName() string {
+	return "sys_image_renditions"
+}
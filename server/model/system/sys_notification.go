@@ -0,0 +1,34 @@
+package system
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 站内通知类型
+const (
+	NotificationTypeAccessRequest   = "access_request"   // 收到访问申请
+	NotificationTypeAccessDecision  = "access_decision"  // 访问申请处理结果
+	NotificationTypeDrawingReview   = "drawing_review"   // 图纸审核结果
+	NotificationTypeCollectionShare = "collection_share" // 收到共享的收藏夹
+	NotificationTypeGrantExpiry     = "grant_expiry"     // 限时授权即将到期
+)
+
+// SysNotification 站内通知表
+type SysNotification struct {
+	ID        uint       `json:"id" gorm:"primarykey"`                    // 主键ID
+	CreatedAt time.Time  `json:"createdAt" gorm:"index"`                  // 通知时间
+	UserUUID  uuid.UUID  `json:"userUUID" gorm:"index;comment:接收用户UUID"`  // 接收用户UUID
+	Type      string     `json:"type" gorm:"size:32;comment:通知类型"`        // 通知类型
+	Title     string     `json:"title" gorm:"size:128;comment:标题"`        // 标题
+	Content   string     `json:"content" gorm:"size:1000;comment:内容"`     // 内容
+	RefType   string     `json:"refType" gorm:"size:32;comment:关联对象类型"`   // 关联对象类型
+	RefID     uint       `json:"refId" gorm:"comment:关联对象ID"`             // 关联对象ID
+	ReadAt    *time.Time `json:"readAt" gorm:"index;comment:阅读时间，为空表示未读"` // 阅读时间
+}
+
+// TableName 站内通知表名
+func (SysNotification) TableName() string {
+	return "sys_notifications"
+}
@@ -0,0 +1,7 @@
+package system
+
+// 全文检索的结果类型
+const (
+	SearchTypeDrawing = "drawing" // 图纸
+	SearchTypeAlbum   = "album"   // 相册
+)
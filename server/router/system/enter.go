@@ -22,30 +22,61 @@ type RouterGroup struct {
 	SysVersionRouter
 	AlbumRouter
 	MustReadRouter
+	BeadInventoryRouter
+	ImageProxyRouter
+	DrawingCompletionRouter
+	NotificationRouter
+	AccessRequestRouter
+	RecycleBinRouter
+	DownloadAnalyticsRouter
+	DownloadAuditRouter
+	DownloadQuotaRouter
+	SearchRouter
+	DrawingCollectionRouter
 }
 
 var (
-	dbApi               = api.ApiGroupApp.SystemApiGroup.DBApi
-	jwtApi              = api.ApiGroupApp.SystemApiGroup.JwtApi
-	baseApi             = api.ApiGroupApp.SystemApiGroup.BaseApi
-	casbinApi           = api.ApiGroupApp.SystemApiGroup.CasbinApi
-	systemApi           = api.ApiGroupApp.SystemApiGroup.SystemApi
-	sysParamsApi        = api.ApiGroupApp.SystemApiGroup.SysParamsApi
-	autoCodeApi         = api.ApiGroupApp.SystemApiGroup.AutoCodeApi
-	authorityApi        = api.ApiGroupApp.SystemApiGroup.AuthorityApi
-	apiRouterApi        = api.ApiGroupApp.SystemApiGroup.SystemApiApi
-	dictionaryApi       = api.ApiGroupApp.SystemApiGroup.DictionaryApi
-	authorityBtnApi     = api.ApiGroupApp.SystemApiGroup.AuthorityBtnApi
-	authorityMenuApi    = api.ApiGroupApp.SystemApiGroup.AuthorityMenuApi
-	autoCodePluginApi   = api.ApiGroupApp.SystemApiGroup.AutoCodePluginApi
-	autocodeHistoryApi  = api.ApiGroupApp.SystemApiGroup.AutoCodeHistoryApi
-	operationRecordApi  = api.ApiGroupApp.SystemApiGroup.OperationRecordApi
-	autoCodePackageApi  = api.ApiGroupApp.SystemApiGroup.AutoCodePackageApi
-	dictionaryDetailApi = api.ApiGroupApp.SystemApiGroup.DictionaryDetailApi
-	autoCodeTemplateApi = api.ApiGroupApp.SystemApiGroup.AutoCodeTemplateApi
-	exportTemplateApi   = api.ApiGroupApp.SystemApiGroup.SysExportTemplateApi
-	sysVersionApi       = api.ApiGroupApp.SystemApiGroup.SysVersionApi
-	albumApi            = api.ApiGroupApp.SystemApiGroup.AlbumApi
-	drawingApi          = api.ApiGroupApp.SystemApiGroup.DrawingApi
-	mustReadApi         = api.ApiGroupApp.SystemApiGroup.MustReadApi
+	dbApi                = api.ApiGroupApp.SystemApiGroup.DBApi
+	jwtApi               = api.ApiGroupApp.SystemApiGroup.JwtApi
+	baseApi              = api.ApiGroupApp.SystemApiGroup.BaseApi
+	casbinApi            = api.ApiGroupApp.SystemApiGroup.CasbinApi
+	systemApi            = api.ApiGroupApp.SystemApiGroup.SystemApi
+	sysParamsApi         = api.ApiGroupApp.SystemApiGroup.SysParamsApi
+	autoCodeApi          = api.ApiGroupApp.SystemApiGroup.AutoCodeApi
+	authorityApi         = api.ApiGroupApp.SystemApiGroup.AuthorityApi
+	apiRouterApi         = api.ApiGroupApp.SystemApiGroup.SystemApiApi
+	dictionaryApi        = api.ApiGroupApp.SystemApiGroup.DictionaryApi
+	authorityBtnApi      = api.ApiGroupApp.SystemApiGroup.AuthorityBtnApi
+	authorityMenuApi     = api.ApiGroupApp.SystemApiGroup.AuthorityMenuApi
+	autoCodePluginApi    = api.ApiGroupApp.SystemApiGroup.AutoCodePluginApi
+	autocodeHistoryApi   = api.ApiGroupApp.SystemApiGroup.AutoCodeHistoryApi
+	operationRecordApi   = api.ApiGroupApp.SystemApiGroup.OperationRecordApi
+	autoCodePackageApi   = api.ApiGroupApp.SystemApiGroup.AutoCodePackageApi
+	dictionaryDetailApi  = api.ApiGroupApp.SystemApiGroup.DictionaryDetailApi
+	autoCodeTemplateApi  = api.ApiGroupApp.SystemApiGroup.AutoCodeTemplateApi
+	exportTemplateApi    = api.ApiGroupApp.SystemApiGroup.SysExportTemplateApi
+	sysVersionApi        = api.ApiGroupApp.SystemApiGroup.SysVersionApi
+	albumApi             = api.ApiGroupApp.SystemApiGroup.AlbumApi
+	drawingApi           = api.ApiGroupApp.SystemApiGroup.DrawingApi
+	mustReadApi          = api.ApiGroupApp.SystemApiGroup.MustReadApi
+	beadInventoryApi     = api.ApiGroupApp.SystemApiGroup.BeadInventoryApi
+	imageProxyApi        = api.ApiGroupApp.SystemApiGroup.ImageProxyApi
+	albumFieldApi        = api.ApiGroupApp.SystemApiGroup.AlbumFieldApi
+	albumMemberApi       = api.ApiGroupApp.SystemApiGroup.AlbumMemberApi
+	accessGrantApi       = api.ApiGroupApp.SystemApiGroup.AccessGrantApi
+	albumInviteApi       = api.ApiGroupApp.SystemApiGroup.AlbumInviteApi
+	drawingShareApi      = api.ApiGroupApp.SystemApiGroup.DrawingShareApi
+	albumSerialApi       = api.ApiGroupApp.SystemApiGroup.AlbumSerialApi
+	drawingTrendApi      = api.ApiGroupApp.SystemApiGroup.DrawingTrendApi
+	drawingRelationApi   = api.ApiGroupApp.SystemApiGroup.DrawingRelationApi
+	drawingReviewApi     = api.ApiGroupApp.SystemApiGroup.DrawingReviewApi
+	drawingCompletionApi = api.ApiGroupApp.SystemApiGroup.DrawingCompletionApi
+	notificationApi      = api.ApiGroupApp.SystemApiGroup.NotificationApi
+	accessRequestApi     = api.ApiGroupApp.SystemApiGroup.AccessRequestApi
+	recycleBinApi        = api.ApiGroupApp.SystemApiGroup.RecycleBinApi
+	downloadAnalyticsApi = api.ApiGroupApp.SystemApiGroup.DownloadAnalyticsApi
+	downloadAuditApi     = api.ApiGroupApp.SystemApiGroup.DownloadAuditApi
+	downloadQuotaApi     = api.ApiGroupApp.SystemApiGroup.DownloadQuotaApi
+	searchApi            = api.ApiGroupApp.SystemApiGroup.SearchApi
+	drawingCollectionApi = api.ApiGroupApp.SystemApiGroup.DrawingCollectionApi
 )
@@ -0,0 +1,22 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type AccessRequestRouter struct{}
+
+// InitAccessRequestRouter 初始化访问申请路由
+func (s *AccessRequestRouter) InitAccessRequestRouter(Router *gin.RouterGroup) {
+	accessRequestRouter := Router.Group("accessRequest").Use(middleware.OperationRecord())
+	accessRequestRouterWithoutRecord := Router.Group("accessRequest")
+	{
+		accessRequestRouter.POST("create", accessRequestApi.CreateAccessRequest) // 提交访问申请
+		accessRequestRouter.PUT("decide", accessRequestApi.DecideAccessRequests) // 处理访问申请
+	}
+	{
+		accessRequestRouterWithoutRecord.POST("my", accessRequestApi.GetMyAccessRequests) // 获取我的访问申请
+		accessRequestRouterWithoutRecord.POST("list", accessRequestApi.GetAccessRequests) // 获取待处理的访问申请
+	}
+}
@@ -12,36 +12,68 @@ func (s *AlbumRouter) InitAlbumRouter(Router *gin.RouterGroup, PublicRouter *gin
 	albumRouter := Router.Group("album").Use(middleware.OperationRecord())
 	albumRouterWithoutRecord := Router.Group("album")
 	{
-		albumRouter.POST("create", albumApi.CreateAlbum)   // 创建相册
-		albumRouter.DELETE("delete", albumApi.DeleteAlbum) // 删除相册
-		albumRouter.PUT("update", albumApi.UpdateAlbum)    // 更新相册
+		albumRouter.POST("create", albumApi.CreateAlbum)                                  // 创建相册
+		albumRouter.DELETE("delete", albumApi.DeleteAlbum)                                // 删除相册
+		albumRouter.PUT("reorder", albumApi.ReorderAlbums)                                // 调整相册顺序
+		albumRouter.PUT("update", albumApi.UpdateAlbum)                                   // 更新相册
+		albumRouter.PUT("fields/save", albumFieldApi.SaveAlbumFields)                     // 保存相册自定义字段
+		albumRouter.POST("members/add", albumMemberApi.AddAlbumMembers)                   // 批量添加相册成员
+		albumRouter.DELETE("members/remove", albumMemberApi.RemoveAlbumMembers)           // 批量移除相册成员
+		albumRouter.POST("invites/create", albumInviteApi.CreateAlbumInvite)              // 创建相册邀请链接
+		albumRouter.PUT("invites/revoke", albumInviteApi.RevokeAlbumInvite)               // 撤销相册邀请链接
+		albumRouter.POST("invites/redeem", albumInviteApi.RedeemAlbumInvite)              // 兑换相册邀请链接
+		albumRouter.PUT("serialScheme/save", albumSerialApi.SaveAlbumSerialScheme)        // 保存相册编号规则
+		albumRouter.DELETE("serialScheme/delete", albumSerialApi.DeleteAlbumSerialScheme) // 删除相册编号规则
 	}
 	{
-		albumRouterWithoutRecord.POST("get", albumApi.GetAlbum)                           // 根据ID获取相册
-		albumRouterWithoutRecord.POST("list", albumApi.GetAlbumList)                      // 获取相册列表
-		albumRouterWithoutRecord.GET("creator/:creatorUUID", albumApi.GetAlbumsByCreator) // 根据创建者UUID获取相册列表
-		albumRouterWithoutRecord.GET("admin/:adminID", albumApi.GetAlbumsByAdmin)         // 根据管理员ID获取相册列表
+		albumRouterWithoutRecord.POST("get", albumApi.GetAlbum)                                   // 根据ID获取相册
+		albumRouterWithoutRecord.POST("list", albumApi.GetAlbumList)                              // 获取相册列表
+		albumRouterWithoutRecord.GET("creator/:creatorUUID", albumApi.GetAlbumsByCreator)         // 根据创建者UUID获取相册列表
+		albumRouterWithoutRecord.GET("admin/:adminID", albumApi.GetAlbumsByAdmin)                 // 根据管理员ID获取相册列表
+		albumRouterWithoutRecord.POST("fields/list", albumFieldApi.GetAlbumFields)                // 获取相册自定义字段
+		albumRouterWithoutRecord.POST("members/list", albumMemberApi.GetAlbumMembers)             // 获取相册成员列表
+		albumRouterWithoutRecord.POST("members/explain", albumMemberApi.ExplainDrawingAccess)     // 查询图纸访问权限来源
+		albumRouterWithoutRecord.POST("invites/list", albumInviteApi.GetAlbumInvites)             // 获取相册邀请链接列表
+		albumRouterWithoutRecord.POST("invites/redemptions", albumInviteApi.GetInviteRedemptions) // 获取邀请链接兑换记录
+		albumRouterWithoutRecord.POST("serialScheme/get", albumSerialApi.GetAlbumSerialScheme)    // 获取相册编号规则
 	}
 
 	// 图纸路由
 	drawingRouter := Router.Group("drawing").Use(middleware.OperationRecord())
 	drawingRouterWithoutRecord := Router.Group("drawing")
 	{
-		drawingRouter.POST("create", drawingApi.CreateDrawing)   // 创建图纸
-		drawingRouter.DELETE("delete", drawingApi.DeleteDrawing) // 删除图纸
-		drawingRouter.PUT("update", drawingApi.UpdateDrawing)    // 更新图纸
+		drawingRouter.POST("create", drawingApi.CreateDrawing)                     // 创建图纸
+		drawingRouter.DELETE("delete", drawingApi.DeleteDrawing)                   // 删除图纸
+		drawingRouter.PUT("update", drawingApi.UpdateDrawing)                      // 更新图纸
+		drawingRouter.POST("move", drawingApi.MoveDrawings)                        // 移动图纸到其他相册
+		drawingRouter.PUT("reorder", drawingApi.ReorderDrawings)                   // 调整相册内图纸顺序
+		drawingRouter.POST("copy", drawingApi.CopyDrawings)                        // 复制图纸到其他相册
+		drawingRouter.POST("grants/add", accessGrantApi.AddDrawingGrants)          // 批量授予图纸访问权限
+		drawingRouter.DELETE("grants/remove", accessGrantApi.RemoveDrawingGrants)  // 批量撤销图纸授权
+		drawingRouter.POST("shares/create", drawingShareApi.CreateDrawingShare)    // 创建图纸分享链接
+		drawingRouter.DELETE("shares/delete", drawingShareApi.DeleteDrawingShares) // 批量删除图纸分享链接
+		drawingRouter.PUT("review/submit", drawingReviewApi.SubmitDrawings)        // 提交图纸审核
+		drawingRouter.PUT("review/decide", drawingReviewApi.ReviewDrawings)        // 审核图纸
+		drawingRouter.PUT("review/archive", drawingReviewApi.ArchiveDrawings)      // 归档或取消归档图纸
 	}
 	{
-		drawingRouterWithoutRecord.POST("get", drawingApi.GetDrawingByID)                  // 根据ID获取图纸
-		drawingRouterWithoutRecord.POST("list", drawingApi.GetDrawingList)                 // 获取图纸列表
-		drawingRouterWithoutRecord.POST("my", drawingApi.GetMyDrawings)                    // 获取当前用户可下载的图纸列表
-		drawingRouterWithoutRecord.POST("updateEmpty", drawingApi.UpdateEmptyDrawings)     // 更新空白图纸记录（临时）
-		drawingRouterWithoutRecord.POST("download", drawingApi.DownloadDrawing)            // 下载图纸
-		drawingRouterWithoutRecord.POST("batchDownload", drawingApi.BatchDownloadDrawings) // 批量下载图纸
-		drawingRouterWithoutRecord.POST("recordDownload", drawingApi.RecordDownload)       // 记录下载点击
-		drawingRouterWithoutRecord.POST("downloadStatus", drawingApi.DownloadStatus)       // 批量获取下载状态
-		drawingRouterWithoutRecord.GET("watermark/:filename", drawingApi.GetWatermarkFile) // 获取水印文件
-		drawingRouterWithoutRecord.GET("file/:filename", drawingApi.GetDrawingFile)        // 获取图纸文件
+		drawingRouterWithoutRecord.POST("get", drawingApi.GetDrawingByID)                     // 根据ID获取图纸
+		drawingRouterWithoutRecord.POST("list", drawingApi.GetDrawingList)                    // 获取图纸列表
+		drawingRouterWithoutRecord.POST("my", drawingApi.GetMyDrawings)                       // 获取当前用户可下载的图纸列表
+		drawingRouterWithoutRecord.POST("feed", drawingTrendApi.GetDrawingFeed)               // 获取个性化图纸推荐流
+		drawingRouterWithoutRecord.POST("related", drawingRelationApi.GetRelatedDrawings)     // 获取关联推荐图纸
+		drawingRouterWithoutRecord.POST("updateEmpty", drawingApi.UpdateEmptyDrawings)        // 更新空白图纸记录（临时）
+		drawingRouterWithoutRecord.POST("download", drawingApi.DownloadDrawing)               // 下载图纸
+		drawingRouterWithoutRecord.POST("batchDownload", drawingApi.BatchDownloadDrawings)    // 批量下载图纸
+		drawingRouterWithoutRecord.POST("recordDownload", drawingApi.RecordDownload)          // 记录下载点击
+		drawingRouterWithoutRecord.POST("downloadStatus", drawingApi.DownloadStatus)          // 批量获取下载状态
+		drawingRouterWithoutRecord.POST("similar", drawingApi.SearchSimilarDrawings)          // 以图搜图
+		drawingRouterWithoutRecord.GET("watermark/:filename", drawingApi.GetWatermarkFile)    // 获取水印文件
+		drawingRouterWithoutRecord.GET("file/:filename", drawingApi.GetDrawingFile)           // 获取图纸文件
+		drawingRouterWithoutRecord.POST("grants/list", accessGrantApi.GetDrawingGrants)       // 获取图纸授权列表
+		drawingRouterWithoutRecord.POST("shares/list", drawingShareApi.GetDrawingShares)      // 获取图纸分享链接列表
+		drawingRouterWithoutRecord.POST("review/queue", drawingReviewApi.GetReviewQueue)      // 获取待审核图纸
+		drawingRouterWithoutRecord.POST("review/history", drawingReviewApi.GetDrawingReviews) // 获取图纸审核记录
 	}
 
 	// 在公共路由组中添加v1路径的文件访问路由，避免权限认证问题
@@ -50,4 +82,11 @@ func (s *AlbumRouter) InitAlbumRouter(Router *gin.RouterGroup, PublicRouter *gin
 		v1DrawingRouter.GET("watermark/:filename", drawingApi.GetWatermarkFile) // 获取水印文件
 		v1DrawingRouter.GET("file/:filename", drawingApi.GetDrawingFile)        // 获取图纸文件
 	}
+
+	// 图纸公开分享页，免登录访问，按IP限流
+	shareRouter := PublicRouter.Group("share").Use(middleware.ShareLimit())
+	{
+		shareRouter.GET("drawing/:token", drawingShareApi.ViewDrawingShare)  // 查看图纸分享
+		shareRouter.POST("drawing/:token", drawingShareApi.ViewDrawingShare) // 提交密码查看图纸分享
+	}
 }
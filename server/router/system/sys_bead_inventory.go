@@ -0,0 +1,24 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type BeadInventoryRouter struct{}
+
+// InitBeadInventoryRouter 初始化拼豆库存路由
+func (s *BeadInventoryRouter) InitBeadInventoryRouter(Router *gin.RouterGroup) {
+	inventoryRouter := Router.Group("inventory").Use(middleware.OperationRecord())
+	inventoryRouterWithoutRecord := Router.Group("inventory")
+	{
+		inventoryRouter.POST("create", beadInventoryApi.CreateInventory)   // 新增库存
+		inventoryRouter.PUT("update", beadInventoryApi.UpdateInventory)    // 更新库存
+		inventoryRouter.DELETE("delete", beadInventoryApi.DeleteInventory) // 删除库存
+		inventoryRouter.POST("import", beadInventoryApi.ImportInventory)   // CSV导入库存
+	}
+	{
+		inventoryRouterWithoutRecord.POST("list", beadInventoryApi.GetInventoryList) // 获取库存列表
+		inventoryRouterWithoutRecord.POST("match", beadInventoryApi.MatchDrawings)   // 库存匹配图纸
+	}
+}
@@ -0,0 +1,17 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type DownloadAnalyticsRouter struct{}
+
+// InitDownloadAnalyticsRouter 初始化下载统计路由
+func (s *DownloadAnalyticsRouter) InitDownloadAnalyticsRouter(Router *gin.RouterGroup) {
+	analyticsRouter := Router.Group("analytics/downloads")
+	{
+		analyticsRouter.POST("series", downloadAnalyticsApi.GetDownloadSeries)   // 获取下载量时间序列
+		analyticsRouter.POST("top", downloadAnalyticsApi.GetTopDrawings)         // 获取下载量排行
+		analyticsRouter.POST("summary", downloadAnalyticsApi.GetDownloadSummary) // 获取下载概况
+	}
+}
@@ -0,0 +1,15 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type DownloadAuditRouter struct{}
+
+// InitDownloadAuditRouter 初始化下载审计路由
+func (s *DownloadAuditRouter) InitDownloadAuditRouter(Router *gin.RouterGroup) {
+	auditRouter := Router.Group("downloadAudit")
+	{
+		auditRouter.POST("search", downloadAuditApi.SearchDownloadAudits) // 检索下载审计记录
+	}
+}
@@ -0,0 +1,16 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type DownloadQuotaRouter struct{}
+
+// InitDownloadQuotaRouter 初始化下载额度路由
+func (s *DownloadQuotaRouter) InitDownloadQuotaRouter(Router *gin.RouterGroup) {
+	quotaRouter := Router.Group("downloadQuota")
+	{
+		quotaRouter.POST("usage", downloadQuotaApi.GetDownloadQuotaUsage)   // 查看用户下载额度用量
+		quotaRouter.POST("reset", downloadQuotaApi.ResetDownloadQuotaUsage) // 重置用户下载额度用量
+	}
+}
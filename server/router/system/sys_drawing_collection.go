@@ -0,0 +1,30 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type DrawingCollectionRouter struct{}
+
+// InitDrawingCollectionRouter 初始化图纸收藏夹路由
+func (s *DrawingCollectionRouter) InitDrawingCollectionRouter(Router *gin.RouterGroup) {
+	collectionRouter := Router.Group("collection").Use(middleware.OperationRecord())
+	collectionRouterWithoutRecord := Router.Group("collection")
+	{
+		collectionRouter.POST("create", drawingCollectionApi.CreateCollection)                    // 创建收藏夹
+		collectionRouter.PUT("update", drawingCollectionApi.UpdateCollection)                     // 更新收藏夹
+		collectionRouter.DELETE("delete", drawingCollectionApi.DeleteCollection)                  // 删除收藏夹
+		collectionRouter.POST("drawings/add", drawingCollectionApi.AddCollectionDrawings)         // 将图纸加入收藏夹
+		collectionRouter.DELETE("drawings/remove", drawingCollectionApi.RemoveCollectionDrawings) // 将图纸移出收藏夹
+		collectionRouter.PUT("drawings/reorder", drawingCollectionApi.ReorderCollectionDrawings)  // 调整收藏夹中图纸的顺序
+		collectionRouter.POST("share", drawingCollectionApi.ShareCollection)                      // 共享收藏夹
+		collectionRouter.DELETE("unshare", drawingCollectionApi.UnshareCollection)                // 取消共享收藏夹
+		collectionRouter.POST("favorite", drawingCollectionApi.SetDrawingFavorite)                // 收藏或取消收藏图纸
+	}
+	{
+		collectionRouterWithoutRecord.POST("list", drawingCollectionApi.GetCollections)            // 获取收藏夹列表
+		collectionRouterWithoutRecord.POST("drawings", drawingCollectionApi.GetCollectionDrawings) // 获取收藏夹中的图纸
+		collectionRouterWithoutRecord.POST("download", drawingCollectionApi.DownloadCollection)    // 批量下载收藏夹
+	}
+}
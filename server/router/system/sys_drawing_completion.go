@@ -0,0 +1,24 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type DrawingCompletionRouter struct{}
+
+// InitDrawingCompletionRouter 初始化图纸完成记录路由
+func (s *DrawingCompletionRouter) InitDrawingCompletionRouter(Router *gin.RouterGroup) {
+	completionRouter := Router.Group("completion").Use(middleware.OperationRecord())
+	completionRouterWithoutRecord := Router.Group("completion")
+	{
+		completionRouter.POST("create", drawingCompletionApi.CreateCompletion)     // 提交完成记录
+		completionRouter.PUT("update", drawingCompletionApi.UpdateCompletion)      // 修改完成记录
+		completionRouter.DELETE("delete", drawingCompletionApi.DeleteCompletion)   // 删除完成记录
+		completionRouter.PUT("moderate", drawingCompletionApi.ModerateCompletions) // 审核完成记录
+	}
+	{
+		completionRouterWithoutRecord.POST("gallery", drawingCompletionApi.GetCompletionGallery) // 获取图纸成品墙
+		completionRouterWithoutRecord.POST("my", drawingCompletionApi.GetMyCompletions)          // 获取我的完成记录
+	}
+}
@@ -0,0 +1,15 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type ImageProxyRouter struct{}
+
+// InitImageProxyRouter 初始化图片缩放路由，图片通过 <img> 直接引用，不做鉴权
+func (s *ImageProxyRouter) InitImageProxyRouter(PublicRouter *gin.RouterGroup) {
+	imgRouter := PublicRouter.Group("img")
+	{
+		imgRouter.GET("*key", imageProxyApi.GetImage) // 图片实时缩放
+	}
+}
@@ -0,0 +1,17 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationRouter struct{}
+
+// InitNotificationRouter 初始化站内通知路由
+func (s *NotificationRouter) InitNotificationRouter(Router *gin.RouterGroup) {
+	notificationRouter := Router.Group("notification")
+	{
+		notificationRouter.POST("list", notificationApi.GetNotifications)                 // 获取站内通知
+		notificationRouter.PUT("read", notificationApi.MarkNotificationsRead)             // 标记通知已读
+		notificationRouter.GET("unreadCount", notificationApi.GetUnreadNotificationCount) // 获取未读通知数量
+	}
+}
@@ -0,0 +1,21 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type RecycleBinRouter struct{}
+
+// InitRecycleBinRouter 初始化回收站路由
+func (s *RecycleBinRouter) InitRecycleBinRouter(Router *gin.RouterGroup) {
+	recycleBinRouter := Router.Group("recycleBin").Use(middleware.OperationRecord())
+	recycleBinRouterWithoutRecord := Router.Group("recycleBin")
+	{
+		recycleBinRouter.PUT("restore", recycleBinApi.RestoreRecycleBinItems) // 恢复回收站条目
+		recycleBinRouter.DELETE("purge", recycleBinApi.PurgeRecycleBinItems)  // 彻底删除回收站条目
+	}
+	{
+		recycleBinRouterWithoutRecord.POST("list", recycleBinApi.GetRecycleBinItems) // 获取回收站条目
+	}
+}
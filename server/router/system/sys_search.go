@@ -0,0 +1,20 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchRouter struct{}
+
+// InitSearchRouter 初始化全文检索路由
+func (s *SearchRouter) InitSearchRouter(Router *gin.RouterGroup) {
+	searchRouter := Router.Group("search")
+	searchRouterWithRecord := Router.Group("search").Use(middleware.OperationRecord())
+	{
+		searchRouter.POST("query", searchApi.Search) // 全文检索图纸与相册
+	}
+	{
+		searchRouterWithRecord.POST("rebuild", searchApi.RebuildIndex) // 重建检索索引
+	}
+}
@@ -22,10 +22,11 @@ func (s *UserRouter) InitUserRouter(Router *gin.RouterGroup) {
 		userRouter.PUT("setSelfSetting", baseApi.SetSelfSetting)          // 用户界面配置
 	}
 	{
-		userRouterWithoutRecord.POST("getUserList", baseApi.GetUserList)            // 分页获取用户列表
-		userRouterWithoutRecord.GET("getUserInfo", baseApi.GetUserInfo)             // 获取自身信息
-		userRouterWithoutRecord.GET("getUserDetail/:id", baseApi.GetUserDetail)     // 获取用户详情
-		userRouterWithoutRecord.GET("getUserDrawings/:id", baseApi.GetUserDrawings) // 获取用户图纸列表
-		userRouterWithoutRecord.GET("getAdminUsers", baseApi.GetAdminUsers)         // 获取超级管理员和管理员用户列表
+		userRouterWithoutRecord.POST("getUserList", baseApi.GetUserList)                  // 分页获取用户列表
+		userRouterWithoutRecord.GET("getUserInfo", baseApi.GetUserInfo)                   // 获取自身信息
+		userRouterWithoutRecord.GET("getUserDetail/:id", baseApi.GetUserDetail)           // 获取用户详情
+		userRouterWithoutRecord.GET("getUserDrawings/:id", baseApi.GetUserDrawings)       // 获取用户图纸列表
+		userRouterWithoutRecord.GET("getUserCompletions/:id", baseApi.GetUserCompletions) // 获取用户完成记录
+		userRouterWithoutRecord.GET("getAdminUsers", baseApi.GetAdminUsers)               // 获取超级管理员和管理员用户列表
 	}
 }
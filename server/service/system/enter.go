@@ -21,6 +21,28 @@ type ServiceGroup struct {
 	AlbumService
 	DrawingService
 	MustReadService
+	BeadInventoryService
+	ImageRenditionService
+	ImageProxyService
+	AlbumFieldService
+	DrawingCompletionService
+	AlbumMemberService
+	AccessGrantService
+	NotificationService
+	AccessRequestService
+	AlbumInviteService
+	DrawingShareService
+	AlbumSerialService
+	RecycleBinService
+	DrawingReviewService
+	DownloadAnalyticsService
+	DownloadAuditService
+	DownloadHistoryService
+	DownloadQuotaService
+	DrawingTrendService
+	DrawingRelationService
+	SearchService
+	DrawingCollectionService
 	AutoCodePlugin   autoCodePlugin
 	AutoCodePackage  autoCodePackage
 	AutoCodeHistory  autoCodeHistory
@@ -0,0 +1,360 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// drawingGrantAccessSQL 图纸限时授权访问条件，参数依次为用户UUID、当前时间、当前时间
+const drawingGrantAccessSQL = "EXISTS (SELECT 1 FROM sys_drawing_grants dg WHERE dg.drawing_id = sys_drawings.id AND dg.user_uuid = ? AND (dg.starts_at IS NULL OR dg.starts_at <= ?) AND (dg.expires_at IS NULL OR dg.expires_at > ?))"
+
+// GrantExpiryReminder 授权到期提醒内容
+type GrantExpiryReminder struct {
+	GrantType  string    // 授权类型 album/drawing
+	TargetID   uint      // 相册ID或图纸ID
+	TargetName string    // 相册标题或图纸名称
+	UserUUID   uuid.UUID // 被授权用户
+	Role       string    // 角色（相册成员）
+	ExpiresAt  time.Time // 过期时间
+}
+
+// GrantExpiryNotifier 授权到期提醒钩子（站内通知之外的渠道），返回错误时该授权下次任务继续提醒
+type GrantExpiryNotifier func(reminder GrantExpiryReminder) error
+
+var (
+	grantExpiryNotifiersMu sync.RWMutex
+	grantExpiryNotifiers   []GrantExpiryNotifier
+)
+
+// RegisterGrantExpiryNotifier 注册授权到期提醒钩子（如邮件、短信）
+func RegisterGrantExpiryNotifier(notifier GrantExpiryNotifier) {
+	grantExpiryNotifiersMu.Lock()
+	defer grantExpiryNotifiersMu.Unlock()
+	grantExpiryNotifiers = append(grantExpiryNotifiers, notifier)
+}
+
+// notifyGrantExpiry 依次调用已注册的外部提醒钩子，站内通知在 markGrantReminded 中与提醒标记一并写入
+func notifyGrantExpiry(reminder GrantExpiryReminder) error {
+	grantExpiryNotifiersMu.RLock()
+	notifiers := grantExpiryNotifiers
+	grantExpiryNotifiersMu.RUnlock()
+
+	global.GVA_LOG.Info("授权即将到期",
+		zap.String("grantType", reminder.GrantType),
+		zap.Uint("targetID", reminder.TargetID),
+		zap.String("targetName", reminder.TargetName),
+		zap.String("userUUID", reminder.UserUUID.String()),
+		zap.Time("expiresAt", reminder.ExpiresAt))
+	for _, notifier := range notifiers {
+		if err := notifier(reminder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateGrantWindow 校验授权的生效与过期时间
+func validateGrantWindow(startsAt, expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errors.New("过期时间必须晚于当前时间")
+	}
+	if startsAt != nil && expiresAt != nil && !expiresAt.After(*startsAt) {
+		return errors.New("过期时间必须晚于生效时间")
+	}
+	return nil
+}
+
+type AccessGrantService struct{}
+
+// checkDrawingsManageable 校验用户能否为图纸授权：图纸创建者或相册创建者/管理员
+func checkDrawingsManageable(drawingIDs []uint, userID uint, userUUID uuid.UUID) ([]system.SysDrawing, error) {
+	var drawings []system.SysDrawing
+	if err := global.GVA_DB.Select("id", "album_id", "creator_uuid").Where("id IN ?", drawingIDs).Find(&drawings).Error; err != nil {
+		return nil, err
+	}
+	if len(drawings) == 0 {
+		return nil, errors.New("图纸不存在")
+	}
+	checked := make(map[uint]bool)
+	for _, drawing := range drawings {
+		if drawing.CreatorUUID == userUUID || checked[drawing.AlbumID] {
+			continue
+		}
+		ok, err := canManageAlbum(drawing.AlbumID, userID, userUUID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("仅图纸创建者或相册创建者/管理员可以授权")
+		}
+		checked[drawing.AlbumID] = true
+	}
+	return drawings, nil
+}
+
+// AddDrawingGrants 为用户批量授予图纸访问权限，已有授权的更新生效与过期时间
+func (grantService *AccessGrantService) AddDrawingGrants(req request.AddDrawingGrants, userID uint, userUUID uuid.UUID) (int, error) {
+	if err := validateGrantWindow(req.StartsAt, req.ExpiresAt); err != nil {
+		return 0, err
+	}
+	drawings, err := checkDrawingsManageable(req.DrawingIDs, userID, userUUID)
+	if err != nil {
+		return 0, err
+	}
+	var users []system.SysUser
+	if err = global.GVA_DB.Where("id IN ?", req.UserIDs).Find(&users).Error; err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, errors.New("用户不存在")
+	}
+
+	count := 0
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, drawing := range drawings {
+			for _, user := range users {
+				if err := upsertDrawingGrant(tx, drawing.ID, user, req.StartsAt, req.ExpiresAt, userUUID); err != nil {
+					return err
+				}
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// upsertDrawingGrant 授予图纸访问权限，已有授权时更新授权时间并重置到期提醒
+func upsertDrawingGrant(tx *gorm.DB, drawingID uint, user system.SysUser, startsAt, expiresAt *time.Time, grantedBy uuid.UUID) error {
+	var grant system.SysDrawingGrant
+	err := tx.Where("drawing_id = ? AND user_uuid = ?", drawingID, user.UUID).Take(&grant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Create(&system.SysDrawingGrant{
+			DrawingID: drawingID,
+			UserID:    user.ID,
+			UserUUID:  user.UUID,
+			StartsAt:  startsAt,
+			ExpiresAt: expiresAt,
+			GrantedBy: grantedBy,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Model(&grant).Updates(map[string]interface{}{
+		"starts_at":   startsAt,
+		"expires_at":  expiresAt,
+		"reminded_at": nil,
+		"granted_by":  grantedBy,
+	}).Error
+}
+
+// RemoveDrawingGrants 批量撤销图纸授权，返回实际撤销数量
+func (grantService *AccessGrantService) RemoveDrawingGrants(req request.RemoveDrawingGrants, userID uint, userUUID uuid.UUID) (int64, error) {
+	drawings, err := checkDrawingsManageable(req.DrawingIDs, userID, userUUID)
+	if err != nil {
+		return 0, err
+	}
+	ids := make([]uint, 0, len(drawings))
+	for _, drawing := range drawings {
+		ids = append(ids, drawing.ID)
+	}
+	result := global.GVA_DB.Where("drawing_id IN ? AND user_id IN ?", ids, req.UserIDs).Delete(&system.SysDrawingGrant{})
+	return result.RowsAffected, result.Error
+}
+
+// GetDrawingGrants 获取图纸的授权列表（含已过期与未生效的授权）
+func (grantService *AccessGrantService) GetDrawingGrants(req request.GetDrawingGrants, userID uint, userUUID uuid.UUID) (list []system.SysDrawingGrant, total int64, err error) {
+	if _, err = checkDrawingsManageable([]uint{req.DrawingID}, userID, userUUID); err != nil {
+		return nil, 0, err
+	}
+	db := global.GVA_DB.Model(&system.SysDrawingGrant{}).Where("drawing_id = ?", req.DrawingID)
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Preload("User").Order("id ASC").Find(&list).Error
+	return list, total, err
+}
+
+// RevokeExpiredGrants 回收已过期的相册成员与图纸授权，并写入授权变更记录
+func (grantService *AccessGrantService) RevokeExpiredGrants() (albumRevoked int, drawingRevoked int, err error) {
+	now := time.Now()
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var members []system.SysAlbumMember
+		if err := tx.Where("expires_at <= ?", now).Find(&members).Error; err != nil {
+			return err
+		}
+		var grants []system.SysDrawingGrant
+		if err := tx.Where("expires_at <= ?", now).Find(&grants).Error; err != nil {
+			return err
+		}
+
+		logs := make([]system.SysAccessGrantLog, 0, len(members)+len(grants))
+		memberIDs := make([]uint, 0, len(members))
+		for _, member := range members {
+			memberIDs = append(memberIDs, member.ID)
+			logs = append(logs, system.SysAccessGrantLog{
+				GrantType: system.AccessGrantTypeAlbum,
+				TargetID:  member.AlbumID,
+				UserUUID:  member.UserUUID,
+				Role:      member.Role,
+				StartsAt:  member.StartsAt,
+				ExpiresAt: member.ExpiresAt,
+				Action:    system.AccessGrantActionExpired,
+			})
+		}
+		grantIDs := make([]uint, 0, len(grants))
+		for _, grant := range grants {
+			grantIDs = append(grantIDs, grant.ID)
+			logs = append(logs, system.SysAccessGrantLog{
+				GrantType: system.AccessGrantTypeDrawing,
+				TargetID:  grant.DrawingID,
+				UserUUID:  grant.UserUUID,
+				StartsAt:  grant.StartsAt,
+				ExpiresAt: grant.ExpiresAt,
+				Action:    system.AccessGrantActionExpired,
+			})
+		}
+		if len(logs) == 0 {
+			return nil
+		}
+
+		if err := tx.CreateInBatches(&logs, 100).Error; err != nil {
+			return err
+		}
+		if len(memberIDs) > 0 {
+			if err := tx.Where("id IN ?", memberIDs).Delete(&system.SysAlbumMember{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(grantIDs) > 0 {
+			if err := tx.Where("id IN ?", grantIDs).Delete(&system.SysDrawingGrant{}).Error; err != nil {
+				return err
+			}
+		}
+		albumRevoked, drawingRevoked = len(memberIDs), len(grantIDs)
+		return nil
+	})
+	return albumRevoked, drawingRevoked, err
+}
+
+// RemindExpiringGrants 对 days 天内到期且尚未提醒的授权调用提醒钩子，返回成功提醒的数量
+func (grantService *AccessGrantService) RemindExpiringGrants(days int) (int, error) {
+	if days <= 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	deadline := now.AddDate(0, 0, days)
+	reminded := 0
+
+	var members []system.SysAlbumMember
+	err := global.GVA_DB.Where("expires_at > ? AND expires_at <= ? AND reminded_at IS NULL", now, deadline).Find(&members).Error
+	if err != nil {
+		return 0, err
+	}
+	albumTitles := make(map[uint]string)
+	if len(members) > 0 {
+		albumIDs := make([]uint, 0, len(members))
+		for _, member := range members {
+			albumIDs = append(albumIDs, member.AlbumID)
+		}
+		var albums []system.SysAlbum
+		if err = global.GVA_DB.Select("id", "title").Where("id IN ?", albumIDs).Find(&albums).Error; err != nil {
+			return 0, err
+		}
+		for _, album := range albums {
+			albumTitles[album.ID] = album.Title
+		}
+	}
+	for _, member := range members {
+		reminder := GrantExpiryReminder{
+			GrantType:  system.AccessGrantTypeAlbum,
+			TargetID:   member.AlbumID,
+			TargetName: albumTitles[member.AlbumID],
+			UserUUID:   member.UserUUID,
+			Role:       member.Role,
+			ExpiresAt:  *member.ExpiresAt,
+		}
+		if markGrantReminded(&system.SysAlbumMember{}, member.ID, reminder, member.StartsAt) {
+			reminded++
+		}
+	}
+
+	var grants []system.SysDrawingGrant
+	err = global.GVA_DB.Preload("Drawing").Where("expires_at > ? AND expires_at <= ? AND reminded_at IS NULL", now, deadline).Find(&grants).Error
+	if err != nil {
+		return reminded, err
+	}
+	for _, grant := range grants {
+		reminder := GrantExpiryReminder{
+			GrantType:  system.AccessGrantTypeDrawing,
+			TargetID:   grant.DrawingID,
+			TargetName: grant.Drawing.Name,
+			UserUUID:   grant.UserUUID,
+			ExpiresAt:  *grant.ExpiresAt,
+		}
+		if markGrantReminded(&system.SysDrawingGrant{}, grant.ID, reminder, grant.StartsAt) {
+			reminded++
+		}
+	}
+	return reminded, nil
+}
+
+// grantExpiryNotification 授权到期提醒的站内通知
+func grantExpiryNotification(reminder GrantExpiryReminder) system.SysNotification {
+	expiresAt := reminder.ExpiresAt.Format("2006-01-02 15:04")
+	content := fmt.Sprintf("您对图纸「%s」的访问授权将于 %s 到期", reminder.TargetName, expiresAt)
+	if reminder.GrantType == system.AccessGrantTypeAlbum {
+		content = fmt.Sprintf("您在相册「%s」的成员权限（%s）将于 %s 到期", reminder.TargetName, reminder.Role, expiresAt)
+	}
+	return system.SysNotification{
+		Type:    system.NotificationTypeGrantExpiry,
+		Title:   "授权即将到期",
+		Content: content,
+		RefType: reminder.GrantType,
+		RefID:   reminder.TargetID,
+	}
+}
+
+// markGrantReminded 发送提醒并标记已提醒：站内通知、提醒标记与授权变更记录在同一事务中写入
+func markGrantReminded(model interface{}, id uint, reminder GrantExpiryReminder, startsAt *time.Time) bool {
+	if err := notifyGrantExpiry(reminder); err != nil {
+		global.GVA_LOG.Warn("发送授权到期提醒失败", zap.String("userUUID", reminder.UserUUID.String()), zap.Error(err))
+		return false
+	}
+	expiresAt := reminder.ExpiresAt
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(model).Where("id = ?", id).Update("reminded_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if err := createNotifications(tx, []uuid.UUID{reminder.UserUUID}, grantExpiryNotification(reminder)); err != nil {
+			return err
+		}
+		return tx.Create(&system.SysAccessGrantLog{
+			GrantType: reminder.GrantType,
+			TargetID:  reminder.TargetID,
+			UserUUID:  reminder.UserUUID,
+			Role:      reminder.Role,
+			StartsAt:  startsAt,
+			ExpiresAt: &expiresAt,
+			Action:    system.AccessGrantActionReminded,
+		}).Error
+	})
+	if err != nil {
+		global.GVA_LOG.Error("记录授权到期提醒失败", zap.Uint("id", id), zap.Error(err))
+		return false
+	}
+	return true
+}
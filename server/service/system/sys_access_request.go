@@ -0,0 +1,303 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxAccessRequestMessageLength 申请留言与处理备注长度上限
+const maxAccessRequestMessageLength = 500
+
+type AccessRequestService struct{}
+
+// CreateRequest 提交图纸或相册访问申请，并通知相册创建者与管理员
+func (requestService *AccessRequestService) CreateRequest(req request.CreateAccessRequest, userID uint, userUUID uuid.UUID) (system.SysAccessRequest, error) {
+	message := strings.TrimSpace(req.Message)
+	if utf8.RuneCountInString(message) > maxAccessRequestMessageLength {
+		return system.SysAccessRequest{}, fmt.Errorf("申请留言不能超过 %d 个字", maxAccessRequestMessageLength)
+	}
+	accessRequest := system.SysAccessRequest{
+		UserID:     userID,
+		UserUUID:   userUUID,
+		TargetType: req.TargetType,
+		Message:    message,
+		Status:     system.AccessRequestStatusPending,
+	}
+
+	var targetName string
+	switch req.TargetType {
+	case system.AccessGrantTypeAlbum:
+		role := req.Role
+		if role == "" {
+			role = system.AlbumRoleDownloader
+		}
+		if system.AlbumRoleRank(role) == 0 || role == system.AlbumRoleAdmin {
+			return accessRequest, errors.New("不支持申请该角色")
+		}
+		var album system.SysAlbum
+		if err := global.GVA_DB.Select("id", "title").First(&album, req.TargetID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return accessRequest, errors.New("相册不存在")
+			}
+			return accessRequest, err
+		}
+		manager, err := canManageAlbum(album.ID, userID, userUUID)
+		if err != nil {
+			return accessRequest, err
+		}
+		current, err := albumMemberRole(album.ID, userUUID)
+		if err != nil {
+			return accessRequest, err
+		}
+		if manager || system.AlbumRoleRank(current) >= system.AlbumRoleRank(role) {
+			return accessRequest, errors.New("已拥有该相册的访问权限")
+		}
+		accessRequest.AlbumID, accessRequest.Role, targetName = album.ID, role, "相册《"+album.Title+"》"
+	case system.AccessGrantTypeDrawing:
+		var drawing system.SysDrawing
+		if err := global.GVA_DB.Select("id", "album_id", "name").First(&drawing, req.TargetID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return accessRequest, errors.New("图纸不存在")
+			}
+			return accessRequest, err
+		}
+		if checkDrawingsDownloadable([]uint{drawing.ID}, userUUID) == nil {
+			return accessRequest, errors.New("已拥有该图纸的访问权限")
+		}
+		accessRequest.AlbumID, accessRequest.DrawingID, accessRequest.Role, targetName = drawing.AlbumID, drawing.ID, system.AlbumRoleDownloader, "图纸《"+drawing.Name+"》"
+	default:
+		return accessRequest, errors.New("不支持的申请对象类型")
+	}
+
+	var pending int64
+	err := global.GVA_DB.Model(&system.SysAccessRequest{}).
+		Where("user_uuid = ? AND target_type = ? AND album_id = ? AND drawing_id = ? AND status = ?",
+			userUUID, accessRequest.TargetType, accessRequest.AlbumID, accessRequest.DrawingID, system.AccessRequestStatusPending).
+		Count(&pending).Error
+	if err != nil {
+		return accessRequest, err
+	}
+	if pending > 0 {
+		return accessRequest, errors.New("已有待处理的申请，请耐心等待")
+	}
+
+	managers, err := albumManagerUUIDs(accessRequest.AlbumID)
+	if err != nil {
+		return accessRequest, err
+	}
+	var applicant system.SysUser
+	if err = global.GVA_DB.Select("id", "uuid", "username", "nick_name").Where("uuid = ?", userUUID).First(&applicant).Error; err != nil {
+		return accessRequest, err
+	}
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&accessRequest).Error; err != nil {
+			return err
+		}
+		content := fmt.Sprintf("%s 申请访问%s", displayName(applicant), targetName)
+		if message != "" {
+			content += "：" + message
+		}
+		return createNotifications(tx, excludeUUID(managers, userUUID), system.SysNotification{
+			Type:    system.NotificationTypeAccessRequest,
+			Title:   "新的访问申请",
+			Content: content,
+			RefType: "access_request",
+			RefID:   accessRequest.ID,
+		})
+	})
+	return accessRequest, err
+}
+
+// GetMyRequests 获取当前用户提交的访问申请
+func (requestService *AccessRequestService) GetMyRequests(req request.GetMyAccessRequests, userUUID uuid.UUID) (list []system.SysAccessRequest, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysAccessRequest{}).Where("user_uuid = ?", userUUID)
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+	return findAccessRequests(db, req.Page, req.PageSize)
+}
+
+// GetRequests 获取当前用户管理的相册下的访问申请
+func (requestService *AccessRequestService) GetRequests(req request.GetAccessRequests, userID uint, userUUID uuid.UUID) (list []system.SysAccessRequest, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysAccessRequest{})
+	if req.AlbumID != 0 {
+		ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			return nil, 0, errors.New("仅相册创建者或管理员可以查看申请")
+		}
+		db = db.Where("album_id = ?", req.AlbumID)
+	} else {
+		albumIDs, err := managedAlbumIDs(userID, userUUID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(albumIDs) == 0 {
+			return []system.SysAccessRequest{}, 0, nil
+		}
+		db = db.Where("album_id IN ?", albumIDs)
+	}
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+	return findAccessRequests(db, req.Page, req.PageSize)
+}
+
+// findAccessRequests 分页查询访问申请并预加载关联信息
+func findAccessRequests(db *gorm.DB, page, pageSize int) (list []system.SysAccessRequest, total int64, err error) {
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if page > 0 && pageSize > 0 {
+		db = db.Offset((page - 1) * pageSize).Limit(pageSize)
+	}
+	err = db.Preload("User").Preload("Album").Preload("Drawing").Order("id DESC").Find(&list).Error
+	return list, total, err
+}
+
+// DecideRequests 处理访问申请，通过时自动创建相册成员或图纸授权，并通知申请人
+func (requestService *AccessRequestService) DecideRequests(req request.DecideAccessRequests, userID uint, userUUID uuid.UUID) error {
+	switch req.Status {
+	case system.AccessRequestStatusApproved:
+		if err := validateGrantWindow(nil, req.ExpiresAt); err != nil {
+			return err
+		}
+		if req.Role != "" && system.AlbumRoleRank(req.Role) == 0 {
+			return errors.New("不支持的成员角色")
+		}
+	case system.AccessRequestStatusRejected:
+	default:
+		return errors.New("处理结果只能是通过或拒绝")
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxAccessRequestMessageLength {
+		return fmt.Errorf("处理备注不能超过 %d 个字", maxAccessRequestMessageLength)
+	}
+
+	var requests []system.SysAccessRequest
+	if err := global.GVA_DB.Preload("User").Preload("Album").Preload("Drawing").Where("id IN ?", req.IDs).Find(&requests).Error; err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		return errors.New("申请不存在")
+	}
+	// 相册ID -> 处理人是否为相册创建者或相册管理员；只有他们可以授予高于申请的角色（包括管理角色）
+	owners := make(map[uint]bool)
+	for _, r := range requests {
+		if r.Status != system.AccessRequestStatusPending {
+			return fmt.Errorf("申请 %d 已处理", r.ID)
+		}
+		owner, checked := owners[r.AlbumID]
+		if !checked {
+			ok, err := canManageAlbum(r.AlbumID, userID, userUUID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.New("仅相册创建者或管理员可以处理申请")
+			}
+			if owner, err = isAlbumOwner(r.AlbumID, userID, userUUID); err != nil {
+				return err
+			}
+			owners[r.AlbumID] = owner
+		}
+		if req.Status == system.AccessRequestStatusApproved && r.TargetType == system.AccessGrantTypeAlbum &&
+			system.AlbumRoleRank(req.Role) > system.AlbumRoleRank(r.Role) && !owner {
+			return fmt.Errorf("申请 %d 授予的角色不能高于申请的角色", r.ID)
+		}
+	}
+
+	now := time.Now()
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, r := range requests {
+			title, verdict, targetName := "访问申请被拒绝", "被拒绝", "相册《"+r.Album.Title+"》"
+			if r.TargetType == system.AccessGrantTypeDrawing {
+				targetName = "图纸《" + r.Drawing.Name + "》"
+			}
+			if req.Status == system.AccessRequestStatusApproved {
+				title, verdict = "访问申请已通过", "已通过"
+				if err := grantAccessRequest(tx, r, req.Role, req.ExpiresAt, userUUID); err != nil {
+					return err
+				}
+			}
+
+			// 以状态为条件更新，避免并发重复处理
+			result := tx.Model(&system.SysAccessRequest{}).
+				Where("id = ? AND status = ?", r.ID, system.AccessRequestStatusPending).
+				Updates(map[string]interface{}{
+					"status":        req.Status,
+					"decided_by":    userUUID,
+					"decided_at":    now,
+					"decision_note": note,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("申请 %d 已处理", r.ID)
+			}
+
+			content := "你对" + targetName + "的访问申请" + verdict
+			if note != "" {
+				content += "：" + note
+			}
+			err := createNotifications(tx, []uuid.UUID{r.UserUUID}, system.SysNotification{
+				Type:    system.NotificationTypeAccessDecision,
+				Title:   title,
+				Content: content,
+				RefType: "access_request",
+				RefID:   r.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// grantAccessRequest 按申请创建相册成员或图纸授权
+func grantAccessRequest(tx *gorm.DB, r system.SysAccessRequest, role string, expiresAt *time.Time, grantedBy uuid.UUID) error {
+	if r.User.ID == 0 {
+		return fmt.Errorf("申请 %d 的申请人不存在", r.ID)
+	}
+	if r.TargetType == system.AccessGrantTypeDrawing {
+		return upsertDrawingGrant(tx, r.DrawingID, r.User, nil, expiresAt, grantedBy)
+	}
+	if role == "" {
+		role = r.Role
+	}
+	_, err := upsertAlbumMember(tx, r.AlbumID, r.User, role, nil, expiresAt, grantedBy)
+	return err
+}
+
+// displayName 用户展示名称，优先使用昵称
+func displayName(user system.SysUser) string {
+	if user.NickName != "" {
+		return user.NickName
+	}
+	return user.Username
+}
+
+// excludeUUID 从列表中排除指定UUID
+func excludeUUID(ids []uuid.UUID, exclude uuid.UUID) []uuid.UUID {
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id != exclude {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestDecideRequestsRoleLimit(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysAlbum{}, &system.SysAlbumAdmin{}, &system.SysDrawing{}, &system.SysAlbumMember{}, &system.SysAccessRequest{}, &system.SysNotification{}); err != nil {
+		t.Fatal(err)
+	}
+	prev := global.GVA_DB
+	global.GVA_DB = db
+	defer func() { global.GVA_DB = prev }()
+
+	creator := system.SysUser{UUID: uuid.New(), Username: "creator"}
+	manager := system.SysUser{UUID: uuid.New(), Username: "manager"}
+	applicant := system.SysUser{UUID: uuid.New(), Username: "applicant"}
+	if err = db.Create([]*system.SysUser{&creator, &manager, &applicant}).Error; err != nil {
+		t.Fatal(err)
+	}
+	album := system.SysAlbum{Title: "相册", CreatorUUID: creator.UUID}
+	if err = db.Create(&album).Error; err != nil {
+		t.Fatal(err)
+	}
+	member := system.SysAlbumMember{AlbumID: album.ID, UserID: manager.ID, UserUUID: manager.UUID, Role: system.AlbumRoleAdmin}
+	if err = db.Create(&member).Error; err != nil {
+		t.Fatal(err)
+	}
+	accessRequest := system.SysAccessRequest{UserID: applicant.ID, UserUUID: applicant.UUID, TargetType: system.AccessGrantTypeAlbum, AlbumID: album.ID, Role: system.AlbumRoleViewer, Status: system.AccessRequestStatusPending}
+	if err = db.Create(&accessRequest).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	svc := &AccessRequestService{}
+	decide := func(role string) request.DecideAccessRequests {
+		return request.DecideAccessRequests{IDs: []uint{accessRequest.ID}, Status: system.AccessRequestStatusApproved, Role: role}
+	}
+	// 具有管理角色的成员不能授予高于申请的角色
+	if err = svc.DecideRequests(decide(system.AlbumRoleAdmin), manager.ID, manager.UUID); err == nil {
+		t.Fatal("manager member should not grant admin")
+	}
+	if err = svc.DecideRequests(decide(system.AlbumRoleDownloader), manager.ID, manager.UUID); err == nil {
+		t.Fatal("manager member should not grant a role above the requested one")
+	}
+	// 相册创建者可以提升角色
+	if err = svc.DecideRequests(decide(system.AlbumRoleDownloader), creator.ID, creator.UUID); err != nil {
+		t.Fatalf("creator cannot approve with a higher role: %v", err)
+	}
+	if role, _ := albumMemberRole(album.ID, applicant.UUID); role != system.AlbumRoleDownloader {
+		t.Fatalf("applicant role = %q, want %q", role, system.AlbumRoleDownloader)
+	}
+}
@@ -2,11 +2,13 @@ package system
 
 import (
 	"errors"
+	"time"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	albumRequest "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type AlbumService struct{}
@@ -74,8 +76,12 @@ func (albumService *AlbumService) CreateAlbum(albumReq albumRequest.CreateAlbum)
 		return album, err
 	}
 
+	// 异步生成封面衍生图，并同步检索索引
+	ImageRenditionServiceApp.QueueAlbumRenditions(album.ID)
+	SearchServiceApp.QueueAlbums(album.ID)
+
 	// 重新查询相册信息（包含关联数据）
-	err = global.GVA_DB.Preload("Creator").Preload("AdminUsers").First(&album, album.ID).Error
+	err = global.GVA_DB.Preload("Creator").Preload("AdminUsers").Preload("Renditions").First(&album, album.ID).Error
 	return album, err
 }
 
@@ -89,20 +95,18 @@ func (albumService *AlbumService) DeleteAlbum(albumReq albumRequest.DeleteAlbum)
 		}
 	}()
 
-	// 删除相册管理员关联
-	if err := tx.Where("album_id = ?", albumReq.ID).Delete(&system.SysAlbumAdmin{}).Error; err != nil {
+	// 相册与其图纸以同一删除时间移入回收站，管理员、成员与授权保留以便恢复
+	if err := trashAlbum(tx, albumReq.ID, time.Now()); err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	// 删除相册
-	if err := tx.Delete(&system.SysAlbum{}, albumReq.ID).Error; err != nil {
-		tx.Rollback()
+	// 提交事务
+	if err := tx.Commit().Error; err != nil {
 		return err
 	}
-
-	// 提交事务
-	return tx.Commit().Error
+	SearchServiceApp.QueueAlbums(albumReq.ID)
+	return nil
 }
 
 // UpdateAlbum 更新相册
@@ -165,12 +169,21 @@ func (albumService *AlbumService) UpdateAlbum(albumReq albumRequest.UpdateAlbum)
 	}
 
 	// 提交事务
-	return tx.Commit().Error
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	// 封面可能已变更，异步刷新衍生图；标题与描述变更同步到相册及其图纸的检索索引
+	ImageRenditionServiceApp.QueueAlbumRenditions(albumReq.ID)
+	SearchServiceApp.QueueAlbums(albumReq.ID)
+	return nil
 }
 
 // GetAlbum 根据ID获取相册
 func (albumService *AlbumService) GetAlbum(albumReq albumRequest.GetAlbumByID) (album system.SysAlbum, err error) {
-	err = global.GVA_DB.Preload("Creator").Preload("AdminUsers").First(&album, albumReq.ID).Error
+	err = global.GVA_DB.Preload("Creator").Preload("AdminUsers").Preload("Renditions").
+		Preload("Fields", func(db *gorm.DB) *gorm.DB { return db.Order("sort ASC, id ASC") }).
+		First(&album, albumReq.ID).Error
 	return album, err
 }
 
@@ -191,6 +204,11 @@ func (albumService *AlbumService) GetAlbumList(info albumRequest.GetAlbumList) (
 		db = db.Where("status = ?", info.Status)
 	}
 
+	order, err := albumListOrder(info.Sort)
+	if err != nil {
+		return
+	}
+
 	// 获取总数
 	err = db.Count(&total).Error
 	if err != nil {
@@ -198,13 +216,14 @@ func (albumService *AlbumService) GetAlbumList(info albumRequest.GetAlbumList) (
 	}
 
 	// 获取列表
-	err = db.Limit(limit).Offset(offset).Preload("Creator").Preload("AdminUsers").Find(&list).Error
+	err = db.Limit(limit).Offset(offset).Preload("Creator").Preload("AdminUsers").Preload("Renditions").Order(order).Find(&list).Error
 	return list, total, err
 }
 
 // GetAlbumsByCreator 根据创建者UUID获取相册列表
 func (albumService *AlbumService) GetAlbumsByCreator(creatorUUID uuid.UUID) (list []system.SysAlbum, err error) {
-	err = global.GVA_DB.Where("creator_uuid = ?", creatorUUID).Preload("Creator").Preload("AdminUsers").Find(&list).Error
+	err = global.GVA_DB.Where("creator_uuid = ?", creatorUUID).Preload("Creator").Preload("AdminUsers").Preload("Renditions").
+		Order("sys_albums.position ASC, sys_albums.created_at DESC").Find(&list).Error
 	return list, err
 }
 
@@ -212,7 +231,8 @@ func (albumService *AlbumService) GetAlbumsByCreator(creatorUUID uuid.UUID) (lis
 func (albumService *AlbumService) GetAlbumsByAdmin(adminID uint) (list []system.SysAlbum, err error) {
 	err = global.GVA_DB.Joins("JOIN sys_album_admin ON sys_albums.id = sys_album_admin.album_id").
 		Where("sys_album_admin.user_id = ?", adminID).
-		Preload("Creator").Preload("AdminUsers").
+		Preload("Creator").Preload("AdminUsers").Preload("Renditions").
+		Order("sys_albums.position ASC, sys_albums.created_at DESC").
 		Find(&list).Error
 	return list, err
 }
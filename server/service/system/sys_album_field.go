@@ -0,0 +1,493 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AlbumFieldService struct{}
+
+// albumFieldKeyPattern 字段标识格式
+var albumFieldKeyPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
+
+// 内置排序字段，序号按自然排序（A-9 排在 A-10 之前）
+var drawingSortColumns = map[string]string{
+	"serialNumber": "sys_drawings.serial_sort_key",
+	"name":         "sys_drawings.name",
+	"beanQuantity": "sys_drawings.bean_quantity",
+	"createdAt":    "sys_drawings.created_at",
+}
+
+// 字段筛选比较运算符
+var fieldFilterOperators = map[string]string{"eq": "=", "ne": "<>", "gt": ">", "gte": ">=", "lt": "<", "lte": "<="}
+
+// isAlbumOwner 判断用户是否为相册创建者或相册管理员（不含具有管理角色的相册成员）
+func isAlbumOwner(albumID uint, userID uint, userUUID uuid.UUID) (bool, error) {
+	var album system.SysAlbum
+	if err := global.GVA_DB.Select("id", "creator_uuid").First(&album, albumID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, errors.New("相册不存在")
+		}
+		return false, err
+	}
+	if album.CreatorUUID == userUUID {
+		return true, nil
+	}
+	var count int64
+	err := global.GVA_DB.Model(&system.SysAlbumAdmin{}).Where("album_id = ? AND user_id = ?", albumID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+// canManageAlbum 判断用户是否为相册创建者、管理员或具有管理角色的相册成员
+func canManageAlbum(albumID uint, userID uint, userUUID uuid.UUID) (bool, error) {
+	owner, err := isAlbumOwner(albumID, userID, userUUID)
+	if err != nil || owner {
+		return owner, err
+	}
+	role, err := albumMemberRole(albumID, userUUID)
+	return role == system.AlbumRoleAdmin, err
+}
+
+// buildAlbumField 校验字段定义并转换为模型
+func buildAlbumField(albumID uint, item request.AlbumFieldItem, sort int) (system.SysAlbumField, error) {
+	field := system.SysAlbumField{
+		AlbumID:  albumID,
+		Key:      strings.TrimSpace(item.Key),
+		Label:    strings.TrimSpace(item.Label),
+		Type:     item.Type,
+		Required: item.Required,
+		Min:      item.Min,
+		Max:      item.Max,
+		Pattern:  item.Pattern,
+		Sort:     sort,
+	}
+	if !albumFieldKeyPattern.MatchString(field.Key) {
+		return field, fmt.Errorf("字段标识 %s 格式错误，需以字母开头且仅包含字母、数字、下划线", field.Key)
+	}
+	if _, ok := drawingSortColumns[field.Key]; ok {
+		return field, fmt.Errorf("字段标识 %s 与内置字段冲突", field.Key)
+	}
+	if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+		return field, fmt.Errorf("字段 %s 最小值不能大于最大值", field.Key)
+	}
+
+	switch field.Type {
+	case system.AlbumFieldTypeText:
+		if field.Pattern != "" {
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return field, fmt.Errorf("字段 %s 校验正则无效", field.Key)
+			}
+		}
+	case system.AlbumFieldTypeEnum:
+		options := make([]string, 0, len(item.Options))
+		seen := make(map[string]bool)
+		for _, option := range item.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				continue
+			}
+			seen[option] = true
+			options = append(options, option)
+		}
+		if len(options) == 0 {
+			return field, fmt.Errorf("枚举字段 %s 至少需要一个选项", field.Key)
+		}
+		data, _ := json.Marshal(options)
+		field.Options = string(data)
+	case system.AlbumFieldTypeNumber, system.AlbumFieldTypeInteger, system.AlbumFieldTypeBoolean, system.AlbumFieldTypeDate:
+	default:
+		return field, fmt.Errorf("字段 %s 类型 %s 不支持", field.Key, field.Type)
+	}
+	return field, nil
+}
+
+// SaveAlbumFields 保存相册自定义字段定义（整体替换）
+// 未出现在新定义中的字段连同图纸字段值一起删除；已有数据的字段不允许修改类型
+func (fieldService *AlbumFieldService) SaveAlbumFields(req request.SaveAlbumFields, userID uint, userUUID uuid.UUID) ([]system.SysAlbumField, error) {
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("仅相册创建者或管理员可以修改字段")
+	}
+
+	fields := make([]system.SysAlbumField, 0, len(req.Fields))
+	keys := make(map[string]bool, len(req.Fields))
+	for i, item := range req.Fields {
+		field, err := buildAlbumField(req.AlbumID, item, i)
+		if err != nil {
+			return nil, err
+		}
+		if keys[field.Key] {
+			return nil, fmt.Errorf("字段标识 %s 重复", field.Key)
+		}
+		keys[field.Key] = true
+		fields = append(fields, field)
+	}
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var existing []system.SysAlbumField
+		if err := tx.Where("album_id = ?", req.AlbumID).Find(&existing).Error; err != nil {
+			return err
+		}
+		byKey := make(map[string]system.SysAlbumField, len(existing))
+		var removed []uint
+		for _, field := range existing {
+			byKey[field.Key] = field
+			if !keys[field.Key] {
+				removed = append(removed, field.ID)
+			}
+		}
+
+		if len(removed) > 0 {
+			if err := tx.Where("field_id IN ?", removed).Delete(&system.SysDrawingFieldValue{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Delete(&system.SysAlbumField{}, removed).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range fields {
+			old, ok := byKey[fields[i].Key]
+			if !ok {
+				if err := tx.Create(&fields[i]).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if old.Type != fields[i].Type {
+				var count int64
+				if err := tx.Model(&system.SysDrawingFieldValue{}).Where("field_id = ?", old.ID).Count(&count).Error; err != nil {
+					return err
+				}
+				if count > 0 {
+					return fmt.Errorf("字段 %s 已有数据，无法修改类型", old.Key)
+				}
+			}
+			updates := map[string]interface{}{
+				"label":    fields[i].Label,
+				"type":     fields[i].Type,
+				"required": fields[i].Required,
+				"options":  fields[i].Options,
+				"min":      fields[i].Min,
+				"max":      fields[i].Max,
+				"pattern":  fields[i].Pattern,
+				"sort":     fields[i].Sort,
+			}
+			if err := tx.Model(&old).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 删除的字段会连同图纸的字段值一起删除，需刷新相册下图纸的检索索引
+	SearchServiceApp.QueueAlbums(req.AlbumID)
+	return fieldService.GetAlbumFields(request.GetAlbumFields{AlbumID: req.AlbumID})
+}
+
+// GetAlbumFields 获取相册自定义字段定义
+func (fieldService *AlbumFieldService) GetAlbumFields(req request.GetAlbumFields) ([]system.SysAlbumField, error) {
+	var fields []system.SysAlbumField
+	err := global.GVA_DB.Where("album_id = ?", req.AlbumID).Order("sort ASC, id ASC").Find(&fields).Error
+	return fields, err
+}
+
+// fieldOptions 解析枚举选项
+func fieldOptions(field system.SysAlbumField) []string {
+	var options []string
+	if field.Options != "" {
+		_ = json.Unmarshal([]byte(field.Options), &options)
+	}
+	return options
+}
+
+// coerceFieldValue 按字段类型转换取值，只做类型转换不做业务校验
+func coerceFieldValue(field system.SysAlbumField, raw interface{}) (string, *float64, error) {
+	switch field.Type {
+	case system.AlbumFieldTypeNumber, system.AlbumFieldTypeInteger:
+		var n float64
+		switch v := raw.(type) {
+		case float64:
+			n = v
+		case int:
+			n = float64(v)
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return "", nil, fmt.Errorf("字段 %s 需要数字", field.Label)
+			}
+			n = f
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return "", nil, fmt.Errorf("字段 %s 需要数字", field.Label)
+			}
+			n = f
+		default:
+			return "", nil, fmt.Errorf("字段 %s 需要数字", field.Label)
+		}
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", nil, fmt.Errorf("字段 %s 需要数字", field.Label)
+		}
+		if field.Type == system.AlbumFieldTypeInteger && n != math.Trunc(n) {
+			return "", nil, fmt.Errorf("字段 %s 需要整数", field.Label)
+		}
+		return "", &n, nil
+	case system.AlbumFieldTypeBoolean:
+		var b bool
+		switch v := raw.(type) {
+		case bool:
+			b = v
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return "", nil, fmt.Errorf("字段 %s 需要布尔值", field.Label)
+			}
+			b = parsed
+		default:
+			return "", nil, fmt.Errorf("字段 %s 需要布尔值", field.Label)
+		}
+		var n float64
+		if b {
+			n = 1
+		}
+		return "", &n, nil
+	case system.AlbumFieldTypeDate:
+		s, ok := raw.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("字段 %s 需要日期", field.Label)
+		}
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(s)); err != nil {
+			return "", nil, fmt.Errorf("字段 %s 日期格式应为 YYYY-MM-DD", field.Label)
+		}
+		return strings.TrimSpace(s), nil, nil
+	default:
+		s, ok := raw.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("字段 %s 需要文本", field.Label)
+		}
+		return strings.TrimSpace(s), nil, nil
+	}
+}
+
+// validateFieldValue 校验单个字段值并转换为存储结构
+func validateFieldValue(field system.SysAlbumField, raw interface{}) (system.SysDrawingFieldValue, error) {
+	value := system.SysDrawingFieldValue{FieldID: field.ID}
+	text, number, err := coerceFieldValue(field, raw)
+	if err != nil {
+		return value, err
+	}
+	value.TextValue, value.NumberValue = text, number
+
+	switch field.Type {
+	case system.AlbumFieldTypeNumber, system.AlbumFieldTypeInteger:
+		if field.Min != nil && *number < *field.Min {
+			return value, fmt.Errorf("字段 %s 不能小于 %v", field.Label, *field.Min)
+		}
+		if field.Max != nil && *number > *field.Max {
+			return value, fmt.Errorf("字段 %s 不能大于 %v", field.Label, *field.Max)
+		}
+	case system.AlbumFieldTypeText:
+		length := float64(utf8.RuneCountInString(text))
+		if field.Min != nil && length < *field.Min {
+			return value, fmt.Errorf("字段 %s 长度不能少于 %v", field.Label, *field.Min)
+		}
+		if length > 255 || (field.Max != nil && length > *field.Max) {
+			return value, fmt.Errorf("字段 %s 长度超出限制", field.Label)
+		}
+		if field.Pattern != "" {
+			re, err := regexp.Compile(field.Pattern)
+			if err == nil && !re.MatchString(text) {
+				return value, fmt.Errorf("字段 %s 格式不正确", field.Label)
+			}
+		}
+	case system.AlbumFieldTypeEnum:
+		for _, option := range fieldOptions(field) {
+			if option == text {
+				return value, nil
+			}
+		}
+		return value, fmt.Errorf("字段 %s 的取值 %s 不在可选项中", field.Label, text)
+	}
+	return value, nil
+}
+
+// isEmptyFieldValue 判断是否未填写
+func isEmptyFieldValue(raw interface{}) bool {
+	if raw == nil {
+		return true
+	}
+	if s, ok := raw.(string); ok {
+		return strings.TrimSpace(s) == ""
+	}
+	return false
+}
+
+// validateDrawingFields 按相册字段定义校验图纸字段值
+func validateDrawingFields(albumID uint, values map[string]interface{}) ([]system.SysDrawingFieldValue, error) {
+	var fields []system.SysAlbumField
+	if err := global.GVA_DB.Where("album_id = ?", albumID).Order("sort ASC, id ASC").Find(&fields).Error; err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = true
+	}
+	for key := range values {
+		if !byKey[key] {
+			return nil, fmt.Errorf("相册未定义字段 %s", key)
+		}
+	}
+
+	result := make([]system.SysDrawingFieldValue, 0, len(fields))
+	for _, field := range fields {
+		raw, ok := values[field.Key]
+		if !ok || isEmptyFieldValue(raw) {
+			if field.Required {
+				return nil, fmt.Errorf("字段 %s 为必填项", field.Label)
+			}
+			continue
+		}
+		value, err := validateFieldValue(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// replaceDrawingFieldValues 整体替换图纸自定义字段值
+func replaceDrawingFieldValues(tx *gorm.DB, drawingID uint, values []system.SysDrawingFieldValue) error {
+	if err := tx.Where("drawing_id = ?", drawingID).Delete(&system.SysDrawingFieldValue{}).Error; err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	for i := range values {
+		values[i].DrawingID = drawingID
+	}
+	return tx.Omit("Field").Create(&values).Error
+}
+
+// fieldValueColumn 字段值所在列
+func fieldValueColumn(field system.SysAlbumField) string {
+	switch field.Type {
+	case system.AlbumFieldTypeNumber, system.AlbumFieldTypeInteger, system.AlbumFieldTypeBoolean:
+		return "number_value"
+	}
+	return "text_value"
+}
+
+// fieldFilterArg 转换筛选值
+func fieldFilterArg(field system.SysAlbumField, raw interface{}) (interface{}, error) {
+	text, number, err := coerceFieldValue(field, raw)
+	if err != nil {
+		return nil, err
+	}
+	if number != nil {
+		return *number, nil
+	}
+	return text, nil
+}
+
+// applyDrawingFieldQuery 按自定义字段筛选与排序图纸
+func applyDrawingFieldQuery(db *gorm.DB, albumID uint, filters []request.DrawingFieldFilter, sortBy, sortOrder string) (*gorm.DB, string, error) {
+	direction := "DESC"
+	if strings.EqualFold(sortOrder, "asc") {
+		direction = "ASC"
+	}
+	order := "sys_drawings.created_at " + direction
+	if column, ok := drawingSortColumns[sortBy]; ok {
+		order = column + " " + direction
+	}
+	_, builtinSort := drawingSortColumns[sortBy]
+	if len(filters) == 0 && (sortBy == "" || builtinSort) {
+		return db, order, nil
+	}
+
+	var fields []system.SysAlbumField
+	if err := global.GVA_DB.Where("album_id = ?", albumID).Find(&fields).Error; err != nil {
+		return nil, "", err
+	}
+	byKey := make(map[string]system.SysAlbumField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	for i, filter := range filters {
+		field, ok := byKey[filter.Key]
+		if !ok {
+			return nil, "", fmt.Errorf("相册未定义字段 %s", filter.Key)
+		}
+		alias := fmt.Sprintf("fv%d", i)
+		column := alias + "." + fieldValueColumn(field)
+		db = db.Joins(fmt.Sprintf("JOIN sys_drawing_field_values %s ON %s.drawing_id = sys_drawings.id AND %s.field_id = ?", alias, alias, alias), field.ID)
+
+		op := filter.Op
+		if op == "" {
+			op = "eq"
+		}
+		switch op {
+		case "in":
+			args := make([]interface{}, 0, len(filter.Values))
+			for _, raw := range filter.Values {
+				arg, err := fieldFilterArg(field, raw)
+				if err != nil {
+					return nil, "", err
+				}
+				args = append(args, arg)
+			}
+			if len(args) == 0 {
+				return nil, "", fmt.Errorf("字段 %s 的 in 筛选需要取值列表", field.Label)
+			}
+			db = db.Where(column+" IN ?", args)
+		case "like":
+			if fieldValueColumn(field) != "text_value" {
+				return nil, "", fmt.Errorf("字段 %s 不支持模糊筛选", field.Label)
+			}
+			arg, err := fieldFilterArg(field, filter.Value)
+			if err != nil {
+				return nil, "", err
+			}
+			db = db.Where(column+" LIKE ?", "%"+arg.(string)+"%")
+		case "eq", "ne", "gt", "gte", "lt", "lte":
+			arg, err := fieldFilterArg(field, filter.Value)
+			if err != nil {
+				return nil, "", err
+			}
+			db = db.Where(column+" "+fieldFilterOperators[op]+" ?", arg)
+		default:
+			return nil, "", fmt.Errorf("不支持的筛选方式 %s", op)
+		}
+	}
+
+	if sortBy != "" && !builtinSort {
+		field, ok := byKey[sortBy]
+		if !ok {
+			return nil, "", fmt.Errorf("相册未定义字段 %s", sortBy)
+		}
+		db = db.Joins("LEFT JOIN sys_drawing_field_values fs ON fs.drawing_id = sys_drawings.id AND fs.field_id = ?", field.ID)
+		order = "fs." + fieldValueColumn(field) + " " + direction + ", sys_drawings.id " + direction
+	}
+	return db, order, nil
+}
@@ -0,0 +1,48 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+)
+
+func Test_validateFieldValue(t *testing.T) {
+	maxDifficulty := 5.0
+	difficulty, err := buildAlbumField(1, request.AlbumFieldItem{Key: "difficulty", Label: "难度", Type: system.AlbumFieldTypeInteger, Max: &maxDifficulty}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	season, err := buildAlbumField(1, request.AlbumFieldItem{Key: "season", Label: "季节", Type: system.AlbumFieldTypeEnum, Options: []string{"春", "夏", " 春 "}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		field   system.SysAlbumField
+		raw     interface{}
+		wantErr bool
+	}{
+		{name: "整数", field: difficulty, raw: float64(3)},
+		{name: "小数", field: difficulty, raw: 2.5, wantErr: true},
+		{name: "超过最大值", field: difficulty, raw: float64(6), wantErr: true},
+		{name: "枚举命中", field: season, raw: "夏"},
+		{name: "枚举未命中", field: season, raw: "冬", wantErr: true},
+		{name: "类型错误", field: season, raw: float64(1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := validateFieldValue(tt.field, tt.raw); (err != nil) != tt.wantErr {
+				t.Errorf("validateFieldValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err = buildAlbumField(1, request.AlbumFieldItem{Key: "name", Label: "名称", Type: system.AlbumFieldTypeText}, 0); err == nil {
+		t.Errorf("buildAlbumField() should reject builtin key")
+	}
+	if _, err = buildAlbumField(1, request.AlbumFieldItem{Key: "theme", Label: "主题", Type: system.AlbumFieldTypeEnum}, 0); err == nil {
+		t.Errorf("buildAlbumField() should reject enum without options")
+	}
+}
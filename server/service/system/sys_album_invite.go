@@ -0,0 +1,266 @@
+package system
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AlbumInviteService struct{}
+
+// newInviteToken 生成随机邀请令牌及其哈希
+func newInviteToken() (token string, hash string, err error) {
+	buf := make([]byte, 24)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = hex.EncodeToString(buf)
+	return token, hashInviteToken(token), nil
+}
+
+// hashInviteToken 计算邀请令牌哈希
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeInviteDomains 规范化邮箱域名限制（小写、去掉@前缀与重复项）
+func normalizeInviteDomains(domains []string) (string, error) {
+	seen := make(map[string]bool, len(domains))
+	result := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain == "" || seen[domain] {
+			continue
+		}
+		if strings.ContainsAny(domain, ", @") || !strings.Contains(domain, ".") {
+			return "", errors.New("非法的邮箱域名: " + domain)
+		}
+		seen[domain] = true
+		result = append(result, domain)
+	}
+	return strings.Join(result, ","), nil
+}
+
+// emailDomainAllowed 判断邮箱是否属于允许的域名，allowed 为空表示不限
+func emailDomainAllowed(email, allowed string) bool {
+	if allowed == "" {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	for _, d := range strings.Split(allowed, ",") {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateInvite 创建相册邀请链接（仅相册创建者或管理员）
+func (inviteService *AlbumInviteService) CreateInvite(req request.CreateAlbumInvite, userID uint, userUUID uuid.UUID) (systemRes.CreateAlbumInviteResponse, error) {
+	var res systemRes.CreateAlbumInviteResponse
+	if system.AlbumRoleRank(req.Role) == 0 {
+		return res, errors.New("不支持的成员角色")
+	}
+	if req.MaxUses < 0 {
+		return res, errors.New("最大使用次数不能为负数")
+	}
+	if err := validateGrantWindow(nil, req.ExpiresAt); err != nil {
+		return res, err
+	}
+	domains, err := normalizeInviteDomains(req.AllowedDomains)
+	if err != nil {
+		return res, err
+	}
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return res, err
+	}
+	if !ok {
+		return res, errors.New("仅相册创建者或管理员可以创建邀请链接")
+	}
+
+	token, hash, err := newInviteToken()
+	if err != nil {
+		return res, err
+	}
+	invite := system.SysAlbumInvite{
+		AlbumID:        req.AlbumID,
+		TokenHash:      hash,
+		TokenPrefix:    token[:8],
+		Role:           req.Role,
+		MaxUses:        req.MaxUses,
+		ExpiresAt:      req.ExpiresAt,
+		AllowedDomains: domains,
+		CreatorUUID:    userUUID,
+	}
+	if err = global.GVA_DB.Create(&invite).Error; err != nil {
+		return res, err
+	}
+	res.Invite, res.Token = invite, token
+	return res, nil
+}
+
+// GetInvites 获取相册的邀请链接列表（仅相册创建者或管理员）
+func (inviteService *AlbumInviteService) GetInvites(req request.GetAlbumInvites, userID uint, userUUID uuid.UUID) (list []system.SysAlbumInvite, total int64, err error) {
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return nil, 0, errors.New("仅相册创建者或管理员可以查看邀请链接")
+	}
+	db := global.GVA_DB.Model(&system.SysAlbumInvite{}).Where("album_id = ?", req.AlbumID)
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Order("id DESC").Find(&list).Error
+	return list, total, err
+}
+
+// loadManagedInvite 加载邀请并校验当前用户能否管理其所属相册
+func loadManagedInvite(inviteID uint, userID uint, userUUID uuid.UUID) (system.SysAlbumInvite, error) {
+	var invite system.SysAlbumInvite
+	if err := global.GVA_DB.First(&invite, inviteID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invite, errors.New("邀请链接不存在")
+		}
+		return invite, err
+	}
+	ok, err := canManageAlbum(invite.AlbumID, userID, userUUID)
+	if err != nil {
+		return invite, err
+	}
+	if !ok {
+		return invite, errors.New("仅相册创建者或管理员可以管理邀请链接")
+	}
+	return invite, nil
+}
+
+// RevokeInvite 撤销邀请链接，已加入的成员不受影响
+func (inviteService *AlbumInviteService) RevokeInvite(req request.RevokeAlbumInvite, userID uint, userUUID uuid.UUID) error {
+	invite, err := loadManagedInvite(req.ID, userID, userUUID)
+	if err != nil {
+		return err
+	}
+	if invite.RevokedAt != nil {
+		return errors.New("邀请链接已撤销")
+	}
+	return global.GVA_DB.Model(&invite).Updates(map[string]interface{}{
+		"revoked_at": time.Now(),
+		"revoked_by": userUUID,
+	}).Error
+}
+
+// GetRedemptions 获取邀请链接的兑换记录
+func (inviteService *AlbumInviteService) GetRedemptions(req request.GetInviteRedemptions, userID uint, userUUID uuid.UUID) (list []system.SysAlbumInviteRedemption, total int64, err error) {
+	if _, err = loadManagedInvite(req.InviteID, userID, userUUID); err != nil {
+		return nil, 0, err
+	}
+	db := global.GVA_DB.Model(&system.SysAlbumInviteRedemption{}).Where("invite_id = ?", req.InviteID)
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Preload("User").Order("id DESC").Find(&list).Error
+	return list, total, err
+}
+
+// RedeemInvite 兑换邀请链接加入相册
+func (inviteService *AlbumInviteService) RedeemInvite(req request.RedeemAlbumInvite, userUUID uuid.UUID, ip string) (systemRes.RedeemAlbumInviteResponse, error) {
+	var res systemRes.RedeemAlbumInviteResponse
+	var invite system.SysAlbumInvite
+	if err := global.GVA_DB.Where("token_hash = ?", hashInviteToken(strings.TrimSpace(req.Token))).First(&invite).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return res, errors.New("邀请链接无效")
+		}
+		return res, err
+	}
+	switch {
+	case invite.RevokedAt != nil:
+		return res, errors.New("邀请链接已撤销")
+	case invite.ExpiresAt != nil && !invite.ExpiresAt.After(time.Now()):
+		return res, errors.New("邀请链接已过期")
+	case invite.MaxUses > 0 && invite.UsedCount >= invite.MaxUses:
+		return res, errors.New("邀请链接使用次数已达上限")
+	}
+
+	var user system.SysUser
+	if err := global.GVA_DB.Where("uuid = ?", userUUID).First(&user).Error; err != nil {
+		return res, err
+	}
+	if !emailDomainAllowed(user.Email, invite.AllowedDomains) {
+		return res, errors.New("该邀请链接仅限指定邮箱域名的用户使用")
+	}
+	var album system.SysAlbum
+	if err := global.GVA_DB.Select("id", "title").First(&album, invite.AlbumID).Error; err != nil {
+		return res, errors.New("相册不存在")
+	}
+	manager, err := canManageAlbum(album.ID, user.ID, userUUID)
+	if err != nil {
+		return res, err
+	}
+	current, err := albumMemberRole(album.ID, userUUID)
+	if err != nil {
+		return res, err
+	}
+	if manager || system.AlbumRoleRank(current) >= system.AlbumRoleRank(invite.Role) {
+		return res, errors.New("已是该相册成员")
+	}
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var redeemed int64
+		if err := tx.Model(&system.SysAlbumInviteRedemption{}).Where("invite_id = ? AND user_uuid = ?", invite.ID, userUUID).Count(&redeemed).Error; err != nil {
+			return err
+		}
+		if redeemed > 0 {
+			return errors.New("已使用过该邀请链接")
+		}
+		// 以使用次数为条件递增，避免并发兑换超出上限
+		result := tx.Model(&system.SysAlbumInvite{}).
+			Where("id = ? AND revoked_at IS NULL AND (max_uses = 0 OR used_count < max_uses)", invite.ID).
+			Update("used_count", gorm.Expr("used_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("邀请链接使用次数已达上限")
+		}
+		if _, err := upsertAlbumMember(tx, album.ID, user, invite.Role, nil, nil, invite.CreatorUUID); err != nil {
+			return err
+		}
+		return tx.Create(&system.SysAlbumInviteRedemption{
+			InviteID: invite.ID,
+			AlbumID:  album.ID,
+			UserID:   user.ID,
+			UserUUID: userUUID,
+			Email:    user.Email,
+			IP:       ip,
+			Role:     invite.Role,
+		}).Error
+	})
+	if err != nil {
+		return res, err
+	}
+	res.AlbumID, res.AlbumTitle, res.Role = album.ID, album.Title, invite.Role
+	return res, nil
+}
@@ -0,0 +1,34 @@
+package system
+
+import "testing"
+
+func Test_emailDomainAllowed(t *testing.T) {
+	allowed, err := normalizeInviteDomains([]string{" @Example.com", "corp.example.org", "example.com"})
+	if err != nil {
+		t.Fatalf("normalizeInviteDomains() error = %v", err)
+	}
+	if allowed != "example.com,corp.example.org" {
+		t.Fatalf("normalizeInviteDomains() = %q", allowed)
+	}
+	tests := []struct {
+		email   string
+		allowed string
+		want    bool
+	}{
+		{email: "a@example.com", allowed: "", want: true},
+		{email: "", allowed: "", want: true},
+		{email: "a@EXAMPLE.com", allowed: allowed, want: true},
+		{email: "b@corp.example.org", allowed: allowed, want: true},
+		{email: "c@sub.example.com", allowed: allowed, want: false},
+		{email: "d@example.com.evil.io", allowed: allowed, want: false},
+		{email: "", allowed: allowed, want: false},
+	}
+	for _, tt := range tests {
+		if got := emailDomainAllowed(tt.email, tt.allowed); got != tt.want {
+			t.Errorf("emailDomainAllowed(%q, %q) = %v, want %v", tt.email, tt.allowed, got, tt.want)
+		}
+	}
+	if _, err = normalizeInviteDomains([]string{"not a domain"}); err == nil {
+		t.Error("normalizeInviteDomains() 应拒绝非法域名")
+	}
+}
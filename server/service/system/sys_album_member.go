@@ -0,0 +1,292 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// albumMemberAccessSQL 相册成员访问条件，参数依次为用户UUID、角色列表、当前时间、当前时间
+const albumMemberAccessSQL = "EXISTS (SELECT 1 FROM sys_album_members am WHERE am.album_id = sys_drawings.album_id AND am.user_uuid = ? AND am.role IN ? AND (am.starts_at IS NULL OR am.starts_at <= ?) AND (am.expires_at IS NULL OR am.expires_at > ?))"
+
+type AlbumMemberService struct{}
+
+// albumMemberRole 获取用户在相册中的有效成员角色，非成员或已过期返回空
+func albumMemberRole(albumID uint, userUUID uuid.UUID) (string, error) {
+	now := time.Now()
+	var member system.SysAlbumMember
+	err := global.GVA_DB.Select("role").
+		Where("album_id = ? AND user_uuid = ?", albumID, userUUID).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Take(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return member.Role, err
+}
+
+// albumManagerUUIDs 获取相册创建者、管理员及具有管理角色的有效成员
+func albumManagerUUIDs(albumID uint) ([]uuid.UUID, error) {
+	var album system.SysAlbum
+	if err := global.GVA_DB.Select("id", "creator_uuid").First(&album, albumID).Error; err != nil {
+		return nil, err
+	}
+	var adminUUIDs []uuid.UUID
+	err := global.GVA_DB.Model(&system.SysUser{}).
+		Joins("JOIN sys_album_admin ON sys_album_admin.user_id = sys_users.id").
+		Where("sys_album_admin.album_id = ?", albumID).
+		Pluck("sys_users.uuid", &adminUUIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var memberUUIDs []uuid.UUID
+	err = global.GVA_DB.Model(&system.SysAlbumMember{}).
+		Where("album_id = ? AND role = ?", albumID, system.AlbumRoleAdmin).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Pluck("user_uuid", &memberUUIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]uuid.UUID{album.CreatorUUID}, adminUUIDs...), memberUUIDs...), nil
+}
+
+// managedAlbumIDs 获取用户作为创建者、管理员或管理角色成员的全部相册ID
+func managedAlbumIDs(userID uint, userUUID uuid.UUID) ([]uint, error) {
+	now := time.Now()
+	var ids []uint
+	err := global.GVA_DB.Model(&system.SysAlbum{}).
+		Where("creator_uuid = ?", userUUID).
+		Or("id IN (?)", global.GVA_DB.Model(&system.SysAlbumAdmin{}).Select("album_id").Where("user_id = ?", userID)).
+		Or("id IN (?)", global.GVA_DB.Model(&system.SysAlbumMember{}).Select("album_id").
+			Where("user_uuid = ? AND role = ?", userUUID, system.AlbumRoleAdmin).
+			Where("starts_at IS NULL OR starts_at <= ?", now).
+			Where("expires_at IS NULL OR expires_at > ?", now)).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// AddMembers 批量添加相册成员，已是成员的用户更新角色与过期时间
+func (memberService *AlbumMemberService) AddMembers(req request.AddAlbumMembers, userID uint, userUUID uuid.UUID) ([]system.SysAlbumMember, error) {
+	if system.AlbumRoleRank(req.Role) == 0 {
+		return nil, errors.New("不支持的成员角色")
+	}
+	if err := validateGrantWindow(req.StartsAt, req.ExpiresAt); err != nil {
+		return nil, err
+	}
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("仅相册创建者或管理员可以管理成员")
+	}
+
+	var users []system.SysUser
+	if err = global.GVA_DB.Where("id IN ?", req.UserIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	members := make([]system.SysAlbumMember, 0, len(users))
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, user := range users {
+			member, err := upsertAlbumMember(tx, req.AlbumID, user, req.Role, req.StartsAt, req.ExpiresAt, userUUID)
+			if err != nil {
+				return err
+			}
+			members = append(members, member)
+		}
+		return nil
+	})
+	return members, err
+}
+
+// upsertAlbumMember 添加相册成员，已是成员时更新角色与授权时间并重置到期提醒
+func upsertAlbumMember(tx *gorm.DB, albumID uint, user system.SysUser, role string, startsAt, expiresAt *time.Time, grantedBy uuid.UUID) (system.SysAlbumMember, error) {
+	var member system.SysAlbumMember
+	err := tx.Where("album_id = ? AND user_uuid = ?", albumID, user.UUID).Take(&member).Error
+	switch {
+	case err == nil:
+		err = tx.Model(&member).Updates(map[string]interface{}{
+			"role":        role,
+			"starts_at":   startsAt,
+			"expires_at":  expiresAt,
+			"reminded_at": nil,
+			"granted_by":  grantedBy,
+		}).Error
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		member = system.SysAlbumMember{
+			AlbumID:   albumID,
+			UserID:    user.ID,
+			UserUUID:  user.UUID,
+			Role:      role,
+			StartsAt:  startsAt,
+			ExpiresAt: expiresAt,
+			GrantedBy: grantedBy,
+		}
+		err = tx.Create(&member).Error
+	}
+	if err != nil {
+		return member, err
+	}
+	member.Role, member.StartsAt, member.ExpiresAt, member.GrantedBy, member.User = role, startsAt, expiresAt, grantedBy, user
+	return member, nil
+}
+
+// RemoveMembers 批量移除相册成员，返回实际移除数量
+func (memberService *AlbumMemberService) RemoveMembers(req request.RemoveAlbumMembers, userID uint, userUUID uuid.UUID) (int64, error) {
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, errors.New("仅相册创建者或管理员可以管理成员")
+	}
+	result := global.GVA_DB.Where("album_id = ? AND user_id IN ?", req.AlbumID, req.UserIDs).Delete(&system.SysAlbumMember{})
+	return result.RowsAffected, result.Error
+}
+
+// GetMembers 获取相册成员列表（含已过期成员）
+func (memberService *AlbumMemberService) GetMembers(req request.GetAlbumMembers, userID uint, userUUID uuid.UUID) (list []system.SysAlbumMember, total int64, err error) {
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return nil, 0, errors.New("仅相册创建者或管理员可以查看成员")
+	}
+
+	db := global.GVA_DB.Model(&system.SysAlbumMember{}).Where("album_id = ?", req.AlbumID)
+	if req.Role != "" {
+		db = db.Where("role = ?", req.Role)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Preload("User").Order("id ASC").Find(&list).Error
+	return list, total, err
+}
+
+// ExplainDrawingAccess 列出对图纸有访问权限的用户及权限来源，仅图纸创建者或相册创建者/管理员可查看
+func (memberService *AlbumMemberService) ExplainDrawingAccess(req request.ExplainDrawingAccess, userID uint, userUUID uuid.UUID) ([]systemRes.DrawingAccessEntry, error) {
+	var drawing system.SysDrawing
+	if err := global.GVA_DB.Preload("Album").First(&drawing, req.DrawingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("图纸不存在")
+		}
+		return nil, err
+	}
+	if drawing.CreatorUUID != userUUID {
+		ok, err := canManageAlbum(drawing.AlbumID, userID, userUUID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("没有权限查看该图纸的访问来源")
+		}
+	}
+
+	// 与 accessibleDrawingsCondition 一致：成员、授权与允许下载名单仅对已发布图纸生效；查看者只能查看，角色体现在 Role 中
+	now := time.Now()
+	published := drawing.Status == system.DrawingStatusPublished
+	reasons := make(map[uuid.UUID][]systemRes.AccessReason)
+	add := func(id uuid.UUID, reason systemRes.AccessReason) {
+		if id != uuid.Nil {
+			reasons[id] = append(reasons[id], reason)
+		}
+	}
+
+	add(drawing.CreatorUUID, systemRes.AccessReason{Source: systemRes.AccessSourceDrawingCreator, Role: system.AlbumRoleEditor, Active: true})
+	add(drawing.Album.CreatorUUID, systemRes.AccessReason{Source: systemRes.AccessSourceAlbumCreator, Role: system.AlbumRoleAdmin, Active: true})
+
+	var admins []system.SysUser
+	err := global.GVA_DB.Joins("JOIN sys_album_admin ON sys_album_admin.user_id = sys_users.id").
+		Where("sys_album_admin.album_id = ?", drawing.AlbumID).Find(&admins).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, admin := range admins {
+		add(admin.UUID, systemRes.AccessReason{Source: systemRes.AccessSourceAlbumAdmin, Role: system.AlbumRoleAdmin, Active: true})
+	}
+
+	var members []system.SysAlbumMember
+	if err = global.GVA_DB.Where("album_id = ?", drawing.AlbumID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		add(member.UserUUID, systemRes.AccessReason{Source: systemRes.AccessSourceAlbumMember, Role: member.Role, StartsAt: member.StartsAt, ExpiresAt: member.ExpiresAt, Active: published && member.Active(now) && system.AlbumRoleRank(member.Role) >= system.AlbumRoleRank(system.AlbumRoleViewer)})
+	}
+
+	var grants []system.SysDrawingGrant
+	if err = global.GVA_DB.Where("drawing_id = ?", drawing.ID).Find(&grants).Error; err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		add(grant.UserUUID, systemRes.AccessReason{Source: systemRes.AccessSourceDrawingGrant, Role: system.AlbumRoleDownloader, StartsAt: grant.StartsAt, ExpiresAt: grant.ExpiresAt, Active: published && grant.Active(now)})
+	}
+
+	if drawing.AllowedMembers != "" {
+		var allowed []string
+		if err = json.Unmarshal([]byte(drawing.AllowedMembers), &allowed); err != nil {
+			return nil, errors.New("解析图纸授权成员失败")
+		}
+		for _, s := range allowed {
+			if id, err := uuid.Parse(s); err == nil {
+				add(id, systemRes.AccessReason{Source: systemRes.AccessSourceDrawingGrant, Role: system.AlbumRoleDownloader, Active: published})
+			}
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(reasons))
+	for id := range reasons {
+		ids = append(ids, id)
+	}
+	var users []system.SysUser
+	if err = global.GVA_DB.Where("uuid IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]systemRes.DrawingAccessEntry, 0, len(users))
+	for _, user := range users {
+		entries = append(entries, systemRes.DrawingAccessEntry{
+			User:          systemRes.ToUserInfo(user),
+			EffectiveRole: effectiveAccessRole(reasons[user.UUID]),
+			Reasons:       reasons[user.UUID],
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ri, rj := system.AlbumRoleRank(entries[i].EffectiveRole), system.AlbumRoleRank(entries[j].EffectiveRole)
+		if ri != rj {
+			return ri > rj
+		}
+		return entries[i].User.Username < entries[j].User.Username
+	})
+	return entries, nil
+}
+
+// effectiveAccessRole 取各有效来源中的最高角色
+func effectiveAccessRole(reasons []systemRes.AccessReason) string {
+	role := ""
+	for _, reason := range reasons {
+		if reason.Active && system.AlbumRoleRank(reason.Role) > system.AlbumRoleRank(role) {
+			role = reason.Role
+		}
+	}
+	return role
+}
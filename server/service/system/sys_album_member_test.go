@@ -0,0 +1,126 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func Test_effectiveAccessRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		reasons []systemRes.AccessReason
+		want    string
+	}{
+		{name: "无来源", want: ""},
+		{name: "单独授权", reasons: []systemRes.AccessReason{
+			{Source: systemRes.AccessSourceDrawingGrant, Role: system.AlbumRoleDownloader, Active: true},
+		}, want: system.AlbumRoleDownloader},
+		{name: "取最高角色", reasons: []systemRes.AccessReason{
+			{Source: systemRes.AccessSourceDrawingGrant, Role: system.AlbumRoleDownloader, Active: true},
+			{Source: systemRes.AccessSourceAlbumMember, Role: system.AlbumRoleEditor, Active: true},
+		}, want: system.AlbumRoleEditor},
+		{name: "忽略已过期成员", reasons: []systemRes.AccessReason{
+			{Source: systemRes.AccessSourceAlbumMember, Role: system.AlbumRoleAdmin, Active: false},
+			{Source: systemRes.AccessSourceDrawingGrant, Role: system.AlbumRoleDownloader, Active: true},
+		}, want: system.AlbumRoleDownloader},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := effectiveAccessRole(tt.reasons); got != tt.want {
+				t.Errorf("effectiveAccessRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlbumRolesAtLeast(t *testing.T) {
+	got := system.AlbumRolesAtLeast(system.AlbumRoleDownloader)
+	want := []string{system.AlbumRoleDownloader, system.AlbumRoleEditor, system.AlbumRoleAdmin}
+	if len(got) != len(want) {
+		t.Fatalf("AlbumRolesAtLeast() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("AlbumRolesAtLeast() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSysDrawingGrant_Active(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	tests := []struct {
+		name  string
+		grant system.SysDrawingGrant
+		want  bool
+	}{
+		{name: "永久授权", grant: system.SysDrawingGrant{}, want: true},
+		{name: "有效期内", grant: system.SysDrawingGrant{StartsAt: &past, ExpiresAt: &future}, want: true},
+		{name: "尚未生效", grant: system.SysDrawingGrant{StartsAt: &future}, want: false},
+		{name: "已过期", grant: system.SysDrawingGrant{ExpiresAt: &past}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.grant.Active(now); got != tt.want {
+				t.Errorf("Active() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewerMemberCanListButNotDownload(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysAlbum{}, &system.SysDrawing{}, &system.SysAlbumMember{}, &system.SysDrawingGrant{}); err != nil {
+		t.Fatal(err)
+	}
+	prev := global.GVA_DB
+	global.GVA_DB = db
+	defer func() { global.GVA_DB = prev }()
+
+	viewer, downloader := uuid.New(), uuid.New()
+	album := system.SysAlbum{Title: "相册", CreatorUUID: uuid.New()}
+	if err = db.Create(&album).Error; err != nil {
+		t.Fatal(err)
+	}
+	drawing := system.SysDrawing{AlbumID: album.ID, SerialNumber: "A-1", Name: "图纸", CreatorUUID: album.CreatorUUID, Status: system.DrawingStatusPublished}
+	if err = db.Create(&drawing).Error; err != nil {
+		t.Fatal(err)
+	}
+	members := []system.SysAlbumMember{
+		{AlbumID: album.ID, UserUUID: viewer, Role: system.AlbumRoleViewer},
+		{AlbumID: album.ID, UserUUID: downloader, Role: system.AlbumRoleDownloader},
+	}
+	if err = db.Create(&members).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var count int64
+	if err = db.Model(&system.SysDrawing{}).Scopes(accessibleDrawingsScope(viewer.String())).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("viewer lists %d drawings, want 1", count)
+	}
+	if err = db.Model(&system.SysDrawing{}).Scopes(visibleDrawingsScope(viewer)).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("viewer sees %d drawings, want 1", count)
+	}
+	if err = checkDrawingsDownloadable([]uint{drawing.ID}, viewer); err == nil {
+		t.Fatal("viewer should not be able to download")
+	}
+	if err = checkDrawingsDownloadable([]uint{drawing.ID}, downloader); err != nil {
+		t.Fatalf("downloader cannot download: %v", err)
+	}
+}
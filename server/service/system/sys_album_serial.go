@@ -0,0 +1,187 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// serialSortKeyLength 序号自然排序键长度上限，与 SysDrawing.SerialSortKey 列宽一致
+const serialSortKeyLength = 255
+
+type AlbumSerialService struct{}
+
+// serialSortKey 生成序号的自然排序键
+func serialSortKey(serial string) string {
+	return utils.NaturalSortKey(serial, serialSortKeyLength)
+}
+
+// serialNumberTaken 判断序号是否已被相册内其他图纸占用（含已删除的图纸，与唯一索引口径一致）
+func serialNumberTaken(db *gorm.DB, albumID uint, serial string, excludeID uint) (bool, error) {
+	var count int64
+	query := db.Unscoped().Model(&system.SysDrawing{}).Where("album_id = ? AND serial_number = ?", albumID, serial)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// duplicateSerialError 写入失败时若序号已被占用（并发写入触发唯一索引），返回友好提示
+func duplicateSerialError(err error, albumID uint, serial string, excludeID uint) error {
+	if err == nil {
+		return nil
+	}
+	if taken, _ := serialNumberTaken(global.GVA_DB, albumID, serial, excludeID); taken {
+		return errors.New("该序号已存在")
+	}
+	return err
+}
+
+// allocateSerialNumber 按相册编号规则分配下一个序号，需在事务中调用
+// 流水行加行锁保证并发分配不重复，已被手工占用的序号会被跳过
+func allocateSerialNumber(tx *gorm.DB, albumID uint, now time.Time) (string, error) {
+	var scheme system.SysAlbumSerialScheme
+	if err := tx.Where("album_id = ?", albumID).First(&scheme).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("相册未配置编号规则，请填写图纸序号")
+		}
+		return "", err
+	}
+	period := scheme.Period(now)
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&system.SysAlbumSerialSequence{AlbumID: albumID, Period: period}).Error
+	if err != nil {
+		return "", err
+	}
+	var sequence system.SysAlbumSerialSequence
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("album_id = ? AND period = ?", albumID, period).First(&sequence).Error
+	if err != nil {
+		return "", err
+	}
+
+	for value := sequence.Value + 1; ; value++ {
+		serial := scheme.Format(period, value)
+		taken, err := serialNumberTaken(tx, albumID, serial, 0)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			continue
+		}
+		if err = tx.Model(&sequence).Update("value", value).Error; err != nil {
+			return "", err
+		}
+		return serial, nil
+	}
+}
+
+// SaveScheme 保存相册编号规则（仅相册创建者或管理员），修改规则不影响已有序号
+func (serialService *AlbumSerialService) SaveScheme(req request.SaveAlbumSerialScheme, userID uint, userUUID uuid.UUID) (system.SysAlbumSerialScheme, error) {
+	scheme := system.SysAlbumSerialScheme{
+		AlbumID:   req.AlbumID,
+		Prefix:    strings.TrimSpace(req.Prefix),
+		DateToken: strings.ToLower(strings.TrimSpace(req.DateToken)),
+		Separator: req.Separator,
+		Padding:   req.Padding,
+	}
+	if utf8.RuneCountInString(scheme.Prefix) > 32 {
+		return scheme, errors.New("序号前缀不能超过32个字")
+	}
+	if utf8.RuneCountInString(scheme.Separator) > 8 {
+		return scheme, errors.New("分隔符不能超过8个字")
+	}
+	if !system.ValidDateToken(scheme.DateToken) {
+		return scheme, errors.New("不支持的日期格式")
+	}
+	if scheme.Padding == 0 {
+		scheme.Padding = system.DefaultSerialPadding
+	}
+	if scheme.Padding < 1 || scheme.Padding > system.MaxSerialPadding {
+		return scheme, fmt.Errorf("流水号位数需在1到%d之间", system.MaxSerialPadding)
+	}
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return scheme, err
+	}
+	if !ok {
+		return scheme, errors.New("仅相册创建者或管理员可以设置编号规则")
+	}
+
+	var existing system.SysAlbumSerialScheme
+	err = global.GVA_DB.Where("album_id = ?", req.AlbumID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = global.GVA_DB.Create(&scheme).Error
+		return scheme, err
+	}
+	if err != nil {
+		return scheme, err
+	}
+	err = global.GVA_DB.Model(&existing).Updates(map[string]interface{}{
+		"prefix":     scheme.Prefix,
+		"date_token": scheme.DateToken,
+		"separator":  scheme.Separator,
+		"padding":    scheme.Padding,
+	}).Error
+	if err != nil {
+		return scheme, err
+	}
+	existing.Prefix, existing.DateToken, existing.Separator, existing.Padding = scheme.Prefix, scheme.DateToken, scheme.Separator, scheme.Padding
+	return existing, nil
+}
+
+// GetScheme 获取相册编号规则，并预览下一个将分配的序号
+func (serialService *AlbumSerialService) GetScheme(req request.GetAlbumSerialScheme) (systemRes.AlbumSerialSchemeResponse, error) {
+	var res systemRes.AlbumSerialSchemeResponse
+	var scheme system.SysAlbumSerialScheme
+	err := global.GVA_DB.Where("album_id = ?", req.AlbumID).First(&scheme).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	res.Scheme = &scheme
+
+	period := scheme.Period(time.Now())
+	var sequence system.SysAlbumSerialSequence
+	err = global.GVA_DB.Where("album_id = ? AND period = ?", req.AlbumID, period).Limit(1).Find(&sequence).Error
+	if err != nil {
+		return res, err
+	}
+	for value := sequence.Value + 1; ; value++ {
+		serial := scheme.Format(period, value)
+		taken, err := serialNumberTaken(global.GVA_DB, req.AlbumID, serial, 0)
+		if err != nil {
+			return res, err
+		}
+		if !taken {
+			res.Next = serial
+			return res, nil
+		}
+	}
+}
+
+// DeleteScheme 删除相册编号规则，之后创建图纸需手工填写序号
+func (serialService *AlbumSerialService) DeleteScheme(req request.GetAlbumSerialScheme, userID uint, userUUID uuid.UUID) error {
+	ok, err := canManageAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("仅相册创建者或管理员可以删除编号规则")
+	}
+	return global.GVA_DB.Unscoped().Where("album_id = ?", req.AlbumID).Delete(&system.SysAlbumSerialScheme{}).Error
+}
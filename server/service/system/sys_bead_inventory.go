@@ -0,0 +1,265 @@
+package system
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BeadInventoryService struct{}
+
+// CreateInventory 新增库存，色号已存在时累加数量
+func (inventoryService *BeadInventoryService) CreateInventory(req request.CreateBeadInventory, userUUID uuid.UUID) (system.SysBeadInventory, error) {
+	code := normalizeColorCode(req.ColorCode)
+	if code == "" {
+		return system.SysBeadInventory{}, errors.New("色号不能为空")
+	}
+	if req.Quantity < 0 {
+		return system.SysBeadInventory{}, errors.New("数量不能为负数")
+	}
+
+	var inventory system.SysBeadInventory
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		return upsertInventory(tx, userUUID, code, req.Quantity, true, &inventory)
+	})
+	return inventory, err
+}
+
+// UpdateInventory 修改库存数量
+func (inventoryService *BeadInventoryService) UpdateInventory(req request.UpdateBeadInventory, userUUID uuid.UUID) error {
+	if req.Quantity < 0 {
+		return errors.New("数量不能为负数")
+	}
+	result := global.GVA_DB.Model(&system.SysBeadInventory{}).
+		Where("id = ? AND user_uuid = ?", req.ID, userUUID).
+		Update("quantity", req.Quantity)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("库存记录不存在")
+	}
+	return nil
+}
+
+// DeleteInventory 删除库存（物理删除，避免色号唯一索引冲突）
+func (inventoryService *BeadInventoryService) DeleteInventory(req request.DeleteBeadInventory, userUUID uuid.UUID) error {
+	return global.GVA_DB.Unscoped().
+		Where("id = ? AND user_uuid = ?", req.ID, userUUID).
+		Delete(&system.SysBeadInventory{}).Error
+}
+
+// GetInventoryList 获取当前用户库存列表
+func (inventoryService *BeadInventoryService) GetInventoryList(req request.GetBeadInventoryList, userUUID uuid.UUID) (list []system.SysBeadInventory, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysBeadInventory{}).Where("user_uuid = ?", userUUID)
+	if req.Keyword != "" {
+		db = db.Where("color_code LIKE ?", "%"+normalizeColorCode(req.Keyword)+"%")
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Order("color_code ASC").Find(&list).Error
+	return list, total, err
+}
+
+// ImportInventoryCSV 从CSV导入库存
+// 每行格式为 色号,数量，首行可为表头；replace 为 true 时先清空原有库存
+func (inventoryService *BeadInventoryService) ImportInventoryCSV(header *multipart.FileHeader, replace bool, userUUID uuid.UUID) (systemRes.ImportInventoryResult, error) {
+	var result systemRes.ImportInventoryResult
+
+	file, err := header.Open()
+	if err != nil {
+		return result, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	quantities := make(map[string]int)
+	var order []string
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("解析CSV失败: %w", err)
+		}
+		line++
+		if len(record) < 2 {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("第%d行: 列数不足", line))
+			continue
+		}
+		code := normalizeColorCode(strings.TrimPrefix(record[0], "\ufeff"))
+		quantity, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			// 首行无法解析数量时视为表头
+			if line == 1 {
+				continue
+			}
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("第%d行: 数量格式错误", line))
+			continue
+		}
+		if code == "" || quantity < 0 {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("第%d行: 色号为空或数量为负数", line))
+			continue
+		}
+		if _, ok := quantities[code]; !ok {
+			order = append(order, code)
+		}
+		quantities[code] += quantity
+	}
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if replace {
+			if err := tx.Unscoped().Where("user_uuid = ?", userUUID).Delete(&system.SysBeadInventory{}).Error; err != nil {
+				return err
+			}
+		}
+		for _, code := range order {
+			var inventory system.SysBeadInventory
+			if err := upsertInventory(tx, userUUID, code, quantities[code], false, &inventory); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Imported = len(order)
+	return result, nil
+}
+
+// MatchDrawings 对比库存与当前用户可访问图纸的色号用量
+func (inventoryService *BeadInventoryService) MatchDrawings(req request.MatchDrawings, userUUID uuid.UUID) (systemRes.DrawingMatchListResponse, error) {
+	var res systemRes.DrawingMatchListResponse
+
+	db := global.GVA_DB.Model(&system.SysDrawing{}).Scopes(accessibleDrawingsScope(userUUID.String()))
+	if req.Keyword != "" {
+		db = db.Where("sys_drawings.serial_number LIKE ? OR sys_drawings.name LIKE ?",
+			"%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+
+	var drawings []system.SysDrawing
+	err := db.Preload("Album").Preload("Colors").Order("sys_drawings.created_at DESC").Find(&drawings).Error
+	if err != nil {
+		return res, err
+	}
+
+	var inventories []system.SysBeadInventory
+	err = global.GVA_DB.Where("user_uuid = ?", userUUID).Find(&inventories).Error
+	if err != nil {
+		return res, err
+	}
+	owned := make(map[string]int, len(inventories))
+	for _, inventory := range inventories {
+		owned[inventory.ColorCode] = inventory.Quantity
+	}
+
+	results := make([]systemRes.DrawingMatchResult, 0, len(drawings))
+	for _, drawing := range drawings {
+		result := matchDrawing(drawing, owned)
+		if req.OnlyMake && !result.CanMake {
+			continue
+		}
+		results = append(results, result)
+	}
+
+	if req.Sort == "missing" {
+		// 未录入色号用量的图纸无法判断，排在最后
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].HasBOM != results[j].HasBOM {
+				return results[i].HasBOM
+			}
+			return results[i].MissingTotal < results[j].MissingTotal
+		})
+	}
+
+	res.Total = int64(len(results))
+	if req.Page > 0 && req.PageSize > 0 {
+		start := (req.Page - 1) * req.PageSize
+		if start > len(results) {
+			start = len(results)
+		}
+		end := start + req.PageSize
+		if end > len(results) {
+			end = len(results)
+		}
+		results = results[start:end]
+	}
+	res.List = results
+	return res, nil
+}
+
+// matchDrawing 计算单张图纸的缺口
+func matchDrawing(drawing system.SysDrawing, owned map[string]int) systemRes.DrawingMatchResult {
+	result := systemRes.DrawingMatchResult{
+		DrawingID:      drawing.ID,
+		AlbumID:        drawing.AlbumID,
+		AlbumTitle:     drawing.Album.Title,
+		SerialNumber:   drawing.SerialNumber,
+		Name:           drawing.Name,
+		PosterImageURL: drawing.PosterImageURL,
+		HasBOM:         len(drawing.Colors) > 0,
+		Shortfalls:     []systemRes.ColorShortfall{},
+	}
+	for _, color := range drawing.Colors {
+		have := owned[color.ColorCode]
+		if have >= color.Quantity {
+			continue
+		}
+		missing := color.Quantity - have
+		result.MissingTotal += missing
+		result.Shortfalls = append(result.Shortfalls, systemRes.ColorShortfall{
+			ColorCode: color.ColorCode,
+			Required:  color.Quantity,
+			Owned:     have,
+			Missing:   missing,
+		})
+	}
+	result.CanMake = result.HasBOM && result.MissingTotal == 0
+	return result
+}
+
+// upsertInventory 写入单个色号库存，accumulate 为 true 时累加数量，否则覆盖
+func upsertInventory(tx *gorm.DB, userUUID uuid.UUID, code string, quantity int, accumulate bool, inventory *system.SysBeadInventory) error {
+	err := tx.Where("user_uuid = ? AND color_code = ?", userUUID, code).First(inventory).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		*inventory = system.SysBeadInventory{UserUUID: userUUID, ColorCode: code, Quantity: quantity}
+		return tx.Create(inventory).Error
+	}
+	if err != nil {
+		return err
+	}
+	if accumulate {
+		inventory.Quantity += quantity
+	} else {
+		inventory.Quantity = quantity
+	}
+	return tx.Model(inventory).Update("quantity", inventory.Quantity).Error
+}
@@ -0,0 +1,61 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+)
+
+func Test_buildDrawingColors(t *testing.T) {
+	colors, err := buildDrawingColors([]request.DrawingColor{
+		{ColorCode: " a1 ", Quantity: 10},
+		{ColorCode: "B2", Quantity: 5},
+		{ColorCode: "A1", Quantity: 3},
+	})
+	if err != nil {
+		t.Fatalf("buildDrawingColors() error = %v", err)
+	}
+	if len(colors) != 2 || colors[0].ColorCode != "A1" || colors[0].Quantity != 13 {
+		t.Errorf("buildDrawingColors() = %+v, want merged A1=13", colors)
+	}
+
+	if _, err = buildDrawingColors([]request.DrawingColor{{ColorCode: "A1", Quantity: -1}}); err == nil {
+		t.Errorf("buildDrawingColors() negative quantity should fail")
+	}
+}
+
+func Test_matchDrawing(t *testing.T) {
+	tests := []struct {
+		name        string
+		colors      []system.SysDrawingColor
+		owned       map[string]int
+		wantMake    bool
+		wantMissing int
+	}{
+		{
+			name:     "库存充足",
+			colors:   []system.SysDrawingColor{{ColorCode: "A1", Quantity: 10}},
+			owned:    map[string]int{"A1": 20},
+			wantMake: true,
+		},
+		{
+			name:        "部分缺少",
+			colors:      []system.SysDrawingColor{{ColorCode: "A1", Quantity: 10}, {ColorCode: "B2", Quantity: 5}},
+			owned:       map[string]int{"A1": 4},
+			wantMissing: 11,
+		},
+		{
+			name:  "未录入色号",
+			owned: map[string]int{"A1": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchDrawing(system.SysDrawing{Colors: tt.colors}, tt.owned)
+			if got.CanMake != tt.wantMake || got.MissingTotal != tt.wantMissing {
+				t.Errorf("matchDrawing() = canMake %v missing %d, want %v %d", got.CanMake, got.MissingTotal, tt.wantMake, tt.wantMissing)
+			}
+		})
+	}
+}
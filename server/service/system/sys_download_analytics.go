@@ -0,0 +1,338 @@
+package system
+
+import (
+	"errors"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 下载统计只读取每日汇总表；汇总表由定时任务按下载历史ID游标增量维护，
+// 下载历史被清理后汇总数据仍然保留
+type DownloadAnalyticsService struct{}
+
+const (
+	downloadRollupBatch      = 5000             // 每批汇总的下载历史条数
+	downloadRollupMaxBatches = 20               // 每次任务最多处理的批数
+	downloadRollupSettle     = 10 * time.Second // 仅汇总写入超过该时长的记录，避免并发写入的记录被游标跳过
+	analyticsMaxDays         = 1096             // 单次统计最长天数
+	analyticsDateLayout      = "2006-01-02"
+)
+
+// dailyDownloads 某一天的下载汇总
+type dailyDownloads struct {
+	Day         string
+	Downloads   int64
+	Watermarked int64
+}
+
+// downloadDay 下载时间戳对应的本地日期
+func downloadDay(ts int64) string {
+	return time.Unix(ts, 0).In(time.Local).Format(analyticsDateLayout)
+}
+
+// aggregateDownloads 将下载历史按天汇总为图纸与用户两个维度，前端上报的下载点击不计入统计
+func aggregateDownloads(histories []system.SysDownloadHistory) ([]system.SysDownloadDailyDrawing, []system.SysDownloadDailyUser) {
+	type drawingKey struct {
+		day       string
+		drawingID uint
+	}
+	type userKey struct {
+		day      string
+		userUUID uuid.UUID
+		albumID  uint
+	}
+	drawingIndex := make(map[drawingKey]int)
+	userIndex := make(map[userKey]int)
+	var drawings []system.SysDownloadDailyDrawing
+	var users []system.SysDownloadDailyUser
+	for _, h := range histories {
+		if h.Channel == system.DownloadChannelClient {
+			continue
+		}
+		day := downloadDay(h.DownloadAt)
+		var watermarked int64
+		if h.Watermarked {
+			watermarked = 1
+		}
+
+		dk := drawingKey{day, h.DrawingID}
+		i, ok := drawingIndex[dk]
+		if !ok {
+			i = len(drawings)
+			drawingIndex[dk] = i
+			drawings = append(drawings, system.SysDownloadDailyDrawing{Day: day, DrawingID: h.DrawingID, AlbumID: h.AlbumID})
+		}
+		drawings[i].Downloads++
+		drawings[i].Watermarked += watermarked
+
+		uk := userKey{day, h.UserUUID, h.AlbumID}
+		j, ok := userIndex[uk]
+		if !ok {
+			j = len(users)
+			userIndex[uk] = j
+			users = append(users, system.SysDownloadDailyUser{Day: day, UserUUID: h.UserUUID, AlbumID: h.AlbumID})
+		}
+		users[j].Downloads++
+		users[j].Watermarked += watermarked
+	}
+	return drawings, users
+}
+
+// incrementAssignments 汇总行冲突时累加计数
+func incrementAssignments(downloads, watermarked int64) clause.Set {
+	return clause.Assignments(map[string]interface{}{
+		"downloads":   gorm.Expr("downloads + ?", downloads),
+		"watermarked": gorm.Expr("watermarked + ?", watermarked),
+	})
+}
+
+// rollupDownloadBatch 汇总一批新增下载历史，游标行加锁保证多实例下不重复汇总
+func rollupDownloadBatch() (int, error) {
+	processed := 0
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&system.SysDownloadRollupCursor{ID: 1}).Error
+		if err != nil {
+			return err
+		}
+		var cursor system.SysDownloadRollupCursor
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&cursor, 1).Error; err != nil {
+			return err
+		}
+		var histories []system.SysDownloadHistory
+		err = tx.Unscoped().Select("id", "user_uuid", "drawing_id", "album_id", "download_at", "watermarked", "channel").
+			Where("id > ? AND created_at < ?", cursor.LastHistoryID, time.Now().Add(-downloadRollupSettle)).
+			Order("id ASC").Limit(downloadRollupBatch).Find(&histories).Error
+		if err != nil || len(histories) == 0 {
+			return err
+		}
+
+		drawings, users := aggregateDownloads(histories)
+		for i := range drawings {
+			err = tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "day"}, {Name: "drawing_id"}},
+				DoUpdates: incrementAssignments(drawings[i].Downloads, drawings[i].Watermarked),
+			}).Create(&drawings[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		for i := range users {
+			err = tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "day"}, {Name: "user_uuid"}, {Name: "album_id"}},
+				DoUpdates: incrementAssignments(users[i].Downloads, users[i].Watermarked),
+			}).Create(&users[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		processed = len(histories)
+		return tx.Model(&cursor).Update("last_history_id", histories[len(histories)-1].ID).Error
+	})
+	return processed, err
+}
+
+// RollupDownloads 将新增下载历史增量汇总到每日汇总表，返回本次处理的记录数
+func (analyticsService *DownloadAnalyticsService) RollupDownloads() (int, error) {
+	total := 0
+	for i := 0; i < downloadRollupMaxBatches; i++ {
+		n, err := rollupDownloadBatch()
+		total += n
+		if err != nil || n < downloadRollupBatch {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// parseAnalyticsRange 解析统计日期范围（含首尾两天）
+func parseAnalyticsRange(r request.DownloadAnalyticsRange) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(analyticsDateLayout, r.StartDate, time.Local)
+	if err != nil {
+		return start, start, errors.New("开始日期格式应为 YYYY-MM-DD")
+	}
+	end, err := time.ParseInLocation(analyticsDateLayout, r.EndDate, time.Local)
+	if err != nil {
+		return start, end, errors.New("结束日期格式应为 YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return start, end, errors.New("结束日期不能早于开始日期")
+	}
+	if end.Sub(start) > analyticsMaxDays*24*time.Hour {
+		return start, end, errors.New("统计范围不能超过3年")
+	}
+	return start, end, nil
+}
+
+// checkAnalyticsScope 校验统计范围：配置的全站统计角色可查看全站，其他用户只能查看自己管理的相册
+func checkAnalyticsScope(albumID uint, userID uint, userUUID uuid.UUID, authorityID uint) error {
+	for _, id := range global.GVA_CONFIG.DownloadAnalytics.GlobalAuthorities {
+		if id == authorityID {
+			return nil
+		}
+	}
+	if albumID == 0 {
+		return errors.New("请指定要统计的相册")
+	}
+	ok, err := canManageAlbum(albumID, userID, userUUID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("仅相册创建者或管理员可以查看该相册的下载统计")
+	}
+	return nil
+}
+
+// analyticsRangeQuery 按日期与相册范围筛选汇总表
+func analyticsRangeQuery(model interface{}, r request.DownloadAnalyticsRange) *gorm.DB {
+	db := global.GVA_DB.Model(model).Where("day BETWEEN ? AND ?", r.StartDate, r.EndDate)
+	if r.AlbumID != 0 {
+		db = db.Where("album_id = ?", r.AlbumID)
+	}
+	return db
+}
+
+// bucketLabel 日期所属分桶的标识
+func bucketLabel(day time.Time, bucket string) string {
+	switch bucket {
+	case system.DownloadBucketWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)).Format(analyticsDateLayout)
+	case system.DownloadBucketMonth:
+		return day.Format("2006-01")
+	default:
+		return day.Format(analyticsDateLayout)
+	}
+}
+
+// buildDownloadBuckets 将每日汇总合并为连续的分桶序列，无下载的分桶补零
+func buildDownloadBuckets(start, end time.Time, bucket string, days []dailyDownloads) []systemRes.DownloadBucket {
+	byDay := make(map[string]dailyDownloads, len(days))
+	for _, d := range days {
+		byDay[d.Day] = d
+	}
+	var buckets []systemRes.DownloadBucket
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		label := bucketLabel(day, bucket)
+		if len(buckets) == 0 || buckets[len(buckets)-1].Bucket != label {
+			buckets = append(buckets, systemRes.DownloadBucket{Bucket: label})
+		}
+		d := byDay[day.Format(analyticsDateLayout)]
+		buckets[len(buckets)-1].Downloads += d.Downloads
+		buckets[len(buckets)-1].Watermarked += d.Watermarked
+	}
+	return buckets
+}
+
+// GetSeries 按天、周或月统计下载量，可按图纸、用户或相册筛选
+func (analyticsService *DownloadAnalyticsService) GetSeries(req request.GetDownloadSeries, userID uint, userUUID uuid.UUID, authorityID uint) ([]systemRes.DownloadBucket, error) {
+	start, end, err := parseAnalyticsRange(req.DownloadAnalyticsRange)
+	if err != nil {
+		return nil, err
+	}
+	bucket := req.Bucket
+	if bucket == "" {
+		bucket = system.DownloadBucketDay
+	}
+	switch bucket {
+	case system.DownloadBucketDay, system.DownloadBucketWeek, system.DownloadBucketMonth:
+	default:
+		return nil, errors.New("不支持的分桶粒度")
+	}
+	if err = checkAnalyticsScope(req.AlbumID, userID, userUUID, authorityID); err != nil {
+		return nil, err
+	}
+
+	var db *gorm.DB
+	if req.UserUUID != uuid.Nil {
+		db = analyticsRangeQuery(&system.SysDownloadDailyUser{}, req.DownloadAnalyticsRange).Where("user_uuid = ?", req.UserUUID)
+	} else {
+		db = analyticsRangeQuery(&system.SysDownloadDailyDrawing{}, req.DownloadAnalyticsRange)
+		if req.DrawingID != 0 {
+			db = db.Where("drawing_id = ?", req.DrawingID)
+		}
+	}
+	var days []dailyDownloads
+	err = db.Select("day, SUM(downloads) AS downloads, SUM(watermarked) AS watermarked").Group("day").Scan(&days).Error
+	if err != nil {
+		return nil, err
+	}
+	return buildDownloadBuckets(start, end, bucket, days), nil
+}
+
+// GetTopDrawings 统计范围内下载量最高的图纸
+func (analyticsService *DownloadAnalyticsService) GetTopDrawings(req request.GetTopDrawings, userID uint, userUUID uuid.UUID, authorityID uint) ([]systemRes.TopDrawing, error) {
+	if _, _, err := parseAnalyticsRange(req.DownloadAnalyticsRange); err != nil {
+		return nil, err
+	}
+	if err := checkAnalyticsScope(req.AlbumID, userID, userUUID, authorityID); err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	var top []systemRes.TopDrawing
+	err := analyticsRangeQuery(&system.SysDownloadDailyDrawing{}, req.DownloadAnalyticsRange).
+		Select("drawing_id, SUM(downloads) AS downloads, SUM(watermarked) AS watermarked").
+		Group("drawing_id").Order("downloads DESC, drawing_id ASC").Limit(limit).Scan(&top).Error
+	if err != nil || len(top) == 0 {
+		return top, err
+	}
+
+	ids := make([]uint, 0, len(top))
+	for _, t := range top {
+		ids = append(ids, t.DrawingID)
+	}
+	var drawings []system.SysDrawing
+	err = global.GVA_DB.Unscoped().Select("id", "album_id", "serial_number", "name").Where("id IN ?", ids).Find(&drawings).Error
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]system.SysDrawing, len(drawings))
+	for _, d := range drawings {
+		byID[d.ID] = d
+	}
+	for i := range top {
+		d := byID[top[i].DrawingID]
+		top[i].AlbumID, top[i].SerialNumber, top[i].Name = d.AlbumID, d.SerialNumber, d.Name
+	}
+	return top, nil
+}
+
+// GetSummary 统计范围内的下载次数、去重下载人数与带水印下载占比
+func (analyticsService *DownloadAnalyticsService) GetSummary(req request.GetDownloadSummary, userID uint, userUUID uuid.UUID, authorityID uint) (systemRes.DownloadSummary, error) {
+	var summary systemRes.DownloadSummary
+	if _, _, err := parseAnalyticsRange(req.DownloadAnalyticsRange); err != nil {
+		return summary, err
+	}
+	if err := checkAnalyticsScope(req.AlbumID, userID, userUUID, authorityID); err != nil {
+		return summary, err
+	}
+	err := analyticsRangeQuery(&system.SysDownloadDailyDrawing{}, req.DownloadAnalyticsRange).
+		Select("COALESCE(SUM(downloads), 0) AS downloads, COALESCE(SUM(watermarked), 0) AS watermarked").
+		Scan(&summary).Error
+	if err != nil {
+		return summary, err
+	}
+	err = analyticsRangeQuery(&system.SysDownloadDailyUser{}, req.DownloadAnalyticsRange).
+		Distinct("user_uuid").Count(&summary.UniqueDownloaders).Error
+	if err != nil {
+		return summary, err
+	}
+	summary.Raw = summary.Downloads - summary.Watermarked
+	if summary.Downloads > 0 {
+		summary.WatermarkRatio = float64(summary.Watermarked) / float64(summary.Downloads)
+	}
+	return summary, nil
+}
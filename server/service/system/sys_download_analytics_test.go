@@ -0,0 +1,48 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/google/uuid"
+)
+
+func Test_aggregateDownloads(t *testing.T) {
+	day := time.Date(2024, 5, 6, 10, 0, 0, 0, time.Local).Unix()
+	next := time.Date(2024, 5, 7, 10, 0, 0, 0, time.Local).Unix()
+	u1, u2 := uuid.New(), uuid.New()
+	drawings, users := aggregateDownloads([]system.SysDownloadHistory{
+		{UserUUID: u1, DrawingID: 1, AlbumID: 9, DownloadAt: day, Watermarked: true},
+		{UserUUID: u2, DrawingID: 1, AlbumID: 9, DownloadAt: day},
+		{UserUUID: u1, DrawingID: 1, AlbumID: 9, DownloadAt: next},
+		{UserUUID: u1, DrawingID: 2, AlbumID: 9, DownloadAt: day},
+		{UserUUID: u2, DrawingID: 2, AlbumID: 9, DownloadAt: day, Channel: system.DownloadChannelClient},
+	})
+	if len(drawings) != 3 || drawings[0].Day != "2024-05-06" || drawings[0].Downloads != 2 || drawings[0].Watermarked != 1 {
+		t.Fatalf("aggregateDownloads() drawings = %+v", drawings)
+	}
+	if len(users) != 3 || users[0].UserUUID != u1 || users[0].Downloads != 2 || users[0].Watermarked != 1 {
+		t.Fatalf("aggregateDownloads() users = %+v", users)
+	}
+}
+
+func Test_buildDownloadBuckets(t *testing.T) {
+	start := time.Date(2024, 4, 29, 0, 0, 0, 0, time.Local) // 周一
+	end := time.Date(2024, 5, 8, 0, 0, 0, 0, time.Local)
+	days := []dailyDownloads{
+		{Day: "2024-04-30", Downloads: 2, Watermarked: 1},
+		{Day: "2024-05-06", Downloads: 3},
+	}
+	if got := buildDownloadBuckets(start, end, system.DownloadBucketDay, days); len(got) != 10 || got[1].Downloads != 2 || got[0].Downloads != 0 {
+		t.Fatalf("day buckets = %+v", got)
+	}
+	weeks := buildDownloadBuckets(start, end, system.DownloadBucketWeek, days)
+	if len(weeks) != 2 || weeks[0].Bucket != "2024-04-29" || weeks[1].Bucket != "2024-05-06" || weeks[1].Downloads != 3 {
+		t.Fatalf("week buckets = %+v", weeks)
+	}
+	months := buildDownloadBuckets(start, end, system.DownloadBucketMonth, days)
+	if len(months) != 2 || months[0].Bucket != "2024-04" || months[0].Watermarked != 1 || months[1].Downloads != 3 {
+		t.Fatalf("month buckets = %+v", months)
+	}
+}
@@ -0,0 +1,120 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DownloadAuditService struct{}
+
+// downloadAuditScope 按检索条件过滤下载历史
+func downloadAuditScope(req request.SearchDownloadAudits) (func(*gorm.DB) *gorm.DB, error) {
+	switch req.Channel {
+	case "", system.DownloadChannelSingle, system.DownloadChannelBatch, system.DownloadChannelClient:
+	default:
+		return nil, fmt.Errorf("不支持的下载渠道 %s", req.Channel)
+	}
+	if req.StartTime > 0 && req.EndTime > 0 && req.StartTime > req.EndTime {
+		return nil, errors.New("开始时间不能晚于结束时间")
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		if req.UserUUID != uuid.Nil {
+			db = db.Where("user_uuid = ?", req.UserUUID)
+		}
+		if req.DrawingID != 0 {
+			db = db.Where("drawing_id = ?", req.DrawingID)
+		}
+		if req.AlbumID != 0 {
+			db = db.Where("album_id = ?", req.AlbumID)
+		}
+		if req.Channel != "" {
+			db = db.Where("channel = ?", req.Channel)
+		}
+		if req.ClientIP != "" {
+			db = db.Where("client_ip = ?", req.ClientIP)
+		}
+		if req.RequestID != "" {
+			db = db.Where("request_id = ?", req.RequestID)
+		}
+		if req.Watermarked != nil {
+			db = db.Where("watermarked = ?", *req.Watermarked)
+		}
+		if req.WatermarkHash != "" {
+			db = db.Where("watermark_hash = ?", req.WatermarkHash)
+		}
+		if req.WatermarkText != "" {
+			db = db.Where("watermark_text LIKE ?", "%"+req.WatermarkText+"%")
+		}
+		if req.File != "" {
+			db = db.Where("files LIKE ?", "%"+req.File+"%")
+		}
+		if req.UserAgent != "" {
+			db = db.Where("user_agent LIKE ?", "%"+req.UserAgent+"%")
+		}
+		if req.StartTime > 0 {
+			db = db.Where("download_at >= ?", req.StartTime)
+		}
+		if req.EndTime > 0 {
+			db = db.Where("download_at <= ?", req.EndTime)
+		}
+		return db
+	}, nil
+}
+
+// SearchDownloadAudits 按用户、图纸、渠道、客户端与水印信息检索下载记录，按下载时间倒序
+func (auditService *DownloadAuditService) SearchDownloadAudits(req request.SearchDownloadAudits) (list []systemRes.DownloadAudit, total int64, err error) {
+	scope, err := downloadAuditScope(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	db := global.GVA_DB.Model(&system.SysDownloadHistory{}).Scopes(scope)
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	var histories []system.SysDownloadHistory
+	err = db.Preload("User").
+		Preload("Drawing", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("download_at DESC, id DESC").Find(&histories).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list = make([]systemRes.DownloadAudit, 0, len(histories))
+	for _, h := range histories {
+		files := []string{}
+		if h.Files != "" {
+			_ = json.Unmarshal([]byte(h.Files), &files)
+		}
+		list = append(list, systemRes.DownloadAudit{
+			ID:            h.ID,
+			DownloadAt:    h.DownloadAt,
+			UserUUID:      h.UserUUID,
+			Username:      h.User.Username,
+			NickName:      h.User.NickName,
+			DrawingID:     h.DrawingID,
+			DrawingName:   h.Drawing.Name,
+			SerialNumber:  h.Drawing.SerialNumber,
+			AlbumID:       h.AlbumID,
+			Channel:       h.Channel,
+			Files:         files,
+			Watermarked:   h.Watermarked,
+			WatermarkText: h.WatermarkText,
+			WatermarkHash: h.WatermarkHash,
+			ClientIP:      h.ClientIP,
+			UserAgent:     h.UserAgent,
+			RequestID:     h.RequestID,
+		})
+	}
+	return list, total, nil
+}
@@ -1,25 +1,67 @@
 package system
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type DownloadHistoryService struct{}
 
-// RecordDownload 记录下载历史
-func (s *DownloadHistoryService) RecordDownload(userUUID uuid.UUID, drawingID, albumID uint) error {
+// watermarkPolicy 当前水印策略标识（文字旋转平铺），水印算法调整时需同步修改，便于追溯泄露文件的水印版本
+const watermarkPolicy = "tiled-rotated-text/v1"
+
+// watermarkPolicyHash 水印文字与策略的哈希，用于从泄露文件反查下载记录
+func watermarkPolicyHash(text string) string {
+	sum := sha256.Sum256([]byte(watermarkPolicy + "\n" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// newDownloadHistory 构建下载历史记录，watermarkText 为空表示提供的是原图
+func newDownloadHistory(userUUID uuid.UUID, drawingID, albumID uint, channel string, client request.DownloadClient, files []string, watermarkText string) *system.SysDownloadHistory {
+	if files == nil {
+		files = []string{}
+	}
+	filesJSON, _ := json.Marshal(files)
 	history := &system.SysDownloadHistory{
-		UserUUID:   userUUID,
-		DrawingID:  drawingID,
-		AlbumID:    albumID,
-		DownloadAt: time.Now().Unix(),
+		UserUUID:  userUUID,
+		DrawingID: drawingID,
+		AlbumID:   albumID,
+		Channel:   channel,
+		Files:     string(filesJSON),
+		ClientIP:  client.ClientIP,
+		UserAgent: truncateRunes(client.UserAgent, 512),
+		RequestID: client.RequestID,
+	}
+	if watermarkText != "" {
+		history.Watermarked = true
+		history.WatermarkText = truncateRunes(watermarkText, 255)
+		history.WatermarkHash = watermarkPolicyHash(watermarkText)
 	}
+	return history
+}
 
+// truncateRunes 按字符数截断字符串
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
+
+// RecordDownload 记录下载历史
+func (s *DownloadHistoryService) RecordDownload(history *system.SysDownloadHistory) error {
+	history.DownloadAt = time.Now().Unix()
 	err := global.GVA_DB.Create(history).Error
 	if err != nil {
 		global.GVA_LOG.Error("记录下载历史失败", zap.Error(err))
@@ -41,124 +83,148 @@ func (s *DownloadHistoryService) GetUserDrawingDownloadHistory(userUUID uuid.UUI
 	return &history, nil
 }
 
-// GetUserDrawingsWithDownloadInfo 获取用户图纸列表（包含下载信息）
-func (s *DownloadHistoryService) GetUserDrawingsWithDownloadInfo(userUUID uuid.UUID) ([]map[string]interface{}, error) {
-	results := make([]map[string]interface{}, 0)
+// userDrawingDownloadsSQL 用户每张图纸的首次与最后下载时间，合并原始下载历史与已压缩的汇总
+const userDrawingDownloadsSQL = "LEFT JOIN (SELECT t.drawing_id, MIN(t.first_at) first_download_at, MAX(t.last_at) last_download_at FROM (" +
+	"SELECT drawing_id, download_at first_at, download_at last_at FROM sys_download_histories WHERE user_uuid = ? AND deleted_at IS NULL" +
+	" UNION ALL SELECT drawing_id, first_download_at, last_download_at FROM sys_download_summaries WHERE user_uuid = ?" +
+	") t GROUP BY t.drawing_id) dl ON dl.drawing_id = sys_drawings.id"
 
-	// 先获取用户ID
-	var user system.SysUser
-	err := global.GVA_DB.Where("uuid = ?", userUUID).First(&user).Error
-	if err != nil {
-		global.GVA_LOG.Error("获取用户信息失败", zap.Error(err))
-		return results, err
-	}
-
-	global.GVA_LOG.Info("查询用户图纸列表",
-		zap.String("userUUID", userUUID.String()),
-		zap.Uint("userID", user.ID))
-
-	// 简化查询：先获取所有图纸，然后过滤权限
-	query := `
-		SELECT
-			d.id,
-			d.serial_number,
-			d.name,
-			d.album_id,
-			a.title AS album_title,
-			ldt.last_download_time,
-			fdt.first_download_time,
-			d.created_at AS created_at
-		FROM sys_drawings d
-		LEFT JOIN sys_albums a ON d.album_id = a.id
-		LEFT JOIN (
-			SELECT drawing_id, MAX(download_at) AS last_download_time
-			FROM sys_download_histories
-			WHERE user_uuid = ?
-			GROUP BY drawing_id
-		) ldt ON ldt.drawing_id = d.id
-		LEFT JOIN (
-			SELECT drawing_id, MIN(download_at) AS first_download_time
-			FROM sys_download_histories
-			WHERE user_uuid = ?
-			GROUP BY drawing_id
-		) fdt ON fdt.drawing_id = d.id
-		WHERE d.creator_uuid = ?
-		   OR JSON_CONTAINS(d.allowed_members, ?)
-		   OR EXISTS (
-			   SELECT 1 FROM sys_album_admin aa
-			   WHERE aa.album_id = d.album_id
-			   AND aa.user_id = ?
-		   )
-		ORDER BY d.created_at DESC
-	`
-
-	rows, err := global.GVA_DB.Raw(
-		query,
-		userUUID.String(),           // ldt.user_uuid = ?
-		userUUID.String(),           // fdt.user_uuid = ?
-		userUUID.String(),           // d.creator_uuid = ?
-		"\""+userUUID.String()+"\"", // JSON_CONTAINS(..., ?)
-		user.ID,                     // aa.user_id = ?
-	).Rows()
+// userDrawingLedgerOrder 用户图纸台账排序子句
+func userDrawingLedgerOrder(sort, sortOrder string) (string, error) {
+	desc := sortOrder == "desc"
+	switch sortOrder {
+	case "", "asc", "desc":
+	default:
+		return "", fmt.Errorf("不支持的排序方向 %s", sortOrder)
+	}
+	if sortOrder == "" {
+		desc = sort == "" || sort == system.LedgerSortAcquired || sort == system.LedgerSortLastDownload
+	}
+	dir := " ASC"
+	if desc {
+		dir = " DESC"
+	}
+	switch sort {
+	case "", system.LedgerSortAcquired:
+		return "CASE WHEN dl.first_download_at IS NULL THEN 1 ELSE 0 END ASC, dl.first_download_at" + dir +
+			", sys_drawings.created_at" + dir + ", sys_drawings.id" + dir, nil
+	case system.LedgerSortLastDownload:
+		return "CASE WHEN dl.last_download_at IS NULL THEN 1 ELSE 0 END ASC, dl.last_download_at" + dir +
+			", sys_drawings.id" + dir, nil
+	case system.LedgerSortName:
+		return "sys_drawings.name" + dir + ", sys_drawings.id" + dir, nil
+	case system.LedgerSortAlbum:
+		return "a.title" + dir + ", sys_drawings.album_id" + dir + ", sys_drawings.serial_sort_key ASC, sys_drawings.id ASC", nil
+	default:
+		return "", fmt.Errorf("不支持的排序方式 %s", sort)
+	}
+}
+
+// GetUserDrawingLedger 获取用户可下载的图纸台账（含首次、最后下载时间与完成情况），支持分页、排序与筛选
+func (s *DownloadHistoryService) GetUserDrawingLedger(userUUID uuid.UUID, req request.GetUserDrawingLedger) (list []systemRes.UserDrawingLedgerItem, total int64, err error) {
+	order, err := userDrawingLedgerOrder(req.Sort, req.SortOrder)
 	if err != nil {
-		global.GVA_LOG.Error("执行查询失败", zap.Error(err))
-		return nil, err
+		return nil, 0, err
 	}
-	defer rows.Close()
-
-	count := 0
-	for rows.Next() {
-		var result map[string]interface{}
-		var id uint
-		var serialNumber, name string
-		var albumID uint
-		var albumTitle string
-		var lastDownloadTime, firstDownloadTime *int64
-		var createdAt time.Time
-
-		err := rows.Scan(&id, &serialNumber, &name, &albumID, &albumTitle,
-			&lastDownloadTime, &firstDownloadTime, &createdAt)
-		if err != nil {
-			global.GVA_LOG.Error("扫描行数据失败", zap.Error(err))
-			continue
-		}
 
-		// 获取时间信息
-		var acquisitionTime, lastDownloadTimeStr string
+	db := global.GVA_DB.Model(&system.SysDrawing{}).
+		Scopes(downloadableDrawingsScope(userUUID)).
+		Joins(userDrawingDownloadsSQL, userUUID, userUUID).
+		Joins("LEFT JOIN sys_albums a ON a.id = sys_drawings.album_id")
+	if req.AlbumID != 0 {
+		db = db.Where("sys_drawings.album_id = ?", req.AlbumID)
+	}
+	if req.Keyword != "" {
+		db = db.Where("sys_drawings.serial_number LIKE ? OR sys_drawings.name LIKE ?", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+	if req.NeverDownloaded {
+		db = db.Where("dl.drawing_id IS NULL")
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
 
-		// 获取时间（第一次下载时间或图纸创建时间）
-		if firstDownloadTime != nil {
-			acquisitionTime = time.Unix(*firstDownloadTime, 0).Format("2006-01-02T15:04:05Z")
-		} else {
-			// 如果没有下载记录，使用图纸创建时间（直接使用已查询的 createdAt）
-			acquisitionTime = createdAt.Format("2006-01-02T15:04:05Z")
-		}
+	var rows []struct {
+		ID              uint
+		SerialNumber    string
+		Name            string
+		AlbumID         uint
+		AlbumTitle      *string
+		CreatedAt       time.Time
+		FirstDownloadAt *int64
+		LastDownloadAt  *int64
+	}
+	err = db.Select("sys_drawings.id, sys_drawings.serial_number, sys_drawings.name, sys_drawings.album_id, " +
+		"a.title AS album_title, sys_drawings.created_at, dl.first_download_at, dl.last_download_at").
+		Order(order).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
 
-		// 最后一次下载时间
-		if lastDownloadTime != nil {
-			lastDownloadTimeStr = time.Unix(*lastDownloadTime, 0).Format("2006-01-02T15:04:05Z")
+	list = make([]systemRes.UserDrawingLedgerItem, 0, len(rows))
+	for _, row := range rows {
+		item := systemRes.UserDrawingLedgerItem{
+			ID:              row.ID,
+			SerialNumber:    row.SerialNumber,
+			Name:            row.Name,
+			AlbumID:         row.AlbumID,
+			AcquisitionTime: row.CreatedAt,
 		}
-
-		result = map[string]interface{}{
-			"id":               id,
-			"serialNumber":     serialNumber,
-			"name":             name,
-			"albumId":          albumID,
-			"albumTitle":       albumTitle,
-			"acquisitionTime":  acquisitionTime,
-			"lastDownloadTime": lastDownloadTimeStr,
+		if row.AlbumTitle != nil {
+			item.AlbumTitle = *row.AlbumTitle
 		}
-
-		results = append(results, result)
-		count++
+		if row.FirstDownloadAt != nil {
+			item.AcquisitionTime = time.Unix(*row.FirstDownloadAt, 0)
+		}
+		if row.LastDownloadAt != nil {
+			last := time.Unix(*row.LastDownloadAt, 0)
+			item.LastDownloadTime = &last
+		}
+		list = append(list, item)
 	}
 
-	global.GVA_LOG.Info("查询结果",
-		zap.String("userUUID", userUUID.String()),
-		zap.Int("resultCount", count),
-		zap.Int("totalResults", len(results)))
+	if err = attachCompletionInfo(userUUID, list); err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
 
-	return results, nil
+// attachCompletionInfo 为图纸台账补充用户的完成情况（最近完成日期与完成次数）
+func attachCompletionInfo(userUUID uuid.UUID, list []systemRes.UserDrawingLedgerItem) error {
+	if len(list) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.ID)
+	}
+	var completions []system.SysDrawingCompletion
+	err := global.GVA_DB.Select("drawing_id", "completed_at").
+		Where("user_uuid = ? AND drawing_id IN ?", userUUID, ids).
+		Find(&completions).Error
+	if err != nil {
+		return err
+	}
+	latest := make(map[uint]time.Time, len(completions))
+	counts := make(map[uint]int, len(completions))
+	for _, completion := range completions {
+		counts[completion.DrawingID]++
+		if completion.CompletedAt.After(latest[completion.DrawingID]) {
+			latest[completion.DrawingID] = completion.CompletedAt
+		}
+	}
+	for i := range list {
+		id := list[i].ID
+		list[i].Completed = counts[id] > 0
+		list[i].CompletionCount = counts[id]
+		if counts[id] > 0 {
+			list[i].LastCompletedAt = latest[id].Format("2006-01-02")
+		}
+	}
+	return nil
 }
 
 // GetDownloadStatus 获取指定图纸ID列表的最新下载时间（按用户）
@@ -179,11 +245,24 @@ func (s *DownloadHistoryService) GetDownloadStatus(userUUID uuid.UUID, drawingID
 	if err != nil {
 		return nil, err
 	}
+	// 已压缩的历史记录只保留在汇总表中
+	var summaries []system.SysDownloadSummary
+	err = global.GVA_DB.Select("drawing_id", "last_download_at").
+		Where("user_uuid = ? AND drawing_id IN ?", userUUID, drawingIDs).
+		Find(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
 	result := make(map[uint]int64, len(rows))
 	for _, r := range rows {
 		if r.LastTime != nil {
 			result[r.DrawingID] = *r.LastTime
 		}
 	}
+	for _, summary := range summaries {
+		if summary.LastDownloadAt > result[summary.DrawingID] {
+			result[summary.DrawingID] = summary.LastDownloadAt
+		}
+	}
 	return result, nil
 }
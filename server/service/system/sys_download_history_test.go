@@ -0,0 +1,47 @@
+package system
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/google/uuid"
+)
+
+func Test_newDownloadHistory(t *testing.T) {
+	client := request.DownloadClient{ClientIP: "10.0.0.1", UserAgent: strings.Repeat("界", 600), RequestID: "req-1"}
+
+	raw := newDownloadHistory(uuid.New(), 1, 2, system.DownloadChannelSingle, client, nil, "")
+	if raw.Watermarked || raw.WatermarkHash != "" || raw.Files != "[]" {
+		t.Fatalf("raw download = %+v", raw)
+	}
+	if n := len([]rune(raw.UserAgent)); n != 512 {
+		t.Fatalf("user agent length = %d, want 512", n)
+	}
+
+	marked := newDownloadHistory(uuid.New(), 1, 2, system.DownloadChannelBatch, client, []string{"a.png"}, "author: x")
+	if !marked.Watermarked || marked.Files != `["a.png"]` || marked.WatermarkHash != watermarkPolicyHash("author: x") {
+		t.Fatalf("watermarked download = %+v", marked)
+	}
+	if watermarkPolicyHash("author: x") == watermarkPolicyHash("author: y") || len(marked.WatermarkHash) != 64 {
+		t.Fatalf("unexpected watermark hash %s", marked.WatermarkHash)
+	}
+}
+
+func Test_userDrawingLedgerOrder(t *testing.T) {
+	order, err := userDrawingLedgerOrder("", "")
+	if err != nil || !strings.HasPrefix(order, "CASE WHEN dl.first_download_at IS NULL") || !strings.Contains(order, "dl.first_download_at DESC") {
+		t.Fatalf("default order = %q, %v", order, err)
+	}
+	order, err = userDrawingLedgerOrder(system.LedgerSortName, "")
+	if err != nil || order != "sys_drawings.name ASC, sys_drawings.id ASC" {
+		t.Fatalf("name order = %q, %v", order, err)
+	}
+	if _, err = userDrawingLedgerOrder(system.LedgerSortAlbum, "sideways"); err == nil {
+		t.Fatal("expected error for unsupported sort order")
+	}
+	if _, err = userDrawingLedgerOrder("price", ""); err == nil {
+		t.Fatal("expected error for unsupported sort")
+	}
+}
@@ -0,0 +1,380 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+)
+
+// 下载额度按规则、用户、相册与周期计数：开启 redis 时计数保存在 redis（多实例共享），
+// 否则保存在本机内存缓存中（重启后清零）
+type DownloadQuotaService struct{}
+
+const (
+	downloadQuotaKeyPrefix  = "GVA_DownloadQuota:"
+	downloadQuotaWindowHour = "hour"
+	downloadQuotaWindowDay  = "day"
+)
+
+// downloadQuotaUsage 额度用量
+type downloadQuotaUsage struct {
+	Downloads int64
+	Bytes     int64
+}
+
+// QuotaExceededError 下载额度已用完
+type QuotaExceededError struct {
+	Rule    string    // 触发限制的规则名称
+	AlbumID uint      // 按相册计数时的相册ID
+	ResetAt time.Time // 额度重置时间
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("下载额度已用完（%s），请 %d 秒后重试", e.Rule, e.RetryAfter())
+}
+
+// RetryAfter 距离额度重置的秒数
+func (e *QuotaExceededError) RetryAfter() int64 {
+	seconds := int64(time.Until(e.ResetAt).Seconds()) + 1
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// downloadQuotaWindow 计算规则当前计数周期的起止时间（本地时区的自然小时或自然日）
+func downloadQuotaWindow(window string, now time.Time) (time.Time, time.Time, error) {
+	now = now.In(time.Local)
+	switch window {
+	case downloadQuotaWindowHour:
+		start := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.Local)
+		return start, start.Add(time.Hour), nil
+	case downloadQuotaWindowDay:
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		return start, start.AddDate(0, 0, 1), nil
+	default:
+		return now, now, fmt.Errorf("不支持的额度周期 %s", window)
+	}
+}
+
+// downloadQuotaKey 额度计数键：前缀 + 用户 + 规则 + 相册 + 周期起点
+func downloadQuotaKey(userUUID uuid.UUID, rule string, albumID uint, windowStart time.Time) string {
+	return fmt.Sprintf("%s%s:%s:%d:%d", downloadQuotaKeyPrefix, userUUID, rule, albumID, windowStart.Unix())
+}
+
+// parseDownloadQuotaKey 解析计数键中的规则、相册与周期起点
+func parseDownloadQuotaKey(key string) (rule string, albumID uint, windowStart int64, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(key, downloadQuotaKeyPrefix), ":")
+	if len(parts) != 4 {
+		return "", 0, 0, false
+	}
+	album, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, 0, false
+	}
+	start, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return "", 0, 0, false
+	}
+	return parts[1], uint(album), start, true
+}
+
+// downloadQuotaStore 额度计数存储
+type downloadQuotaStore interface {
+	add(key string, usage downloadQuotaUsage, expireAt time.Time) (downloadQuotaUsage, error)
+	get(key string) (downloadQuotaUsage, error)
+	keys(prefix string) ([]string, error)
+	del(keys ...string) error
+}
+
+func currentDownloadQuotaStore() downloadQuotaStore {
+	if global.GVA_REDIS != nil {
+		return redisQuotaStore{}
+	}
+	return memoryQuotaStore{}
+}
+
+// redisQuotaStore 以 hash 保存下载数(d)与字节数(b)
+type redisQuotaStore struct{}
+
+func (redisQuotaStore) add(key string, usage downloadQuotaUsage, expireAt time.Time) (downloadQuotaUsage, error) {
+	ctx := context.Background()
+	pipe := global.GVA_REDIS.TxPipeline()
+	downloads := pipe.HIncrBy(ctx, key, "d", usage.Downloads)
+	bytes := pipe.HIncrBy(ctx, key, "b", usage.Bytes)
+	pipe.ExpireAt(ctx, key, expireAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return downloadQuotaUsage{}, err
+	}
+	return downloadQuotaUsage{Downloads: downloads.Val(), Bytes: bytes.Val()}, nil
+}
+
+func (redisQuotaStore) get(key string) (downloadQuotaUsage, error) {
+	values, err := global.GVA_REDIS.HGetAll(context.Background(), key).Result()
+	if err != nil {
+		return downloadQuotaUsage{}, err
+	}
+	downloads, _ := strconv.ParseInt(values["d"], 10, 64)
+	bytes, _ := strconv.ParseInt(values["b"], 10, 64)
+	return downloadQuotaUsage{Downloads: downloads, Bytes: bytes}, nil
+}
+
+func (redisQuotaStore) keys(prefix string) ([]string, error) {
+	var keys []string
+	iter := global.GVA_REDIS.Scan(context.Background(), 0, prefix+"*", 100).Iterator()
+	for iter.Next(context.Background()) {
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
+}
+
+func (redisQuotaStore) del(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return global.GVA_REDIS.Del(context.Background(), keys...).Err()
+}
+
+// memoryQuotaStore 使用额度专用的本地缓存计数（不与 JWT 黑名单共用），读改写与遍历均由互斥锁保护
+type memoryQuotaStore struct{}
+
+var (
+	memoryQuotaMu sync.Mutex
+	// memoryQuotaCache 额度计数缓存，定期清理已过期周期的计数
+	memoryQuotaCache = local_cache.NewCache(
+		local_cache.SetInternal(10*time.Minute),
+		local_cache.SetCapture(func(string, interface{}) {}),
+	)
+)
+
+func (memoryQuotaStore) add(key string, usage downloadQuotaUsage, expireAt time.Time) (downloadQuotaUsage, error) {
+	memoryQuotaMu.Lock()
+	defer memoryQuotaMu.Unlock()
+	if v, ok := memoryQuotaCache.Get(key); ok {
+		current, _ := v.(downloadQuotaUsage)
+		usage.Downloads += current.Downloads
+		usage.Bytes += current.Bytes
+	}
+	memoryQuotaCache.Set(key, usage, time.Until(expireAt))
+	return usage, nil
+}
+
+func (memoryQuotaStore) get(key string) (downloadQuotaUsage, error) {
+	memoryQuotaMu.Lock()
+	defer memoryQuotaMu.Unlock()
+	v, _ := memoryQuotaCache.Get(key)
+	usage, _ := v.(downloadQuotaUsage)
+	return usage, nil
+}
+
+func (memoryQuotaStore) keys(prefix string) ([]string, error) {
+	memoryQuotaMu.Lock()
+	defer memoryQuotaMu.Unlock()
+	var keys []string
+	for key := range memoryQuotaCache.Iterator() {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (memoryQuotaStore) del(keys ...string) error {
+	memoryQuotaMu.Lock()
+	defer memoryQuotaMu.Unlock()
+	for _, key := range keys {
+		memoryQuotaCache.Delete(key)
+	}
+	return nil
+}
+
+// quotaRuleScopes 规则对本次下载生效的计数范围（相册ID -> 用量），不按相册计数时合并为相册 0
+func quotaRuleScopes(rule config.DownloadQuotaRule, cost map[uint]downloadQuotaUsage) map[uint]downloadQuotaUsage {
+	scopes := make(map[uint]downloadQuotaUsage)
+	for albumID, usage := range cost {
+		if rule.AlbumID != 0 && rule.AlbumID != albumID {
+			continue
+		}
+		scope := uint(0)
+		if rule.PerAlbum || rule.AlbumID != 0 {
+			scope = albumID
+		}
+		total := scopes[scope]
+		total.Downloads += usage.Downloads
+		total.Bytes += usage.Bytes
+		scopes[scope] = total
+	}
+	return scopes
+}
+
+// quotaExceeded 用量是否超过规则上限
+func quotaExceeded(rule config.DownloadQuotaRule, usage downloadQuotaUsage) bool {
+	return (rule.MaxDownloads > 0 && usage.Downloads > rule.MaxDownloads) ||
+		(rule.MaxBytes > 0 && usage.Bytes > rule.MaxBytes)
+}
+
+// downloadQuotaExempt 用户角色是否不受额度限制
+func downloadQuotaExempt(userUUID uuid.UUID) (bool, error) {
+	exempt := global.GVA_CONFIG.DownloadQuota.ExemptAuthorities
+	if len(exempt) == 0 {
+		return false, nil
+	}
+	var user system.SysUser
+	if err := global.GVA_DB.Select("authority_id").Where("uuid = ?", userUUID).First(&user).Error; err != nil {
+		return false, err
+	}
+	for _, id := range exempt {
+		if id == user.AuthorityId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// consumeDownloadQuota 按相册计入本次下载的图纸数与字节数，任一规则超限时撤销本次计数并返回 QuotaExceededError
+func consumeDownloadQuota(userUUID uuid.UUID, cost map[uint]downloadQuotaUsage) error {
+	rules := global.GVA_CONFIG.DownloadQuota.Rules
+	if len(rules) == 0 || len(cost) == 0 {
+		return nil
+	}
+	exempt, err := downloadQuotaExempt(userUUID)
+	if err != nil || exempt {
+		return err
+	}
+
+	store := currentDownloadQuotaStore()
+	now := time.Now()
+	type applied struct {
+		key      string
+		usage    downloadQuotaUsage
+		expireAt time.Time
+	}
+	var done []applied
+	rollback := func() {
+		for _, a := range done {
+			if _, err := store.add(a.key, downloadQuotaUsage{Downloads: -a.usage.Downloads, Bytes: -a.usage.Bytes}, a.expireAt); err != nil {
+				global.GVA_LOG.Warn("撤销下载额度计数失败", zap.String("key", a.key), zap.Error(err))
+			}
+		}
+	}
+	for _, rule := range rules {
+		if rule.MaxDownloads <= 0 && rule.MaxBytes <= 0 {
+			continue
+		}
+		start, end, err := downloadQuotaWindow(rule.Window, now)
+		if err != nil {
+			rollback()
+			return err
+		}
+		for albumID, usage := range quotaRuleScopes(rule, cost) {
+			key := downloadQuotaKey(userUUID, rule.Name, albumID, start)
+			total, err := store.add(key, usage, end)
+			if err != nil {
+				rollback()
+				return err
+			}
+			done = append(done, applied{key: key, usage: usage, expireAt: end})
+			if quotaExceeded(rule, total) {
+				rollback()
+				return &QuotaExceededError{Rule: rule.Name, AlbumID: albumID, ResetAt: end}
+			}
+		}
+	}
+	return nil
+}
+
+// downloadFilesSize 图纸源文件总字节数，不存在的文件忽略
+func downloadFilesSize(drawingURLs []string) int64 {
+	var size int64
+	for _, drawingURL := range drawingURLs {
+		fullPath := drawingURL
+		if !strings.HasPrefix(drawingURL, "uploads/") {
+			fullPath = filepath.Join("uploads", drawingURL)
+		}
+		if info, err := os.Stat(fullPath); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// GetUsage 查看用户在各规则当前周期的用量，albumID 不为 0 时只看该相册的按相册计数
+func (quotaService *DownloadQuotaService) GetUsage(userUUID uuid.UUID, albumID uint) ([]systemRes.DownloadQuotaUsage, error) {
+	store := currentDownloadQuotaStore()
+	keys, err := store.keys(fmt.Sprintf("%s%s:", downloadQuotaKeyPrefix, userUUID))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	list := make([]systemRes.DownloadQuotaUsage, 0)
+	for _, rule := range global.GVA_CONFIG.DownloadQuota.Rules {
+		start, end, err := downloadQuotaWindow(rule.Window, now)
+		if err != nil {
+			return nil, err
+		}
+		albums := make(map[uint]bool)
+		if !rule.PerAlbum && rule.AlbumID == 0 {
+			albums[0] = true
+		} else if rule.AlbumID != 0 {
+			albums[rule.AlbumID] = true
+		}
+		for _, key := range keys {
+			name, album, windowStart, ok := parseDownloadQuotaKey(key)
+			if ok && name == rule.Name && windowStart == start.Unix() {
+				albums[album] = true
+			}
+		}
+		for album := range albums {
+			if albumID != 0 && album != 0 && album != albumID {
+				continue
+			}
+			usage, err := store.get(downloadQuotaKey(userUUID, rule.Name, album, start))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, systemRes.DownloadQuotaUsage{
+				Rule:         rule.Name,
+				Window:       rule.Window,
+				AlbumID:      album,
+				Downloads:    usage.Downloads,
+				Bytes:        usage.Bytes,
+				MaxDownloads: rule.MaxDownloads,
+				MaxBytes:     rule.MaxBytes,
+				ResetAt:      end,
+			})
+		}
+	}
+	return list, nil
+}
+
+// ResetUsage 清空用户的额度计数，albumID 不为 0 时只清空该相册的按相册计数
+func (quotaService *DownloadQuotaService) ResetUsage(userUUID uuid.UUID, albumID uint) error {
+	store := currentDownloadQuotaStore()
+	keys, err := store.keys(fmt.Sprintf("%s%s:", downloadQuotaKeyPrefix, userUUID))
+	if err != nil {
+		return err
+	}
+	if albumID == 0 {
+		return store.del(keys...)
+	}
+	matched := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, album, _, ok := parseDownloadQuotaKey(key); ok && album == albumID {
+			matched = append(matched, key)
+		}
+	}
+	return store.del(matched...)
+}
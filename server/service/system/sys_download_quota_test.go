@@ -0,0 +1,63 @@
+package system
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+)
+
+func Test_downloadQuotaWindow(t *testing.T) {
+	now := time.Date(2024, 5, 6, 10, 42, 7, 0, time.Local)
+	start, end, err := downloadQuotaWindow(downloadQuotaWindowHour, now)
+	if err != nil || !start.Equal(time.Date(2024, 5, 6, 10, 0, 0, 0, time.Local)) || end.Sub(start) != time.Hour {
+		t.Fatalf("hour window = %v - %v, %v", start, end, err)
+	}
+	start, end, err = downloadQuotaWindow(downloadQuotaWindowDay, now)
+	if err != nil || !start.Equal(time.Date(2024, 5, 6, 0, 0, 0, 0, time.Local)) || !end.Equal(time.Date(2024, 5, 7, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("day window = %v - %v, %v", start, end, err)
+	}
+	if _, _, err = downloadQuotaWindow("week", now); err == nil {
+		t.Fatal("expected error for unsupported window")
+	}
+}
+
+func Test_consumeDownloadQuota(t *testing.T) {
+	oldCache, oldQuota := memoryQuotaCache, global.GVA_CONFIG.DownloadQuota
+	defer func() { memoryQuotaCache, global.GVA_CONFIG.DownloadQuota = oldCache, oldQuota }()
+	memoryQuotaCache = local_cache.NewCache()
+	global.GVA_CONFIG.DownloadQuota = config.DownloadQuota{Rules: []config.DownloadQuotaRule{
+		{Name: "hourly", Window: downloadQuotaWindowHour, MaxDownloads: 3},
+		{Name: "album", Window: downloadQuotaWindowDay, MaxBytes: 100, PerAlbum: true},
+	}}
+
+	user := uuid.New()
+	if err := consumeDownloadQuota(user, map[uint]downloadQuotaUsage{1: {Downloads: 2, Bytes: 60}}); err != nil {
+		t.Fatal(err)
+	}
+	// 相册1字节超限，整次下载撤销，小时计数不应增加
+	err := consumeDownloadQuota(user, map[uint]downloadQuotaUsage{1: {Downloads: 1, Bytes: 50}})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Rule != "album" || quotaErr.AlbumID != 1 || quotaErr.RetryAfter() < 1 {
+		t.Fatalf("expected album quota error, got %v", err)
+	}
+	if err = consumeDownloadQuota(user, map[uint]downloadQuotaUsage{2: {Downloads: 1, Bytes: 50}}); err != nil {
+		t.Fatalf("other album should still be allowed: %v", err)
+	}
+	err = consumeDownloadQuota(user, map[uint]downloadQuotaUsage{2: {Downloads: 1}})
+	if !errors.As(err, &quotaErr) || quotaErr.Rule != "hourly" {
+		t.Fatalf("expected hourly quota error, got %v", err)
+	}
+
+	svc := &DownloadQuotaService{}
+	if err = svc.ResetUsage(user, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = consumeDownloadQuota(user, map[uint]downloadQuotaUsage{1: {Downloads: 3, Bytes: 100}}); err != nil {
+		t.Fatalf("quota should be available after reset: %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package system
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	downloadCompactBatch       = 2000 // 每批压缩的下载历史条数
+	defaultDownloadArchivePath = "archive/download_histories"
+)
+
+// downloadArchive 下载历史归档文件（gzip 压缩的 JSON Lines，每行一条原始记录）
+type downloadArchive struct {
+	path string
+	file *os.File
+	gz   *gzip.Writer
+	enc  *json.Encoder
+}
+
+// privateArchiveDir 校验归档目录不在对外提供访问的上传目录下
+// 归档包含所有用户的 UUID、IP、UA、文件列表与水印文本，只能保存在不对外提供访问的目录
+func privateArchiveDir(dir string) (string, error) {
+	if dir == "" {
+		dir = defaultDownloadArchivePath
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	local := global.GVA_CONFIG.Local
+	for _, public := range []string{local.StorePath, local.Path, "uploads"} {
+		if public == "" {
+			continue
+		}
+		publicAbs, err := filepath.Abs(public)
+		if err != nil {
+			return "", err
+		}
+		if abs == publicAbs || strings.HasPrefix(abs, publicAbs+string(filepath.Separator)) {
+			return "", fmt.Errorf("下载历史归档目录 %s 位于公开的上传目录下，请配置为不对外提供访问的目录", dir)
+		}
+	}
+	return dir, nil
+}
+
+// openDownloadArchive 在私有归档目录下创建本次任务的归档文件，目录与文件仅允许服务进程读写
+func openDownloadArchive(dir string, now time.Time) (*downloadArchive, error) {
+	dir, err := privateArchiveDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("download_histories_%s.jsonl.gz", now.Format("20060102150405")))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(file)
+	return &downloadArchive{path: path, file: file, gz: gz, enc: json.NewEncoder(gz)}, nil
+}
+
+// write 追加一批记录并落盘，确保删除数据库记录前归档已写入
+func (a *downloadArchive) write(histories []system.SysDownloadHistory) error {
+	for i := range histories {
+		if err := a.enc.Encode(&histories[i]); err != nil {
+			return err
+		}
+	}
+	if err := a.gz.Flush(); err != nil {
+		return err
+	}
+	return a.file.Sync()
+}
+
+func (a *downloadArchive) close() error {
+	if err := a.gz.Close(); err != nil {
+		a.file.Close()
+		return err
+	}
+	return a.file.Close()
+}
+
+// recordDownloadArchive 记录归档文件位置，便于按需取回
+func recordDownloadArchive(path string, records int, cutoff int64) error {
+	return global.GVA_DB.Create(&system.SysDownloadArchive{Path: path, Records: records, Cutoff: cutoff}).Error
+}
+
+// aggregateDownloadSummaries 将一批下载历史按用户与图纸汇总，已软删除的记录只归档不计入汇总
+func aggregateDownloadSummaries(histories []system.SysDownloadHistory) []system.SysDownloadSummary {
+	type key struct {
+		user    string
+		drawing uint
+	}
+	index := make(map[key]int)
+	summaries := make([]system.SysDownloadSummary, 0)
+	for _, h := range histories {
+		if h.DeletedAt.Valid {
+			continue
+		}
+		k := key{h.UserUUID.String(), h.DrawingID}
+		i, ok := index[k]
+		if !ok {
+			i = len(summaries)
+			index[k] = i
+			summaries = append(summaries, system.SysDownloadSummary{
+				UserUUID:        h.UserUUID,
+				DrawingID:       h.DrawingID,
+				FirstDownloadAt: h.DownloadAt,
+				LastDownloadAt:  h.DownloadAt,
+			})
+		}
+		s := &summaries[i]
+		s.Downloads++
+		if h.Watermarked {
+			s.Watermarked++
+		}
+		if h.DownloadAt < s.FirstDownloadAt {
+			s.FirstDownloadAt = h.DownloadAt
+		}
+		if h.DownloadAt > s.LastDownloadAt {
+			s.LastDownloadAt = h.DownloadAt
+		}
+	}
+	return summaries
+}
+
+// compactDownloadBatch 压缩一批超期下载历史：归档、合并到汇总表后删除原始记录
+func compactDownloadBatch(cutoff int64, maxID uint, archive *downloadArchive) (int, error) {
+	var histories []system.SysDownloadHistory
+	err := global.GVA_DB.Unscoped().
+		Where("download_at < ? AND id <= ?", cutoff, maxID).
+		Order("id ASC").Limit(downloadCompactBatch).Find(&histories).Error
+	if err != nil || len(histories) == 0 {
+		return 0, err
+	}
+	if archive != nil {
+		if err = archive.write(histories); err != nil {
+			return 0, err
+		}
+	}
+
+	summaries := aggregateDownloadSummaries(histories)
+	ids := make([]uint, 0, len(histories))
+	for _, h := range histories {
+		ids = append(ids, h.ID)
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for i := range summaries {
+			s := summaries[i]
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "user_uuid"}, {Name: "drawing_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"first_download_at": gorm.Expr("CASE WHEN first_download_at < ? THEN first_download_at ELSE ? END", s.FirstDownloadAt, s.FirstDownloadAt),
+					"last_download_at":  gorm.Expr("CASE WHEN last_download_at > ? THEN last_download_at ELSE ? END", s.LastDownloadAt, s.LastDownloadAt),
+					"downloads":         gorm.Expr("downloads + ?", s.Downloads),
+					"watermarked":       gorm.Expr("watermarked + ?", s.Watermarked),
+					"updated_at":        time.Now(),
+				}),
+			}).Create(&s).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&system.SysDownloadHistory{}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(histories), nil
+}
+
+// CompactExpired 将超过保留天数的原始下载历史压缩为用户-图纸汇总并删除，返回压缩条数与归档文件路径。
+// 只处理已汇总到每日统计表的记录，保证下载统计不丢失
+func (s *DownloadHistoryService) CompactExpired(days int, archive bool, archivePath string) (int, string, error) {
+	if days <= 0 {
+		return 0, "", nil
+	}
+	var cursor system.SysDownloadRollupCursor
+	err := global.GVA_DB.Limit(1).Find(&cursor, 1).Error
+	if err != nil || cursor.LastHistoryID == 0 {
+		return 0, "", err
+	}
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -days).Unix()
+	var file *downloadArchive
+	total := 0
+	for {
+		if archive && file == nil {
+			if file, err = openDownloadArchive(archivePath, now); err != nil {
+				return total, "", err
+			}
+		}
+		n, err := compactDownloadBatch(cutoff, cursor.LastHistoryID, file)
+		total += n
+		if err != nil || n < downloadCompactBatch {
+			if file == nil {
+				return total, "", err
+			}
+			closeErr := file.close()
+			if total == 0 {
+				// 没有需要压缩的记录，移除空归档
+				_ = os.Remove(file.path)
+				return 0, "", err
+			}
+			if err == nil {
+				err = closeErr
+			}
+			if recordErr := recordDownloadArchive(file.path, total, cutoff); recordErr != nil {
+				global.GVA_LOG.Warn("记录下载历史归档失败", zap.String("path", file.path), zap.Error(recordErr))
+			}
+			return total, file.path, err
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package system
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func Test_aggregateDownloadSummaries(t *testing.T) {
+	u1, u2 := uuid.New(), uuid.New()
+	deleted := system.SysDownloadHistory{UserUUID: u1, DrawingID: 1, DownloadAt: 50}
+	deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	summaries := aggregateDownloadSummaries([]system.SysDownloadHistory{
+		{UserUUID: u1, DrawingID: 1, DownloadAt: 300, Watermarked: true},
+		{UserUUID: u1, DrawingID: 1, DownloadAt: 100},
+		{UserUUID: u2, DrawingID: 1, DownloadAt: 200},
+		deleted,
+	})
+	if len(summaries) != 2 {
+		t.Fatalf("aggregateDownloadSummaries() = %+v", summaries)
+	}
+	s := summaries[0]
+	if s.UserUUID != u1 || s.FirstDownloadAt != 100 || s.LastDownloadAt != 300 || s.Downloads != 2 || s.Watermarked != 1 {
+		t.Fatalf("summary for u1 = %+v", s)
+	}
+}
+
+func Test_downloadArchive(t *testing.T) {
+	archive, err := openDownloadArchive(t.TempDir(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	histories := []system.SysDownloadHistory{{DrawingID: 1}, {DrawingID: 2}}
+	if err = archive.write(histories); err != nil {
+		t.Fatal(err)
+	}
+	if err = archive.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(archive.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := 0
+	for scanner := bufio.NewScanner(gz); scanner.Scan(); {
+		lines++
+	}
+	if lines != len(histories) {
+		t.Fatalf("archived %d lines, want %d", lines, len(histories))
+	}
+}
+
+func Test_privateArchiveDir(t *testing.T) {
+	prev := global.GVA_CONFIG.Local
+	global.GVA_CONFIG.Local.StorePath = "uploads/file"
+	defer func() { global.GVA_CONFIG.Local = prev }()
+
+	for _, dir := range []string{"uploads/file", "uploads/file/archive", "uploads/archive/download_histories"} {
+		if _, err := privateArchiveDir(dir); err == nil {
+			t.Fatalf("privateArchiveDir(%q) should reject public directory", dir)
+		}
+	}
+	if dir, err := privateArchiveDir(""); err != nil || dir != defaultDownloadArchivePath {
+		t.Fatalf("privateArchiveDir(\"\") = %q, %v", dir, err)
+	}
+}
@@ -21,53 +21,99 @@ import (
 
 type DrawingService struct{}
 
-// CreateDrawing 创建图纸
-func (drawingService *DrawingService) CreateDrawing(req request.CreateDrawing) (*system.SysDrawing, error) {
-	// 检查序号是否已存在
-	var existingDrawing system.SysDrawing
-	err := global.GVA_DB.Where("album_id = ? AND serial_number = ?", req.AlbumID, req.SerialNumber).First(&existingDrawing).Error
-	if err == nil {
-		return nil, errors.New("该序号已存在")
+// CreateDrawing 创建图纸，同时返回与创建者可查看的图纸疑似重复的列表（仅提示，不阻止创建）
+// 未指定状态时有相册审核权限的直接发布、否则提交审核；创建者为当前登录用户（userID/userUUID），忽略请求中的 creatorUUID
+func (drawingService *DrawingService) CreateDrawing(req request.CreateDrawing, userID uint, userUUID uuid.UUID) (*system.SysDrawing, []systemRes.SimilarDrawing, error) {
+	status, publishAt, err := initialDrawingStatus(req, userID, userUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 检查序号是否已存在，未填写序号时在事务中按相册编号规则分配
+	serialNumber := strings.TrimSpace(req.SerialNumber)
+	if serialNumber != "" {
+		taken, err := serialNumberTaken(global.GVA_DB, req.AlbumID, serialNumber, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		if taken {
+			return nil, nil, errors.New("该序号已存在")
+		}
 	}
 
 	// 将图纸文件URLs转换为JSON字符串
 	drawingURLsJSON, err := json.Marshal(req.DrawingURLs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 将允许下载的成员UUIDs转换为JSON字符串
 	allowedMembersJSON, err := json.Marshal(req.AllowedMemberUUIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// 整理色号用量清单
+	colors, err := buildDrawingColors(req.Colors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 按相册字段定义校验自定义字段
+	fieldValues, err := validateDrawingFields(req.AlbumID, req.Fields)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	drawing := &system.SysDrawing{
 		AlbumID:        req.AlbumID,
-		SerialNumber:   req.SerialNumber,
+		SerialNumber:   serialNumber,
 		Name:           req.Name,
 		BeanQuantity:   req.BeanQuantity,
 		PosterImageURL: req.PosterImageURL,
 		DrawingURLs:    string(drawingURLsJSON),
-		CreatorUUID:    req.CreatorUUID,
+		CreatorUUID:    userUUID,
 		AllowedMembers: string(allowedMembersJSON),
+		Status:         status,
+		PublishAt:      publishAt,
+		Colors:         colors,
 	}
 
-	err = global.GVA_DB.Create(drawing).Error
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if drawing.SerialNumber == "" {
+			serial, err := allocateSerialNumber(tx, req.AlbumID, time.Now())
+			if err != nil {
+				return err
+			}
+			drawing.SerialNumber = serial
+		}
+		drawing.SerialSortKey = serialSortKey(drawing.SerialNumber)
+		if err := tx.Create(drawing).Error; err != nil {
+			return err
+		}
+		return replaceDrawingFieldValues(tx, drawing.ID, fieldValues)
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, duplicateSerialError(err, req.AlbumID, drawing.SerialNumber, 0)
 	}
 
+	// 计算海报与图纸图片的感知哈希并检查疑似重复
+	duplicates := checkDuplicateDrawings(*drawing)
+
+	// 异步生成海报与图纸图片衍生图，并同步检索索引
+	ImageRenditionServiceApp.QueueDrawingRenditions(drawing.ID)
+	SearchServiceApp.QueueDrawings(drawing.ID)
+
 	// 预加载关联数据
-	err = global.GVA_DB.Preload("Album").Preload("Creator").First(drawing, drawing.ID).Error
+	err = global.GVA_DB.Preload("Album").Preload("Creator").Preload("Colors").Preload("Renditions").Preload("FieldValues.Field").First(drawing, drawing.ID).Error
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return drawing, nil
+	return drawing, duplicates, nil
 }
 
-// UpdateDrawing 更新图纸
+// UpdateDrawing 更新图纸，不能更换所属相册（更换相册需通过 MoveDrawings 校验目标相册权限与序号冲突）
 func (drawingService *DrawingService) UpdateDrawing(req request.UpdateDrawing) error {
 	// 检查图纸是否存在
 	var existingDrawing system.SysDrawing
@@ -79,11 +125,17 @@ func (drawingService *DrawingService) UpdateDrawing(req request.UpdateDrawing) e
 		return err
 	}
 
-	// 检查序号是否已被其他图纸使用
+	if req.AlbumID != existingDrawing.AlbumID {
+		return errors.New("不能通过编辑更换相册，请使用移动图纸功能")
+	}
+
+	// 检查序号是否已被相册中的其他图纸使用
 	if req.SerialNumber != existingDrawing.SerialNumber {
-		var duplicateDrawing system.SysDrawing
-		err = global.GVA_DB.Where("album_id = ? AND serial_number = ? AND id != ?", req.AlbumID, req.SerialNumber, req.ID).First(&duplicateDrawing).Error
-		if err == nil {
+		taken, err := serialNumberTaken(global.GVA_DB, existingDrawing.AlbumID, req.SerialNumber, req.ID)
+		if err != nil {
+			return err
+		}
+		if taken {
 			return errors.New("该序号已被其他图纸使用")
 		}
 	}
@@ -102,8 +154,8 @@ func (drawingService *DrawingService) UpdateDrawing(req request.UpdateDrawing) e
 
 	// 更新图纸
 	updates := map[string]interface{}{
-		"album_id":         req.AlbumID,
 		"serial_number":    req.SerialNumber,
+		"serial_sort_key":  serialSortKey(req.SerialNumber),
 		"name":             req.Name,
 		"bean_quantity":    req.BeanQuantity,
 		"poster_image_url": req.PosterImageURL,
@@ -111,31 +163,209 @@ func (drawingService *DrawingService) UpdateDrawing(req request.UpdateDrawing) e
 		"allowed_members":  string(allowedMembersJSON),
 	}
 
-	return global.GVA_DB.Model(&existingDrawing).Updates(updates).Error
+	// 未提供色号清单时保留原有数据
+	var colors []system.SysDrawingColor
+	if req.Colors != nil {
+		colors, err = buildDrawingColors(req.Colors)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 提供了自定义字段或更换了相册时，按目标相册字段定义重新校验
+	fieldsChanged := req.Fields != nil
+	var fieldValues []system.SysDrawingFieldValue
+	if fieldsChanged {
+		fieldValues, err = validateDrawingFields(existingDrawing.AlbumID, req.Fields)
+		if err != nil {
+			return err
+		}
+	}
+
+	imagesChanged := req.PosterImageURL != existingDrawing.PosterImageURL || string(drawingURLsJSON) != existingDrawing.DrawingURLs
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&existingDrawing).Updates(updates).Error; err != nil {
+			return err
+		}
+		if req.Colors != nil {
+			if err := replaceDrawingColors(tx, existingDrawing.ID, colors); err != nil {
+				return err
+			}
+		}
+		if fieldsChanged {
+			return replaceDrawingFieldValues(tx, existingDrawing.ID, fieldValues)
+		}
+		return nil
+	})
+	if err != nil {
+		return duplicateSerialError(err, existingDrawing.AlbumID, req.SerialNumber, existingDrawing.ID)
+	}
+
+	// 图片有变化时异步刷新感知哈希与衍生图
+	if imagesChanged {
+		drawingService.QueueDrawingHashes(existingDrawing.ID)
+		ImageRenditionServiceApp.QueueDrawingRenditions(existingDrawing.ID)
+	}
+	SearchServiceApp.QueueDrawings(existingDrawing.ID)
+	return nil
+}
+
+// buildDrawingColors 规范化色号用量清单，合并重复色号
+func buildDrawingColors(items []request.DrawingColor) ([]system.SysDrawingColor, error) {
+	colors := make([]system.SysDrawingColor, 0, len(items))
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		code := normalizeColorCode(item.ColorCode)
+		if code == "" {
+			return nil, errors.New("色号不能为空")
+		}
+		if item.Quantity < 0 {
+			return nil, fmt.Errorf("色号 %s 的用量不能为负数", code)
+		}
+		if i, ok := index[code]; ok {
+			colors[i].Quantity += item.Quantity
+			continue
+		}
+		index[code] = len(colors)
+		colors = append(colors, system.SysDrawingColor{ColorCode: code, Quantity: item.Quantity})
+	}
+	return colors, nil
+}
+
+// replaceDrawingColors 整体替换图纸的色号用量清单
+func replaceDrawingColors(tx *gorm.DB, drawingID uint, colors []system.SysDrawingColor) error {
+	if err := tx.Where("drawing_id = ?", drawingID).Delete(&system.SysDrawingColor{}).Error; err != nil {
+		return err
+	}
+	if len(colors) == 0 {
+		return nil
+	}
+	for i := range colors {
+		colors[i].DrawingID = drawingID
+	}
+	return tx.Create(&colors).Error
+}
+
+// normalizeColorCode 统一色号格式（去空格、大写）
+func normalizeColorCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// accessibleDrawingsScope 当前用户可查看图纸的筛选条件（与 GetMyDrawings 口径一致）
+// 可查看 = 图纸创建者 ∪ 已发布图纸中的（图纸单独授权（永久或在有效期内）∪ 有效的相册成员（含查看者））
+func accessibleDrawingsScope(userUUID string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query, args := accessibleDrawingsCondition(userUUID)
+		return db.Where(query, args...)
+	}
+}
+
+// visibleDrawingsScope 当前用户可查看图纸的筛选条件：可查看的图纸以及自己创建或管理的相册下的图纸，用于列表、搜索与推荐
+func visibleDrawingsScope(userUUID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query, args := withManagedAlbumsCondition(accessibleDrawingsCondition(userUUID.String()))
+		return db.Where(query, append(args, userUUID, userUUID)...)
+	}
+}
+
+// downloadableDrawingsScope 当前用户可下载图纸的筛选条件：可下载的图纸以及自己创建或管理的相册下的图纸
+func downloadableDrawingsScope(userUUID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query, args := withManagedAlbumsCondition(downloadableDrawingsCondition(userUUID.String()))
+		return db.Where(query, append(args, userUUID, userUUID)...)
+	}
+}
+
+// withManagedAlbumsCondition 在访问条件上追加自己创建或管理的相册，调用方需再追加两个用户UUID参数
+func withManagedAlbumsCondition(query string, args []interface{}) (string, []interface{}) {
+	query += " OR EXISTS (SELECT 1 FROM sys_albums a WHERE a.id = sys_drawings.album_id AND a.creator_uuid = ?)" +
+		" OR EXISTS (SELECT 1 FROM sys_album_admin aa JOIN sys_users u ON u.id = aa.user_id WHERE aa.album_id = sys_drawings.album_id AND u.uuid = ?)"
+	return query, args
+}
+
+// accessibleDrawingsCondition 可查看图纸的查询条件及参数，任意角色的有效相册成员均可查看
+func accessibleDrawingsCondition(userUUID string) (string, []interface{}) {
+	return drawingAccessCondition(userUUID, system.AlbumRoleViewer)
+}
+
+// downloadableDrawingsCondition 可下载图纸的查询条件及参数，相册成员需具有下载及以上角色
+func downloadableDrawingsCondition(userUUID string) (string, []interface{}) {
+	return drawingAccessCondition(userUUID, system.AlbumRoleDownloader)
+}
+
+// drawingAccessCondition 图纸访问条件，相册成员的角色需不低于 minRole；允许下载的成员为 UUID 字符串数组，按带引号的 UUID 匹配以兼容各数据库
+func drawingAccessCondition(userUUID string, minRole string) (string, []interface{}) {
+	now := time.Now()
+	query := "sys_drawings.creator_uuid = ? OR (sys_drawings.status = ? AND (sys_drawings.allowed_members LIKE ? OR " +
+		drawingGrantAccessSQL + " OR " + albumMemberAccessSQL + "))"
+	args := []interface{}{
+		userUUID, system.DrawingStatusPublished, fmt.Sprintf("%%\"%s\"%%", userUUID),
+		userUUID, now, now,
+		userUUID, system.AlbumRolesAtLeast(minRole), now, now,
+	}
+	return query, args
+}
+
+// checkDrawingsDownloadable 校验用户对图纸的下载权限（含限时授权的有效期）
+func checkDrawingsDownloadable(drawingIDs []uint, userUUID uuid.UUID) error {
+	var count int64
+	err := global.GVA_DB.Model(&system.SysDrawing{}).
+		Where("sys_drawings.id IN ?", drawingIDs).
+		Scopes(downloadableDrawingsScope(userUUID)).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count < int64(len(drawingIDs)) {
+		return errors.New("没有下载权限或授权已过期")
+	}
+	return nil
 }
 
 // DeleteDrawing 删除图纸
 func (drawingService *DrawingService) DeleteDrawing(req request.DeleteDrawing) error {
-	return global.GVA_DB.Delete(&system.SysDrawing{}, req.ID).Error
+	if err := global.GVA_DB.Delete(&system.SysDrawing{}, req.ID).Error; err != nil {
+		return err
+	}
+	SearchServiceApp.QueueDrawings(req.ID)
+	return nil
 }
 
-// GetDrawingByID 根据ID获取图纸
-func (drawingService *DrawingService) GetDrawingByID(req request.GetDrawingByID) (*system.SysDrawing, error) {
+// GetDrawingByID 根据ID获取图纸，未发布的图纸仅创建者与有相册审核权限的用户可见
+func (drawingService *DrawingService) GetDrawingByID(req request.GetDrawingByID, userID uint, userUUID uuid.UUID) (*system.SysDrawing, error) {
 	var drawing system.SysDrawing
-	err := global.GVA_DB.Preload("Album").Preload("Creator").First(&drawing, req.ID).Error
+	err := global.GVA_DB.Preload("Album").Preload("Creator").Preload("Colors").Preload("Renditions").Preload("FieldValues.Field").First(&drawing, req.ID).Error
 	if err != nil {
 		return nil, err
 	}
+	if drawing.Status != system.DrawingStatusPublished && drawing.CreatorUUID != userUUID {
+		ok, err := canEditAlbum(drawing.AlbumID, userID, userUUID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("图纸不存在")
+		}
+	}
 	return &drawing, nil
 }
 
-// GetDrawingList 获取图纸列表
-func (drawingService *DrawingService) GetDrawingList(req request.GetDrawingList) ([]*system.SysDrawing, int64, error) {
+// GetDrawingList 获取图纸列表，没有相册审核权限的用户只能看到已发布的图纸及自己创建的图纸
+func (drawingService *DrawingService) GetDrawingList(req request.GetDrawingList, userID uint, userUUID uuid.UUID) ([]*system.SysDrawing, int64, error) {
 	var drawings []*system.SysDrawing
 	var total int64
 
 	db := global.GVA_DB.Model(&system.SysDrawing{}).Where("album_id = ?", req.AlbumID)
 
+	editable, err := canEditAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !editable {
+		db = db.Where("status = ? OR creator_uuid = ?", system.DrawingStatusPublished, userUUID)
+	}
+
 	// 添加搜索条件
 	if req.Keyword != "" {
 		db = db.Where("serial_number LIKE ? OR name LIKE ?", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
@@ -146,8 +376,32 @@ func (drawingService *DrawingService) GetDrawingList(req request.GetDrawingList)
 		db = db.Where("creator_id = ?", req.CreatorID)
 	}
 
+	// 按发布状态筛选
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+
+	// sort 为内置排序方式时按对应规则排序，否则按内置列或自定义字段排序；未指定时按手动排序位置
+	sort := req.Sort
+	if sort == "" {
+		sort = system.ListSortPosition
+	}
+	sortBy := ""
+	if !isDrawingListSort(sort) {
+		sortBy = sort
+	}
+	db, order, err := applyDrawingFieldQuery(db, req.AlbumID, req.Filters, sortBy, req.SortOrder)
+	if err != nil {
+		return nil, 0, err
+	}
+	if sortBy == "" {
+		if order, err = drawingListOrder(sort, false); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	// 获取总数
-	err := db.Count(&total).Error
+	err = db.Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -159,7 +413,7 @@ func (drawingService *DrawingService) GetDrawingList(req request.GetDrawingList)
 	}
 
 	// 预加载关联数据
-	err = db.Preload("Album").Preload("Creator").Order("created_at DESC").Find(&drawings).Error
+	err = db.Preload("Album").Preload("Creator").Preload("Renditions").Preload("FieldValues.Field").Order(order).Find(&drawings).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -167,7 +421,7 @@ func (drawingService *DrawingService) GetDrawingList(req request.GetDrawingList)
 	return drawings, total, nil
 }
 
-// GetMyDrawings 获取当前用户可下载的图纸列表
+// GetMyDrawings 获取当前用户可查看的图纸列表，下载时另按下载权限校验
 func (drawingService *DrawingService) GetMyDrawings(req request.GetMyDrawings) ([]*system.SysDrawing, int64, error) {
 	var drawings []*system.SysDrawing
 	var total int64
@@ -186,8 +440,7 @@ func (drawingService *DrawingService) GetMyDrawings(req request.GetMyDrawings) (
 	db := global.GVA_DB.Model(&system.SysDrawing{}).
 		Joins("LEFT JOIN sys_albums ON sys_drawings.album_id = sys_albums.id").
 		Joins("LEFT JOIN sys_album_admin ON sys_albums.id = sys_album_admin.album_id").
-		Where("sys_drawings.creator_uuid = ? OR JSON_CONTAINS(sys_drawings.allowed_members, ?)",
-			req.UserUUID, fmt.Sprintf("\"%s\"", req.UserUUID))
+		Scopes(accessibleDrawingsScope(req.UserUUID))
 
 	// 添加搜索条件
 	if req.Keyword != "" {
@@ -195,8 +448,13 @@ func (drawingService *DrawingService) GetMyDrawings(req request.GetMyDrawings) (
 			"%"+req.Keyword+"%", "%"+req.Keyword+"%")
 	}
 
+	order, err := drawingListOrder(req.Sort, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// 获取总数
-	err := db.Count(&total).Error
+	err = db.Count(&total).Error
 	if err != nil {
 		global.GVA_LOG.Error("获取总数失败", zap.Error(err))
 		return nil, 0, err
@@ -211,8 +469,8 @@ func (drawingService *DrawingService) GetMyDrawings(req request.GetMyDrawings) (
 	}
 
 	// 预加载关联数据并去重，使用子查询来避免DISTINCT和ORDER BY的冲突
-	err = db.Preload("Album").Preload("Creator").
-		Order("sys_drawings.created_at DESC").
+	err = db.Preload("Album").Preload("Creator").Preload("Renditions").
+		Order(order).
 		Find(&drawings).Error
 	if err != nil {
 		global.GVA_LOG.Error("查询图纸列表失败", zap.Error(err))
@@ -281,6 +539,7 @@ func (drawingService *DrawingService) UpdateEmptyDrawings() error {
 		updates := map[string]interface{}{
 			"album_id":         album.ID,
 			"serial_number":    fmt.Sprintf("TEST-%03d", i+1),
+			"serial_sort_key":  serialSortKey(fmt.Sprintf("TEST-%03d", i+1)),
 			"name":             fmt.Sprintf("测试图纸%d", i+1),
 			"bean_quantity":    (i + 1) * 100,
 			"poster_image_url": fmt.Sprintf("uploads/test/poster%d.jpg", i+1),
@@ -305,7 +564,7 @@ func (drawingService *DrawingService) UpdateEmptyDrawings() error {
 }
 
 // DownloadDrawing 下载图纸
-func (drawingService *DrawingService) DownloadDrawing(req request.DownloadDrawing, userUUID uuid.UUID) (*systemRes.DownloadResponse, error) {
+func (drawingService *DrawingService) DownloadDrawing(req request.DownloadDrawing, userUUID uuid.UUID, client request.DownloadClient) (*systemRes.DownloadResponse, error) {
 	// 获取图纸信息
 	var drawing system.SysDrawing
 	err := global.GVA_DB.First(&drawing, req.DrawingID).Error
@@ -319,15 +578,9 @@ func (drawingService *DrawingService) DownloadDrawing(req request.DownloadDrawin
 		zap.String("drawing_name", drawing.Name),
 		zap.String("drawing_urls", drawing.DrawingURLs))
 
-	// 检查权限（这里可以添加更复杂的权限检查逻辑）
-	// TODO: 实现权限检查
-
-	// 记录下载历史
-	downloadHistoryService := &DownloadHistoryService{}
-	err = downloadHistoryService.RecordDownload(userUUID, req.DrawingID, req.AlbumID)
-	if err != nil {
-		global.GVA_LOG.Warn("记录下载历史失败", zap.Error(err))
-		// 不因为记录失败而阻止下载
+	// 检查权限（含限时授权的有效期）
+	if err = checkDrawingsDownloadable([]uint{drawing.ID}, userUUID); err != nil {
+		return nil, err
 	}
 
 	// 解析图纸文件URLs
@@ -352,20 +605,28 @@ func (drawingService *DrawingService) DownloadDrawing(req request.DownloadDrawin
 			zap.String("drawing_name", drawing.Name))
 	}
 
+	// 计入下载额度，超限时拒绝下载
+	cost := map[uint]downloadQuotaUsage{drawing.AlbumID: {Downloads: 1, Bytes: downloadFilesSize(drawingURLs)}}
+	if err = consumeDownloadQuota(userUUID, cost); err != nil {
+		return nil, err
+	}
+
 	// 获取创建者信息用于水印
 	var creator system.SysUser
 	if err := global.GVA_DB.Where("uuid = ?", drawing.CreatorUUID).First(&creator).Error; err != nil {
 		global.GVA_LOG.Warn("获取创建者信息失败", zap.Error(err))
 	}
 
-	// 处理水印
+	// 处理水印，任一文件水印失败改为提供原图时，下载记录视为未加水印
 	var filePaths []string
+	var appliedWatermark string
 	if req.AddWatermark && len(drawingURLs) > 0 {
 		watermarkService := watermark.NewWatermarkService()
 		watermarkText := req.WatermarkText
 		if watermarkText == "" {
 			watermarkText = fmt.Sprintf("创建者: %s", creator.Username)
 		}
+		appliedWatermark = watermarkText
 
 		// 为每个图纸文件添加水印
 		for _, drawingURL := range drawingURLs {
@@ -395,6 +656,7 @@ func (drawingService *DrawingService) DownloadDrawing(req request.DownloadDrawin
 					// 水印失败时，返回原文件的HTTP路径
 					httpPath := "/api/v1/drawing/file/" + filepath.Base(fullPath)
 					filePaths = append(filePaths, httpPath)
+					appliedWatermark = ""
 				}
 			} else {
 				global.GVA_LOG.Warn("文件不存在", zap.String("file", fullPath), zap.Error(err))
@@ -424,6 +686,13 @@ func (drawingService *DrawingService) DownloadDrawing(req request.DownloadDrawin
 		}
 	}
 
+	// 记录下载历史（含实际提供的文件与水印信息）
+	history := newDownloadHistory(userUUID, drawing.ID, drawing.AlbumID, system.DownloadChannelSingle, client, filePaths, appliedWatermark)
+	if err = (&DownloadHistoryService{}).RecordDownload(history); err != nil {
+		global.GVA_LOG.Warn("记录下载历史失败", zap.Error(err))
+		// 不因为记录失败而阻止下载
+	}
+
 	// 添加最终调试日志
 	global.GVA_LOG.Info("下载完成",
 		zap.Uint("drawing_id", req.DrawingID),
@@ -445,13 +714,24 @@ func (drawingService *DrawingService) DownloadDrawing(req request.DownloadDrawin
 }
 
 // RecordDownload 点击下载时记录下载历史（不返回文件）
-func (drawingService *DrawingService) RecordDownload(req request.RecordDownload, userUUID uuid.UUID) error {
-	downloadHistoryService := &DownloadHistoryService{}
-	return downloadHistoryService.RecordDownload(userUUID, req.DrawingID, req.AlbumID)
+// 记录为前端上报渠道，只用于下载状态与台账，不计入下载统计；相册以图纸所在相册为准
+func (drawingService *DrawingService) RecordDownload(req request.RecordDownload, userUUID uuid.UUID, client request.DownloadClient) error {
+	var drawing system.SysDrawing
+	if err := global.GVA_DB.Select("id", "album_id").First(&drawing, req.DrawingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("图纸不存在")
+		}
+		return err
+	}
+	if err := checkDrawingsDownloadable([]uint{drawing.ID}, userUUID); err != nil {
+		return err
+	}
+	history := newDownloadHistory(userUUID, drawing.ID, drawing.AlbumID, system.DownloadChannelClient, client, nil, "")
+	return (&DownloadHistoryService{}).RecordDownload(history)
 }
 
 // BatchDownloadDrawings 批量下载图纸
-func (drawingService *DrawingService) BatchDownloadDrawings(req request.BatchDownloadDrawings, userUUID uuid.UUID) (*systemRes.DownloadResponse, error) {
+func (drawingService *DrawingService) BatchDownloadDrawings(req request.BatchDownloadDrawings, userUUID uuid.UUID, client request.DownloadClient) (*systemRes.DownloadResponse, error) {
 	// 获取所有图纸信息
 	var drawings []system.SysDrawing
 	err := global.GVA_DB.Where("id IN ?", req.DrawingIDs).Find(&drawings).Error
@@ -465,21 +745,35 @@ func (drawingService *DrawingService) BatchDownloadDrawings(req request.BatchDow
 		zap.Int("found_drawings", len(drawings)),
 		zap.Any("drawing_ids", req.DrawingIDs))
 
-	// 检查权限（这里可以添加更复杂的权限检查逻辑）
-	// TODO: 实现权限检查
-
-	// 记录批量下载历史
-	downloadHistoryService := &DownloadHistoryService{}
+	// 检查权限（含限时授权的有效期）
+	ids := make([]uint, 0, len(drawings))
 	for _, drawing := range drawings {
-		err = downloadHistoryService.RecordDownload(userUUID, drawing.ID, req.AlbumID)
-		if err != nil {
-			global.GVA_LOG.Warn("记录下载历史失败", zap.Error(err))
-			// 不因为记录失败而阻止下载
+		ids = append(ids, drawing.ID)
+	}
+	if len(ids) > 0 {
+		if err = checkDrawingsDownloadable(ids, userUUID); err != nil {
+			return nil, err
 		}
 	}
 
-	// 收集所有图纸文件URLs
+	// 按图纸所在相册计入下载额度，超限时整批拒绝
+	cost := make(map[uint]downloadQuotaUsage)
+	for _, drawing := range drawings {
+		var drawingURLs []string
+		_ = json.Unmarshal([]byte(drawing.DrawingURLs), &drawingURLs)
+		usage := cost[drawing.AlbumID]
+		usage.Downloads++
+		usage.Bytes += downloadFilesSize(drawingURLs)
+		cost[drawing.AlbumID] = usage
+	}
+	if err = consumeDownloadQuota(userUUID, cost); err != nil {
+		return nil, err
+	}
+
+	// 收集所有图纸文件URLs，同时按图纸记录实际提供的文件及水印是否降级为原图
 	var allFilePaths []string
+	servedFiles := make(map[uint][]string, len(drawings))
+	watermarkFallback := make(map[uint]bool)
 	if req.AddWatermark {
 		watermarkService := watermark.NewWatermarkService()
 		var watermarkText string
@@ -528,12 +822,15 @@ func (drawingService *DrawingService) BatchDownloadDrawings(req request.BatchDow
 							// 返回可以通过HTTP访问的路径
 							httpPath := "/api/v1/drawing/watermark/" + filepath.Base(watermarkedPath)
 							allFilePaths = append(allFilePaths, httpPath)
+							servedFiles[drawing.ID] = append(servedFiles[drawing.ID], httpPath)
 							global.GVA_LOG.Info("添加水印成功", zap.String("file", fullPath), zap.String("http_path", httpPath))
 						} else {
 							global.GVA_LOG.Warn("添加水印失败", zap.String("file", fullPath), zap.Error(err))
 							// 水印失败时，返回原文件的HTTP路径
 							httpPath := "/api/v1/drawing/file/" + filepath.Base(fullPath)
 							allFilePaths = append(allFilePaths, httpPath)
+							servedFiles[drawing.ID] = append(servedFiles[drawing.ID], httpPath)
+							watermarkFallback[drawing.ID] = true
 						}
 					} else {
 						global.GVA_LOG.Warn("文件不存在", zap.String("file", fullPath), zap.Error(err))
@@ -585,6 +882,7 @@ func (drawingService *DrawingService) BatchDownloadDrawings(req request.BatchDow
 
 					if _, err := os.Stat(fullPath); err == nil {
 						allFilePaths = append(allFilePaths, fullPath)
+						servedFiles[drawing.ID] = append(servedFiles[drawing.ID], fullPath)
 						global.GVA_LOG.Info("文件存在（无水印）", zap.String("file", fullPath))
 					} else {
 						global.GVA_LOG.Warn("文件不存在（无水印）", zap.String("file", fullPath), zap.Error(err))
@@ -598,6 +896,20 @@ func (drawingService *DrawingService) BatchDownloadDrawings(req request.BatchDow
 		}
 	}
 
+	// 记录批量下载历史
+	downloadHistoryService := &DownloadHistoryService{}
+	for _, drawing := range drawings {
+		var watermarkText string
+		if req.AddWatermark && !watermarkFallback[drawing.ID] {
+			watermarkText = "author: " + drawing.CreatorUUID.String()
+		}
+		history := newDownloadHistory(userUUID, drawing.ID, drawing.AlbumID, system.DownloadChannelBatch, client, servedFiles[drawing.ID], watermarkText)
+		if err = downloadHistoryService.RecordDownload(history); err != nil {
+			global.GVA_LOG.Warn("记录下载历史失败", zap.Error(err))
+			// 不因为记录失败而阻止下载
+		}
+	}
+
 	// 添加最终调试日志
 	global.GVA_LOG.Info("批量下载完成",
 		zap.Int("total_file_paths", len(allFilePaths)),
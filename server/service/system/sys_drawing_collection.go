@@ -0,0 +1,466 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DrawingCollectionService struct{}
+
+const (
+	collectionDefaultName     = "我的收藏"
+	collectionDefaultPageSize = 20
+	collectionItemOrder       = "ci.position ASC, ci.created_at ASC"
+)
+
+// ownedCollection 获取当前用户拥有的收藏夹
+func ownedCollection(collectionID uint, userUUID uuid.UUID) (system.SysDrawingCollection, error) {
+	var collection system.SysDrawingCollection
+	if err := global.GVA_DB.First(&collection, collectionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return collection, errors.New("收藏夹不存在")
+		}
+		return collection, err
+	}
+	if collection.OwnerUUID != userUUID {
+		return collection, errors.New("仅收藏夹所有者可以修改")
+	}
+	return collection, nil
+}
+
+// viewableCollection 获取当前用户可查看的收藏夹：自己的或他人共享的
+func viewableCollection(collectionID uint, userUUID uuid.UUID) (system.SysDrawingCollection, error) {
+	var collection system.SysDrawingCollection
+	err := global.GVA_DB.Where("id = ?", collectionID).
+		Where("owner_uuid = ? OR EXISTS (SELECT 1 FROM sys_drawing_collection_shares cs WHERE cs.collection_id = sys_drawing_collections.id AND cs.user_uuid = ?)", userUUID, userUUID).
+		First(&collection).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return collection, errors.New("收藏夹不存在或没有访问权限")
+	}
+	return collection, err
+}
+
+// collectionDrawingsQuery 收藏夹中当前用户可访问的图纸，访问权限已被撤销或已删除的图纸不返回
+func collectionDrawingsQuery(collectionID uint, userUUID uuid.UUID) *gorm.DB {
+	return global.GVA_DB.Model(&system.SysDrawing{}).
+		Joins("JOIN sys_drawing_collection_items ci ON ci.drawing_id = sys_drawings.id AND ci.collection_id = ?", collectionID).
+		Scopes(downloadableDrawingsScope(userUUID))
+}
+
+// addCollectionItems 将图纸追加到收藏夹末尾，已在收藏夹中的图纸跳过，返回新加入的数量
+func addCollectionItems(tx *gorm.DB, collectionID uint, drawingIDs []uint) (int, error) {
+	var items []system.SysDrawingCollectionItem
+	if err := tx.Where("collection_id = ?", collectionID).Find(&items).Error; err != nil {
+		return 0, err
+	}
+	exists := make(map[uint]bool, len(items))
+	position := 0
+	for _, item := range items {
+		exists[item.DrawingID] = true
+		position = max(position, item.Position)
+	}
+
+	var added []system.SysDrawingCollectionItem
+	for _, id := range drawingIDs {
+		if exists[id] {
+			continue
+		}
+		exists[id] = true
+		position++
+		added = append(added, system.SysDrawingCollectionItem{CollectionID: collectionID, DrawingID: id, Position: position})
+	}
+	if len(added) == 0 {
+		return 0, nil
+	}
+	return len(added), tx.Create(&added).Error
+}
+
+// reorderCollectionItems 将 drawingIDs 依次排在最前，其余图纸保持原相对顺序排在其后，返回位置有变化的条目
+func reorderCollectionItems(items []system.SysDrawingCollectionItem, drawingIDs []uint) ([]system.SysDrawingCollectionItem, error) {
+	byDrawing := make(map[uint]int, len(items))
+	for i, item := range items {
+		byDrawing[item.DrawingID] = i
+	}
+	ordered := make([]system.SysDrawingCollectionItem, 0, len(items))
+	placed := make(map[uint]bool, len(drawingIDs))
+	for _, id := range drawingIDs {
+		i, ok := byDrawing[id]
+		if !ok {
+			return nil, fmt.Errorf("图纸 %d 不在收藏夹中", id)
+		}
+		placed[id] = true
+		ordered = append(ordered, items[i])
+	}
+	for _, item := range items {
+		if !placed[item.DrawingID] {
+			ordered = append(ordered, item)
+		}
+	}
+
+	var changed []system.SysDrawingCollectionItem
+	for i := range ordered {
+		if ordered[i].Position != i+1 {
+			ordered[i].Position = i + 1
+			changed = append(changed, ordered[i])
+		}
+	}
+	return changed, nil
+}
+
+// CreateCollection 创建收藏夹
+func (collectionService *DrawingCollectionService) CreateCollection(req request.CreateDrawingCollection, userUUID uuid.UUID) (system.SysDrawingCollection, error) {
+	collection := system.SysDrawingCollection{
+		OwnerUUID:   userUUID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	err := global.GVA_DB.Create(&collection).Error
+	return collection, err
+}
+
+// UpdateCollection 更新收藏夹名称与描述
+func (collectionService *DrawingCollectionService) UpdateCollection(req request.UpdateDrawingCollection, userUUID uuid.UUID) error {
+	collection, err := ownedCollection(req.ID, userUUID)
+	if err != nil {
+		return err
+	}
+	return global.GVA_DB.Model(&collection).Updates(map[string]interface{}{
+		"name":        req.Name,
+		"description": req.Description,
+	}).Error
+}
+
+// DeleteCollection 删除收藏夹，连同其中的图纸条目与共享
+func (collectionService *DrawingCollectionService) DeleteCollection(req request.DeleteDrawingCollection, userUUID uuid.UUID) error {
+	collection, err := ownedCollection(req.ID, userUUID)
+	if err != nil {
+		return err
+	}
+	if collection.IsDefault {
+		return errors.New("默认收藏夹不能删除")
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collection.ID).Delete(&system.SysDrawingCollectionItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ?", collection.ID).Delete(&system.SysDrawingCollectionShare{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&collection).Error
+	})
+}
+
+// GetCollections 获取当前用户的收藏夹与他人共享给自己的收藏夹，图纸数只统计当前用户可访问的图纸
+func (collectionService *DrawingCollectionService) GetCollections(userUUID uuid.UUID) ([]systemRes.DrawingCollectionResponse, error) {
+	var owned, shared []system.SysDrawingCollection
+	err := global.GVA_DB.Where("owner_uuid = ?", userUUID).Preload("Owner").
+		Order("is_default DESC, created_at DESC").Find(&owned).Error
+	if err != nil {
+		return nil, err
+	}
+	err = global.GVA_DB.Joins("JOIN sys_drawing_collection_shares cs ON cs.collection_id = sys_drawing_collections.id AND cs.user_uuid = ?", userUUID).
+		Preload("Owner").Order("cs.created_at DESC").Find(&shared).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(owned)+len(shared))
+	for _, collection := range append(owned, shared...) {
+		ids = append(ids, collection.ID)
+	}
+	if len(ids) == 0 {
+		return []systemRes.DrawingCollectionResponse{}, nil
+	}
+
+	var counts []struct {
+		CollectionID uint
+		Count        int64
+	}
+	err = global.GVA_DB.Model(&system.SysDrawing{}).
+		Select("ci.collection_id, COUNT(*) AS count").
+		Joins("JOIN sys_drawing_collection_items ci ON ci.drawing_id = sys_drawings.id").
+		Where("ci.collection_id IN ?", ids).
+		Scopes(downloadableDrawingsScope(userUUID)).
+		Group("ci.collection_id").Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	countByID := make(map[uint]int64, len(counts))
+	for _, c := range counts {
+		countByID[c.CollectionID] = c.Count
+	}
+
+	var shares []system.SysDrawingCollectionShare
+	if len(owned) > 0 {
+		if err = global.GVA_DB.Where("collection_id IN ?", ids[:len(owned)]).Preload("User").Order("created_at ASC").Find(&shares).Error; err != nil {
+			return nil, err
+		}
+	}
+	sharedWith := make(map[uint][]systemRes.UserInfo)
+	for _, share := range shares {
+		sharedWith[share.CollectionID] = append(sharedWith[share.CollectionID], systemRes.ToUserInfo(share.User))
+	}
+
+	list := make([]systemRes.DrawingCollectionResponse, 0, len(ids))
+	for _, collection := range owned {
+		users := sharedWith[collection.ID]
+		if users == nil {
+			users = []systemRes.UserInfo{}
+		}
+		list = append(list, systemRes.DrawingCollectionResponse{
+			SysDrawingCollection: collection,
+			DrawingCount:         countByID[collection.ID],
+			SharedWith:           users,
+		})
+	}
+	for _, collection := range shared {
+		list = append(list, systemRes.DrawingCollectionResponse{
+			SysDrawingCollection: collection,
+			DrawingCount:         countByID[collection.ID],
+			Shared:               true,
+			SharedWith:           []systemRes.UserInfo{},
+		})
+	}
+	return list, nil
+}
+
+// GetCollectionDrawings 分页获取收藏夹中当前用户可访问的图纸，按收藏夹内顺序排列
+func (collectionService *DrawingCollectionService) GetCollectionDrawings(req request.GetCollectionDrawings, userUUID uuid.UUID) (list []systemRes.CollectionDrawing, total int64, err error) {
+	if _, err = viewableCollection(req.CollectionID, userUUID); err != nil {
+		return nil, 0, err
+	}
+
+	db := collectionDrawingsQuery(req.CollectionID, userUUID)
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = collectionDefaultPageSize
+	}
+	page := max(req.Page, 1)
+
+	var drawings []*system.SysDrawing
+	err = db.Preload("Album").Preload("Creator").Preload("Renditions").
+		Order(collectionItemOrder).Offset((page - 1) * pageSize).Limit(pageSize).
+		Find(&drawings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, 0, len(drawings))
+	for _, drawing := range drawings {
+		ids = append(ids, drawing.ID)
+	}
+	var items []system.SysDrawingCollectionItem
+	if len(ids) > 0 {
+		if err = global.GVA_DB.Where("collection_id = ? AND drawing_id IN ?", req.CollectionID, ids).Find(&items).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+	itemByDrawing := make(map[uint]system.SysDrawingCollectionItem, len(items))
+	for _, item := range items {
+		itemByDrawing[item.DrawingID] = item
+	}
+
+	list = make([]systemRes.CollectionDrawing, 0, len(drawings))
+	for _, drawing := range drawings {
+		item := itemByDrawing[drawing.ID]
+		list = append(list, systemRes.CollectionDrawing{
+			DrawingResponse: systemRes.ToDrawingResponse(drawing),
+			Position:        item.Position,
+			AddedAt:         item.CreatedAt,
+		})
+	}
+	return list, total, nil
+}
+
+// AddDrawings 将图纸加入收藏夹，只能加入当前用户可访问的图纸，返回新加入的数量
+func (collectionService *DrawingCollectionService) AddDrawings(req request.CollectionDrawings, userUUID uuid.UUID) (int, error) {
+	if err := uniqueIDs(req.DrawingIDs); err != nil {
+		return 0, err
+	}
+	if _, err := ownedCollection(req.CollectionID, userUUID); err != nil {
+		return 0, err
+	}
+	if err := checkDrawingsDownloadable(req.DrawingIDs, userUUID); err != nil {
+		return 0, err
+	}
+	var added int
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		added, err = addCollectionItems(tx, req.CollectionID, req.DrawingIDs)
+		return err
+	})
+	return added, err
+}
+
+// RemoveDrawings 将图纸移出收藏夹，返回实际移除的数量
+func (collectionService *DrawingCollectionService) RemoveDrawings(req request.CollectionDrawings, userUUID uuid.UUID) (int64, error) {
+	if _, err := ownedCollection(req.CollectionID, userUUID); err != nil {
+		return 0, err
+	}
+	result := global.GVA_DB.Where("collection_id = ? AND drawing_id IN ?", req.CollectionID, req.DrawingIDs).Delete(&system.SysDrawingCollectionItem{})
+	return result.RowsAffected, result.Error
+}
+
+// ReorderDrawings 按给定顺序排列收藏夹中的图纸，未包含的图纸保持原相对顺序排在其后
+func (collectionService *DrawingCollectionService) ReorderDrawings(req request.CollectionDrawings, userUUID uuid.UUID) error {
+	if err := uniqueIDs(req.DrawingIDs); err != nil {
+		return err
+	}
+	if _, err := ownedCollection(req.CollectionID, userUUID); err != nil {
+		return err
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var items []system.SysDrawingCollectionItem
+		if err := tx.Table("sys_drawing_collection_items ci").Where("ci.collection_id = ?", req.CollectionID).
+			Order(collectionItemOrder).Find(&items).Error; err != nil {
+			return err
+		}
+		changed, err := reorderCollectionItems(items, req.DrawingIDs)
+		if err != nil {
+			return err
+		}
+		for _, item := range changed {
+			err = tx.Model(&system.SysDrawingCollectionItem{}).
+				Where("collection_id = ? AND drawing_id = ?", item.CollectionID, item.DrawingID).
+				Update("position", item.Position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ShareCollection 将收藏夹只读共享给其他用户并发送站内通知，返回新共享的用户数
+func (collectionService *DrawingCollectionService) ShareCollection(req request.ShareDrawingCollection, userUUID uuid.UUID) (int, error) {
+	collection, err := ownedCollection(req.CollectionID, userUUID)
+	if err != nil {
+		return 0, err
+	}
+	var users []system.SysUser
+	if err = global.GVA_DB.Where("id IN ?", req.UserIDs).Find(&users).Error; err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, errors.New("用户不存在")
+	}
+	var existing []uuid.UUID
+	err = global.GVA_DB.Model(&system.SysDrawingCollectionShare{}).Where("collection_id = ?", collection.ID).Pluck("user_uuid", &existing).Error
+	if err != nil {
+		return 0, err
+	}
+	skip := map[uuid.UUID]bool{userUUID: true}
+	for _, id := range existing {
+		skip[id] = true
+	}
+
+	var shares []system.SysDrawingCollectionShare
+	var recipients []uuid.UUID
+	for _, user := range users {
+		if skip[user.UUID] {
+			continue
+		}
+		skip[user.UUID] = true
+		shares = append(shares, system.SysDrawingCollectionShare{CollectionID: collection.ID, UserUUID: user.UUID})
+		recipients = append(recipients, user.UUID)
+	}
+	if len(shares) == 0 {
+		return 0, nil
+	}
+
+	var owner system.SysUser
+	if err = global.GVA_DB.Select("username", "nick_name").Where("uuid = ?", userUUID).First(&owner).Error; err != nil {
+		return 0, err
+	}
+	ownerName := owner.NickName
+	if ownerName == "" {
+		ownerName = owner.Username
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&shares).Error; err != nil {
+			return err
+		}
+		return createNotifications(tx, recipients, system.SysNotification{
+			Type:    system.NotificationTypeCollectionShare,
+			Title:   "收藏夹共享",
+			Content: fmt.Sprintf("%s 与你共享了收藏夹「%s」", ownerName, collection.Name),
+			RefType: "collection",
+			RefID:   collection.ID,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(shares), nil
+}
+
+// UnshareCollection 取消收藏夹对指定用户的共享，返回实际取消的数量
+func (collectionService *DrawingCollectionService) UnshareCollection(req request.ShareDrawingCollection, userUUID uuid.UUID) (int64, error) {
+	if _, err := ownedCollection(req.CollectionID, userUUID); err != nil {
+		return 0, err
+	}
+	result := global.GVA_DB.
+		Where("collection_id = ? AND user_uuid IN (SELECT uuid FROM sys_users WHERE id IN ?)", req.CollectionID, req.UserIDs).
+		Delete(&system.SysDrawingCollectionShare{})
+	return result.RowsAffected, result.Error
+}
+
+// SetFavorite 将图纸加入或移出当前用户的默认收藏夹，默认收藏夹不存在时自动创建
+func (collectionService *DrawingCollectionService) SetFavorite(req request.SetDrawingFavorite, userUUID uuid.UUID) error {
+	if req.Favorite {
+		if err := checkDrawingsDownloadable([]uint{req.DrawingID}, userUUID); err != nil {
+			return err
+		}
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var collection system.SysDrawingCollection
+		err := tx.Where("owner_uuid = ? AND is_default = ?", userUUID, true).Order("id ASC").First(&collection).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if !req.Favorite {
+				return nil
+			}
+			collection = system.SysDrawingCollection{OwnerUUID: userUUID, Name: collectionDefaultName, IsDefault: true}
+			if err = tx.Create(&collection).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		}
+
+		if !req.Favorite {
+			return tx.Where("collection_id = ? AND drawing_id = ?", collection.ID, req.DrawingID).Delete(&system.SysDrawingCollectionItem{}).Error
+		}
+		_, err = addCollectionItems(tx, collection.ID, []uint{req.DrawingID})
+		return err
+	})
+}
+
+// DownloadCollection 通过批量下载流程下载收藏夹中当前用户可访问的全部图纸，下载额度与历史记录同批量下载
+func (collectionService *DrawingCollectionService) DownloadCollection(req request.DownloadDrawingCollection, userUUID uuid.UUID, client request.DownloadClient) (*systemRes.DownloadResponse, error) {
+	if _, err := viewableCollection(req.CollectionID, userUUID); err != nil {
+		return nil, err
+	}
+	var ids []uint
+	if err := collectionDrawingsQuery(req.CollectionID, userUUID).Order(collectionItemOrder).Pluck("sys_drawings.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("收藏夹中没有可下载的图纸")
+	}
+	return (&DrawingService{}).BatchDownloadDrawings(request.BatchDownloadDrawings{
+		DrawingIDs:    ids,
+		AddWatermark:  req.AddWatermark,
+		WatermarkText: req.WatermarkText,
+	}, userUUID, client)
+}
@@ -0,0 +1,35 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func Test_reorderCollectionItems(t *testing.T) {
+	items := []system.SysDrawingCollectionItem{
+		{DrawingID: 10, Position: 1},
+		{DrawingID: 20, Position: 2},
+		{DrawingID: 30, Position: 3},
+		{DrawingID: 40, Position: 4},
+	}
+
+	changed, err := reorderCollectionItems(items, []uint{30, 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 目标顺序 30, 10, 20, 40，只有 40 位置不变
+	want := map[uint]int{30: 1, 10: 2, 20: 3}
+	if len(changed) != len(want) {
+		t.Fatalf("changed = %+v", changed)
+	}
+	for _, item := range changed {
+		if want[item.DrawingID] != item.Position {
+			t.Fatalf("drawing %d position = %d, want %d", item.DrawingID, item.Position, want[item.DrawingID])
+		}
+	}
+
+	if _, err = reorderCollectionItems(items, []uint{50}); err == nil {
+		t.Fatal("expected error for drawing not in collection")
+	}
+}
@@ -0,0 +1,325 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/upload"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxCompletionPhotoSize 成品照片大小上限
+	maxCompletionPhotoSize = 10 << 20
+	// maxCompletionNoteLength 备注长度上限
+	maxCompletionNoteLength = 500
+)
+
+type DrawingCompletionService struct{}
+
+// canViewDrawing 判断用户能否访问图纸：图纸创建者、允许下载的成员或相册创建者/管理员
+func canViewDrawing(drawing system.SysDrawing, userID uint, userUUID uuid.UUID) (bool, error) {
+	var count int64
+	err := global.GVA_DB.Model(&system.SysDrawing{}).
+		Where("id = ?", drawing.ID).
+		Scopes(accessibleDrawingsScope(userUUID.String())).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	return canManageAlbum(drawing.AlbumID, userID, userUUID)
+}
+
+// visibleCompletionsScope 完成记录可见范围：自己可访问的图纸下已通过审核的、自己的，以及自己管理的相册下的
+func visibleCompletionsScope(viewerID uint, viewerUUID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		accessible, accessibleArgs := accessibleDrawingsCondition(viewerUUID.String())
+		query := "(sys_drawing_completions.status = ? AND EXISTS (SELECT 1 FROM sys_drawings WHERE sys_drawings.id = sys_drawing_completions.drawing_id AND sys_drawings.deleted_at IS NULL AND (" + accessible + ")))" +
+			" OR sys_drawing_completions.user_uuid = ?" +
+			" OR EXISTS (SELECT 1 FROM sys_albums a WHERE a.id = sys_drawing_completions.album_id AND a.creator_uuid = ?)" +
+			" OR EXISTS (SELECT 1 FROM sys_album_admin aa WHERE aa.album_id = sys_drawing_completions.album_id AND aa.user_id = ?)"
+		args := append([]interface{}{system.CompletionStatusApproved}, accessibleArgs...)
+		return db.Where(query, append(args, viewerUUID, viewerUUID, viewerID)...)
+	}
+}
+
+// parseCompletionDate 解析完成日期，为空时取当天
+func parseCompletionDate(value string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return date, errors.New("完成日期格式应为 YYYY-MM-DD")
+	}
+	if date.After(time.Now()) {
+		return date, errors.New("完成日期不能晚于今天")
+	}
+	return date, nil
+}
+
+// validateCompletionPhoto 校验成品照片的大小与格式
+func validateCompletionPhoto(header *multipart.FileHeader) error {
+	if header.Size > maxCompletionPhotoSize {
+		return fmt.Errorf("照片大小不能超过 %dMB", maxCompletionPhotoSize>>20)
+	}
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".jpg", ".jpeg", ".png":
+	default:
+		return errors.New("照片仅支持 jpg、png 格式")
+	}
+	f, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, _, err = image.DecodeConfig(f); err != nil {
+		return errors.New("无法识别的图片文件")
+	}
+	return nil
+}
+
+// CreateCompletion 标记图纸已完成并上传成品照片
+// 相册创建者或管理员提交的记录直接通过审核，其余用户需等待审核
+func (completionService *DrawingCompletionService) CreateCompletion(header *multipart.FileHeader, drawingID uint, note, completedAt string, userID uint, userUUID uuid.UUID) (system.SysDrawingCompletion, error) {
+	var completion system.SysDrawingCompletion
+	if utf8.RuneCountInString(note) > maxCompletionNoteLength {
+		return completion, fmt.Errorf("备注不能超过 %d 个字", maxCompletionNoteLength)
+	}
+	date, err := parseCompletionDate(completedAt)
+	if err != nil {
+		return completion, err
+	}
+	if err = validateCompletionPhoto(header); err != nil {
+		return completion, err
+	}
+
+	var drawing system.SysDrawing
+	if err = global.GVA_DB.First(&drawing, drawingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return completion, errors.New("图纸不存在")
+		}
+		return completion, err
+	}
+	ok, err := canViewDrawing(drawing, userID, userUUID)
+	if err != nil {
+		return completion, err
+	}
+	if !ok {
+		return completion, errors.New("没有该图纸的访问权限")
+	}
+	manager, err := canManageAlbum(drawing.AlbumID, userID, userUUID)
+	if err != nil {
+		return completion, err
+	}
+
+	oss := upload.NewOss()
+	photoURL, photoKey, err := oss.UploadFile(header)
+	if err != nil {
+		return completion, err
+	}
+
+	completion = system.SysDrawingCompletion{
+		DrawingID:   drawing.ID,
+		AlbumID:     drawing.AlbumID,
+		UserUUID:    userUUID,
+		PhotoURL:    photoURL,
+		PhotoKey:    photoKey,
+		Note:        strings.TrimSpace(note),
+		CompletedAt: date,
+		Status:      system.CompletionStatusPending,
+	}
+	if manager {
+		now := time.Now()
+		completion.Status = system.CompletionStatusApproved
+		completion.ModeratorUUID = &userUUID
+		completion.ModeratedAt = &now
+	}
+	if err = global.GVA_DB.Create(&completion).Error; err != nil {
+		if delErr := oss.DeleteFile(photoKey); delErr != nil {
+			global.GVA_LOG.Warn("删除成品照片失败", zap.String("key", photoKey), zap.Error(delErr))
+		}
+		return completion, err
+	}
+	err = global.GVA_DB.Preload("User").Preload("Drawing").First(&completion, completion.ID).Error
+	return completion, err
+}
+
+// UpdateCompletion 修改自己的完成记录
+func (completionService *DrawingCompletionService) UpdateCompletion(req request.UpdateCompletion, userUUID uuid.UUID) error {
+	if utf8.RuneCountInString(req.Note) > maxCompletionNoteLength {
+		return fmt.Errorf("备注不能超过 %d 个字", maxCompletionNoteLength)
+	}
+	date, err := parseCompletionDate(req.CompletedAt)
+	if err != nil {
+		return err
+	}
+	result := global.GVA_DB.Model(&system.SysDrawingCompletion{}).
+		Where("id = ? AND user_uuid = ?", req.ID, userUUID).
+		Updates(map[string]interface{}{
+			"note":         strings.TrimSpace(req.Note),
+			"completed_at": date,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("完成记录不存在")
+	}
+	return nil
+}
+
+// DeleteCompletion 删除完成记录及成品照片，本人或相册创建者/管理员可操作
+func (completionService *DrawingCompletionService) DeleteCompletion(req request.DeleteCompletion, userID uint, userUUID uuid.UUID) error {
+	var completion system.SysDrawingCompletion
+	if err := global.GVA_DB.First(&completion, req.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("完成记录不存在")
+		}
+		return err
+	}
+	if completion.UserUUID != userUUID {
+		ok, err := canManageAlbum(completion.AlbumID, userID, userUUID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("没有权限删除该完成记录")
+		}
+	}
+
+	if err := global.GVA_DB.Unscoped().Delete(&completion).Error; err != nil {
+		return err
+	}
+	if completion.PhotoKey != "" {
+		if err := upload.NewOss().DeleteFile(completion.PhotoKey); err != nil {
+			global.GVA_LOG.Warn("删除成品照片失败", zap.String("key", completion.PhotoKey), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// ModerateCompletions 审核完成记录，仅相册创建者或管理员可操作
+func (completionService *DrawingCompletionService) ModerateCompletions(req request.ModerateCompletion, userID uint, userUUID uuid.UUID) error {
+	switch req.Status {
+	case system.CompletionStatusPending, system.CompletionStatusApproved, system.CompletionStatusHidden:
+	default:
+		return errors.New("不支持的审核状态")
+	}
+
+	var completions []system.SysDrawingCompletion
+	if err := global.GVA_DB.Where("id IN ?", req.IDs).Find(&completions).Error; err != nil {
+		return err
+	}
+	if len(completions) == 0 {
+		return errors.New("完成记录不存在")
+	}
+
+	checked := make(map[uint]bool)
+	for _, completion := range completions {
+		if checked[completion.AlbumID] {
+			continue
+		}
+		ok, err := canManageAlbum(completion.AlbumID, userID, userUUID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("仅相册创建者或管理员可以审核")
+		}
+		checked[completion.AlbumID] = true
+	}
+
+	ids := make([]uint, 0, len(completions))
+	for _, completion := range completions {
+		ids = append(ids, completion.ID)
+	}
+	return global.GVA_DB.Model(&system.SysDrawingCompletion{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"status":         req.Status,
+		"moderator_uuid": userUUID,
+		"moderated_at":   time.Now(),
+	}).Error
+}
+
+// GetCompletionGallery 获取图纸成品墙
+// 相册创建者或管理员可以看到全部记录并按状态筛选，其余用户需能访问该图纸，且只能看到已通过的和自己的记录
+func (completionService *DrawingCompletionService) GetCompletionGallery(req request.GetCompletionGallery, userID uint, userUUID uuid.UUID) (list []system.SysDrawingCompletion, total int64, err error) {
+	var drawing system.SysDrawing
+	if err = global.GVA_DB.First(&drawing, req.DrawingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errors.New("图纸不存在")
+		}
+		return nil, 0, err
+	}
+	manager, err := canManageAlbum(drawing.AlbumID, userID, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !manager {
+		ok, err := canViewDrawing(drawing, userID, userUUID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			return nil, 0, errors.New("图纸不存在")
+		}
+	}
+
+	db := global.GVA_DB.Model(&system.SysDrawingCompletion{}).Where("drawing_id = ?", drawing.ID)
+	if manager {
+		if req.Status != "" {
+			db = db.Where("status = ?", req.Status)
+		}
+	} else {
+		db = db.Where("status = ? OR user_uuid = ?", system.CompletionStatusApproved, userUUID)
+	}
+
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Preload("User").Preload("Drawing").Order("completed_at DESC, id DESC").Find(&list).Error
+	return list, total, err
+}
+
+// GetMyCompletions 获取当前用户的完成记录
+func (completionService *DrawingCompletionService) GetMyCompletions(req request.GetMyCompletions, userUUID uuid.UUID) (list []system.SysDrawingCompletion, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysDrawingCompletion{}).Where("user_uuid = ?", userUUID)
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Preload("User").Preload("Drawing").Order("completed_at DESC, id DESC").Find(&list).Error
+	return list, total, err
+}
+
+// GetUserCompletions 获取指定用户的完成记录（用户详情页），按查看者身份过滤可见范围
+func (completionService *DrawingCompletionService) GetUserCompletions(targetUUID uuid.UUID, viewerID uint, viewerUUID uuid.UUID) ([]system.SysDrawingCompletion, error) {
+	var list []system.SysDrawingCompletion
+	err := global.GVA_DB.Model(&system.SysDrawingCompletion{}).
+		Where("user_uuid = ?", targetUUID).
+		Scopes(visibleCompletionsScope(viewerID, viewerUUID)).
+		Preload("User").Preload("Drawing").
+		Order("completed_at DESC, id DESC").
+		Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,363 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/imagehash"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/upload"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// duplicateHashDistance 创建图纸时判定为疑似重复的最大汉明距离
+	duplicateHashDistance = 6
+	// similarHashDistance 以图搜图默认的最大汉明距离
+	similarHashDistance = 12
+	// duplicateWarnLimit 创建图纸时最多提示的疑似重复图纸数
+	duplicateWarnLimit = 10
+)
+
+// isImageURL 根据扩展名判断是否为可解码的图片
+func isImageURL(u string) bool {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	switch strings.ToLower(filepath.Ext(u)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".bmp", ".tif", ".tiff":
+		return true
+	}
+	return false
+}
+
+// resolveUploadPath 将图纸中保存的URL转换为本地文件路径（与下载逻辑保持一致）
+func resolveUploadPath(u string) string {
+	u = strings.TrimPrefix(u, "/")
+	if strings.HasPrefix(u, "uploads/") {
+		return u
+	}
+	return filepath.Join("uploads", u)
+}
+
+// storageObjectKey 将对象存储的访问地址还原为存储 key，不属于当前配置存储的地址返回 false
+func storageObjectKey(u string) (string, bool) {
+	base := upload.ObjectURL("")
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		return "", false
+	}
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	key := strings.TrimPrefix(u, base)
+	if key == u || key == "" {
+		return "", false
+	}
+	return key, true
+}
+
+// openDrawingImage 打开图纸图片，只读取本系统上传存储中的文件，不请求任意外部地址
+func openDrawingImage(u string) (io.ReadCloser, error) {
+	if strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://") {
+		key, ok := storageObjectKey(u)
+		if !ok {
+			return nil, errors.New("图片不在本系统存储中")
+		}
+		return upload.OpenFile(key)
+	}
+	if strings.Contains(u, "..") {
+		return nil, errors.New("非法的图片路径")
+	}
+	return os.Open(resolveUploadPath(u))
+}
+
+// hashImageURL 计算单张图片的差值哈希
+func hashImageURL(u string) (uint64, error) {
+	f, err := openDrawingImage(u)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return imagehash.DHashReader(f)
+}
+
+// computeDrawingHashes 计算图纸海报与图纸文件中所有图片的感知哈希，无法读取的图片仅记录日志
+// 没有可计算的图片时返回一条无哈希的标记记录，避免补齐任务反复处理同一图纸
+func computeDrawingHashes(posterURL string, drawingURLs []string) []system.SysDrawingImageHash {
+	type source struct {
+		kind string
+		url  string
+	}
+	sources := []source{{system.DrawingImageKindPoster, posterURL}}
+	for _, u := range drawingURLs {
+		sources = append(sources, source{system.DrawingImageKindPattern, u})
+	}
+
+	hashes := make([]system.SysDrawingImageHash, 0, len(sources))
+	for _, src := range sources {
+		if src.url == "" || !isImageURL(src.url) {
+			continue
+		}
+		hash, err := hashImageURL(src.url)
+		if err != nil {
+			global.GVA_LOG.Warn("计算图片哈希失败", zap.String("url", src.url), zap.Error(err))
+			continue
+		}
+		hashes = append(hashes, system.SysDrawingImageHash{
+			Kind:     src.kind,
+			ImageURL: src.url,
+			Hash:     imagehash.Format(hash),
+		})
+	}
+	if len(hashes) == 0 {
+		hashes = append(hashes, system.SysDrawingImageHash{Kind: system.DrawingImageKindNone})
+	}
+	return hashes
+}
+
+// replaceDrawingHashes 整体替换图纸的图片哈希
+func replaceDrawingHashes(tx *gorm.DB, drawingID uint, hashes []system.SysDrawingImageHash) error {
+	if err := tx.Where("drawing_id = ?", drawingID).Delete(&system.SysDrawingImageHash{}).Error; err != nil {
+		return err
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	for i := range hashes {
+		hashes[i].ID = 0
+		hashes[i].DrawingID = drawingID
+	}
+	return tx.Create(&hashes).Error
+}
+
+// findSimilarDrawings 按汉明距离查找相似图纸
+// scope 为空时在全部图纸中查找；excludeID 用于排除图纸自身
+func findSimilarDrawings(targets []uint64, scope func(db *gorm.DB) *gorm.DB, excludeID uint, maxDistance, limit int) ([]systemRes.SimilarDrawing, error) {
+	if len(targets) == 0 {
+		return []systemRes.SimilarDrawing{}, nil
+	}
+
+	db := global.GVA_DB.Table("sys_drawing_image_hashes").
+		Select("sys_drawing_image_hashes.drawing_id, sys_drawing_image_hashes.hash").
+		Joins("JOIN sys_drawings ON sys_drawings.id = sys_drawing_image_hashes.drawing_id AND sys_drawings.deleted_at IS NULL").
+		Where("sys_drawing_image_hashes.hash <> ''")
+	if scope != nil {
+		db = db.Scopes(scope)
+	}
+	if excludeID != 0 {
+		db = db.Where("sys_drawing_image_hashes.drawing_id <> ?", excludeID)
+	}
+	rows, err := db.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// 逐行读取哈希，每张图纸取最相似的一张图片的距离，避免一次性加载全部记录
+	best := make(map[uint]int)
+	for rows.Next() {
+		var drawingID uint
+		var value string
+		if err := rows.Scan(&drawingID, &value); err != nil {
+			return nil, err
+		}
+		hash, err := imagehash.Parse(value)
+		if err != nil {
+			continue
+		}
+		for _, target := range targets {
+			d := imagehash.Distance(hash, target)
+			if d > maxDistance {
+				continue
+			}
+			if cur, ok := best[drawingID]; !ok || d < cur {
+				best[drawingID] = d
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if len(best) == 0 {
+		return []systemRes.SimilarDrawing{}, nil
+	}
+
+	ids := make([]uint, 0, len(best))
+	for id := range best {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if best[ids[i]] != best[ids[j]] {
+			return best[ids[i]] < best[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	var drawings []system.SysDrawing
+	if err := global.GVA_DB.Preload("Album").Where("id IN ?", ids).Find(&drawings).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]system.SysDrawing, len(drawings))
+	for _, drawing := range drawings {
+		byID[drawing.ID] = drawing
+	}
+
+	results := make([]systemRes.SimilarDrawing, 0, len(ids))
+	for _, id := range ids {
+		drawing, ok := byID[id]
+		if !ok {
+			continue
+		}
+		results = append(results, systemRes.SimilarDrawing{
+			DrawingID:      drawing.ID,
+			AlbumID:        drawing.AlbumID,
+			AlbumTitle:     drawing.Album.Title,
+			SerialNumber:   drawing.SerialNumber,
+			Name:           drawing.Name,
+			PosterImageURL: drawing.PosterImageURL,
+			Distance:       best[id],
+		})
+	}
+	return results, nil
+}
+
+// refreshDrawingHashes 重新计算并保存图纸图片哈希
+// 计算期间图片已被再次修改时放弃保存，由后续的计算任务写入
+func refreshDrawingHashes(drawing system.SysDrawing) ([]system.SysDrawingImageHash, bool, error) {
+	var drawingURLs []string
+	if drawing.DrawingURLs != "" {
+		_ = json.Unmarshal([]byte(drawing.DrawingURLs), &drawingURLs)
+	}
+	hashes := computeDrawingHashes(drawing.PosterImageURL, drawingURLs)
+
+	saved := false
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var current system.SysDrawing
+		if err := tx.Select("id, poster_image_url, drawing_urls").First(&current, drawing.ID).Error; err != nil {
+			return err
+		}
+		if current.PosterImageURL != drawing.PosterImageURL || current.DrawingURLs != drawing.DrawingURLs {
+			return nil
+		}
+		saved = true
+		return replaceDrawingHashes(tx, drawing.ID, hashes)
+	})
+	return hashes, saved, err
+}
+
+// checkDuplicateDrawings 计算新建图纸的图片哈希，并返回创建者可查看范围内的疑似重复图纸
+// 只读取本系统存储中的图片；计算或查询失败仅记录日志，不影响创建
+func checkDuplicateDrawings(drawing system.SysDrawing) []systemRes.SimilarDrawing {
+	defer recoverDrawingHash()
+	hashes, _, err := refreshDrawingHashes(drawing)
+	if err != nil {
+		global.GVA_LOG.Warn("保存图片哈希失败", zap.Uint("drawing_id", drawing.ID), zap.Error(err))
+		return nil
+	}
+	duplicates, err := findSimilarDrawings(parseHashes(hashes), visibleDrawingsScope(drawing.CreatorUUID), drawing.ID, duplicateHashDistance, duplicateWarnLimit)
+	if err != nil {
+		global.GVA_LOG.Warn("检查重复图纸失败", zap.Uint("drawing_id", drawing.ID), zap.Error(err))
+		return nil
+	}
+	return duplicates
+}
+
+// QueueDrawingHashes 异步重新计算图纸图片哈希，不阻塞保存请求
+func (drawingService *DrawingService) QueueDrawingHashes(drawingID uint) {
+	go func() {
+		defer recoverDrawingHash()
+		var drawing system.SysDrawing
+		if err := global.GVA_DB.First(&drawing, drawingID).Error; err != nil {
+			return
+		}
+		if _, _, err := refreshDrawingHashes(drawing); err != nil {
+			global.GVA_LOG.Warn("保存图片哈希失败", zap.Uint("drawing_id", drawingID), zap.Error(err))
+		}
+	}()
+}
+
+// BackfillDrawingHashes 为尚无图片哈希记录的历史图纸补齐哈希，按批次扫描，返回处理的图纸数
+// 没有可计算图片的图纸会写入标记记录，下次扫描不再重复处理
+func (drawingService *DrawingService) BackfillDrawingHashes(batchSize int) (processed int, err error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	var lastID uint
+	for {
+		var drawings []system.SysDrawing
+		err = global.GVA_DB.
+			Where("id > ?", lastID).
+			Where("NOT EXISTS (SELECT 1 FROM sys_drawing_image_hashes WHERE sys_drawing_image_hashes.drawing_id = sys_drawings.id)").
+			Order("id").Limit(batchSize).Find(&drawings).Error
+		if err != nil {
+			return processed, err
+		}
+		for _, drawing := range drawings {
+			if _, _, err := refreshDrawingHashes(drawing); err != nil {
+				global.GVA_LOG.Warn("补齐图片哈希失败", zap.Uint("drawing_id", drawing.ID), zap.Error(err))
+			}
+			lastID = drawing.ID
+			processed++
+		}
+		if len(drawings) < batchSize {
+			break
+		}
+	}
+	return processed, nil
+}
+
+// recoverDrawingHash 防止图片哈希计算中的 panic 影响主进程
+func recoverDrawingHash() {
+	if r := recover(); r != nil {
+		global.GVA_LOG.Error("计算图片哈希异常", zap.Any("panic", r), zap.String("stack", string(debug.Stack())))
+	}
+}
+
+// parseHashes 将哈希记录转换为数值
+func parseHashes(records []system.SysDrawingImageHash) []uint64 {
+	hashes := make([]uint64, 0, len(records))
+	for _, record := range records {
+		if hash, err := imagehash.Parse(record.Hash); err == nil {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// SearchSimilarDrawings 以图搜图：返回当前用户可访问的最相似图纸
+func (drawingService *DrawingService) SearchSimilarDrawings(header *multipart.FileHeader, userUUID uuid.UUID, maxDistance, limit int) ([]systemRes.SimilarDrawing, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hash, err := imagehash.DHashReader(file)
+	if err != nil {
+		return nil, errors.New("无法识别的图片格式")
+	}
+
+	if maxDistance <= 0 || maxDistance > 64 {
+		maxDistance = similarHashDistance
+	}
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+	return findSimilarDrawings([]uint64{hash}, accessibleDrawingsScope(userUUID.String()), 0, maxDistance, limit)
+}
@@ -0,0 +1,17 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func Test_computeDrawingHashesMarker(t *testing.T) {
+	hashes := computeDrawingHashes("", []string{"uploads/file/pattern.pdf"})
+	if len(hashes) != 1 || hashes[0].Kind != system.DrawingImageKindNone || hashes[0].Hash != "" {
+		t.Fatalf("computeDrawingHashes() = %+v, want a single marker record", hashes)
+	}
+	if got := parseHashes(hashes); len(got) != 0 {
+		t.Fatalf("parseHashes(marker) = %v, want none", got)
+	}
+}
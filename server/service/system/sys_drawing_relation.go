@@ -0,0 +1,197 @@
+package system
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 关联推荐以“用户下载过的图纸集合”为篮子统计图纸两两共现次数，
+// 按余弦相似度 共现人数 / sqrt(下载A人数 * 下载B人数) 为每张图纸保留前 K 个关联图纸
+type DrawingRelationService struct{}
+
+const (
+	relationTopK        = 20  // 每张图纸保留的关联图纸数
+	relationMinSupport  = 2   // 至少有多少用户同时下载过才视为关联
+	relationMaxBasket   = 500 // 下载图纸数超过该值的用户（批量抓取或管理员）不参与统计
+	relationInsertBatch = 500
+	relatedDefaultLimit = 10
+)
+
+// downloadPairsSQL 用户与其下载过的图纸（去重，含已压缩到汇总表的下载记录，不含前端上报的下载点击），按用户排序便于逐个组装篮子
+const downloadPairsSQL = "SELECT p.user_uuid, p.drawing_id FROM (" +
+	"SELECT user_uuid, drawing_id FROM sys_download_histories WHERE deleted_at IS NULL AND channel <> '" + system.DownloadChannelClient + "'" +
+	" UNION SELECT user_uuid, drawing_id FROM sys_download_summaries" +
+	") p ORDER BY p.user_uuid"
+
+// drawingCooccurrence 图纸下载人数与两两共现人数
+type drawingCooccurrence struct {
+	counts map[uint]int64
+	pairs  map[uint]map[uint]int64
+}
+
+func newDrawingCooccurrence() *drawingCooccurrence {
+	return &drawingCooccurrence{counts: make(map[uint]int64), pairs: make(map[uint]map[uint]int64)}
+}
+
+// add 计入一个用户下载过的图纸集合（不含重复ID）
+func (c *drawingCooccurrence) add(basket []uint) {
+	if len(basket) == 0 || len(basket) > relationMaxBasket {
+		return
+	}
+	for _, a := range basket {
+		c.counts[a]++
+	}
+	for i, a := range basket {
+		for _, b := range basket[i+1:] {
+			c.inc(a, b)
+			c.inc(b, a)
+		}
+	}
+}
+
+func (c *drawingCooccurrence) inc(a, b uint) {
+	related, ok := c.pairs[a]
+	if !ok {
+		related = make(map[uint]int64)
+		c.pairs[a] = related
+	}
+	related[b]++
+}
+
+// relations 按相似度为每张图纸取前 topK 个关联图纸
+func (c *drawingCooccurrence) relations(topK int, minSupport int64) []system.SysDrawingRelation {
+	result := make([]system.SysDrawingRelation, 0)
+	for drawingID, related := range c.pairs {
+		candidates := make([]system.SysDrawingRelation, 0, len(related))
+		for relatedID, co := range related {
+			if co < minSupport {
+				continue
+			}
+			candidates = append(candidates, system.SysDrawingRelation{
+				DrawingID:   drawingID,
+				RelatedID:   relatedID,
+				Score:       float64(co) / math.Sqrt(float64(c.counts[drawingID]*c.counts[relatedID])),
+				CoDownloads: co,
+			})
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Score != candidates[j].Score {
+				return candidates[i].Score > candidates[j].Score
+			}
+			if candidates[i].CoDownloads != candidates[j].CoDownloads {
+				return candidates[i].CoDownloads > candidates[j].CoDownloads
+			}
+			return candidates[i].RelatedID < candidates[j].RelatedID
+		})
+		if len(candidates) > topK {
+			candidates = candidates[:topK]
+		}
+		for i := range candidates {
+			candidates[i].Position = i + 1
+		}
+		result = append(result, candidates...)
+	}
+	return result
+}
+
+// RebuildRelations 根据全部下载记录重新计算图纸关联推荐，返回关联条数
+func (relationService *DrawingRelationService) RebuildRelations() (int, error) {
+	rows, err := global.GVA_DB.Raw(downloadPairsSQL).Rows()
+	if err != nil {
+		return 0, err
+	}
+	co := newDrawingCooccurrence()
+	var current uuid.UUID
+	var basket []uint
+	for rows.Next() {
+		var userUUID uuid.UUID
+		var drawingID uint
+		if err = rows.Scan(&userUUID, &drawingID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if userUUID != current {
+			co.add(basket)
+			current, basket = userUUID, basket[:0]
+		}
+		basket = append(basket, drawingID)
+	}
+	co.add(basket)
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return 0, err
+	}
+	rows.Close()
+
+	relations := co.relations(relationTopK, relationMinSupport)
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&system.SysDrawingRelation{}).Error; err != nil {
+			return err
+		}
+		if len(relations) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(relations, relationInsertBatch).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(relations), nil
+}
+
+// GetRelatedDrawings 获取下载过该图纸的成员也下载了的图纸，只返回当前用户可访问的图纸
+func (relationService *DrawingRelationService) GetRelatedDrawings(req request.GetRelatedDrawings, userUUID uuid.UUID) ([]systemRes.RelatedDrawing, error) {
+	var count int64
+	err := global.GVA_DB.Model(&system.SysDrawing{}).Where("sys_drawings.id = ?", req.DrawingID).
+		Scopes(visibleDrawingsScope(userUUID)).Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.New("图纸不存在或没有访问权限")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = relatedDefaultLimit
+	}
+	if limit > relationTopK {
+		limit = relationTopK
+	}
+	var drawings []*system.SysDrawing
+	err = global.GVA_DB.Model(&system.SysDrawing{}).
+		Joins("JOIN sys_drawing_relations r ON r.related_id = sys_drawings.id AND r.drawing_id = ?", req.DrawingID).
+		Scopes(visibleDrawingsScope(userUUID)).
+		Preload("Album").Preload("Creator").Preload("Renditions").
+		Order("r.position ASC").Limit(limit).Find(&drawings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []system.SysDrawingRelation
+	if err = global.GVA_DB.Where("drawing_id = ?", req.DrawingID).Find(&relations).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]system.SysDrawingRelation, len(relations))
+	for _, relation := range relations {
+		byID[relation.RelatedID] = relation
+	}
+	list := make([]systemRes.RelatedDrawing, 0, len(drawings))
+	for _, drawing := range drawings {
+		relation := byID[drawing.ID]
+		list = append(list, systemRes.RelatedDrawing{
+			DrawingResponse: systemRes.ToDrawingResponse(drawing),
+			Score:           relation.Score,
+			CoDownloads:     relation.CoDownloads,
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,33 @@
+package system
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_drawingCooccurrence(t *testing.T) {
+	co := newDrawingCooccurrence()
+	co.add([]uint{1, 2, 3})
+	co.add([]uint{1, 2})
+	co.add([]uint{1, 3, 4})
+	co.add([]uint{2, 4})
+
+	relations := co.relations(1, 2)
+	related := make(map[uint]uint)
+	for _, r := range relations {
+		if r.Position != 1 {
+			t.Fatalf("unexpected position %+v", r)
+		}
+		related[r.DrawingID] = r.RelatedID
+		if r.DrawingID == 1 && (r.CoDownloads != 2 || math.Abs(r.Score-2/math.Sqrt(6)) > 1e-9) {
+			t.Fatalf("relation for drawing 1 = %+v", r)
+		}
+	}
+	// 1-2 共现 2 次、1-3 共现 2 次，相似度 1-3 更高（3 只被下载 2 次）
+	if related[1] != 3 || related[3] != 1 {
+		t.Fatalf("relations = %+v", relations)
+	}
+	if _, ok := related[4]; ok {
+		t.Fatalf("drawing 4 should have no relation below min support: %+v", relations)
+	}
+}
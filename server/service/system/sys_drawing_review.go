@@ -0,0 +1,338 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 图纸审核由相册审核人（相册创建者、管理员或编辑及以上角色的成员）处理，图纸创建者可提交自己的草稿
+type DrawingReviewService struct{}
+
+// drawingStatusTransition 一次状态变更
+type drawingStatusTransition struct {
+	from      []string   // 允许的原状态
+	to        string     // 新状态
+	publishAt *time.Time // 发布时间，为空时不修改
+	action    string     // 审核操作
+	comment   string     // 审核意见
+}
+
+// publishStatus 审核通过后的状态：定时发布时间在未来则等待发布，否则立即发布
+func publishStatus(publishAt *time.Time, now time.Time) (string, *time.Time) {
+	if publishAt != nil && publishAt.After(now) {
+		t := *publishAt
+		return system.DrawingStatusScheduled, &t
+	}
+	return system.DrawingStatusPublished, &now
+}
+
+// initialDrawingStatus 确定新建图纸的发布状态，直接发布需当前用户（userID/userUUID 取自登录令牌）具有相册审核权限
+// 未指定状态时沿用原有的直接发布行为：有审核权限的直接发布，否则提交审核
+func initialDrawingStatus(req request.CreateDrawing, userID uint, userUUID uuid.UUID) (string, *time.Time, error) {
+	switch req.Status {
+	case "":
+		ok, err := canEditAlbum(req.AlbumID, userID, userUUID)
+		if err != nil {
+			return "", nil, err
+		}
+		if !ok {
+			return system.DrawingStatusInReview, nil, nil
+		}
+		status, publishAt := publishStatus(req.PublishAt, time.Now())
+		return status, publishAt, nil
+	case system.DrawingStatusDraft:
+		return system.DrawingStatusDraft, nil, nil
+	case system.DrawingStatusInReview:
+		return system.DrawingStatusInReview, nil, nil
+	case system.DrawingStatusPublished:
+		ok, err := canEditAlbum(req.AlbumID, userID, userUUID)
+		if err != nil {
+			return "", nil, err
+		}
+		if !ok {
+			return "", nil, errors.New("没有直接发布图纸的权限，请提交审核")
+		}
+		status, publishAt := publishStatus(req.PublishAt, time.Now())
+		return status, publishAt, nil
+	default:
+		return "", nil, errors.New("不支持的图纸状态")
+	}
+}
+
+// loadReviewDrawings 加载图纸并校验状态与审核权限，allowCreator 为 true 时图纸创建者也可操作
+func loadReviewDrawings(drawingIDs []uint, from []string, allowCreator bool, userID uint, userUUID uuid.UUID) ([]system.SysDrawing, error) {
+	var drawings []system.SysDrawing
+	err := global.GVA_DB.Select("id", "album_id", "serial_number", "name", "creator_uuid", "status").
+		Where("id IN ?", drawingIDs).Order("id ASC").Find(&drawings).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(drawings) == 0 {
+		return nil, errors.New("图纸不存在")
+	}
+	reviewable := make(map[uint]bool)
+	for _, drawing := range drawings {
+		allowed := false
+		for _, status := range from {
+			allowed = allowed || drawing.Status == status
+		}
+		if !allowed {
+			return nil, fmt.Errorf("图纸 %s 当前状态为 %s，不能执行该操作", drawing.SerialNumber, drawing.Status)
+		}
+		if allowCreator && drawing.CreatorUUID == userUUID {
+			continue
+		}
+		ok, checked := reviewable[drawing.AlbumID]
+		if !checked {
+			if ok, err = canEditAlbum(drawing.AlbumID, userID, userUUID); err != nil {
+				return nil, err
+			}
+			reviewable[drawing.AlbumID] = ok
+		}
+		if !ok {
+			return nil, fmt.Errorf("没有审核图纸 %s 的权限", drawing.SerialNumber)
+		}
+	}
+	return drawings, nil
+}
+
+// applyDrawingTransition 在事务中以原状态为条件更新图纸状态并写入审核记录，避免并发重复处理
+func applyDrawingTransition(tx *gorm.DB, drawing system.SysDrawing, t drawingStatusTransition, operator uuid.UUID) error {
+	updates := map[string]interface{}{"status": t.to}
+	if t.publishAt != nil {
+		updates["publish_at"] = *t.publishAt
+	}
+	result := tx.Model(&system.SysDrawing{}).Where("id = ? AND status IN ?", drawing.ID, t.from).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("图纸 %s 状态已变化，请刷新后重试", drawing.SerialNumber)
+	}
+	return tx.Create(&system.SysDrawingReview{
+		DrawingID:    drawing.ID,
+		OperatorUUID: operator,
+		Action:       t.action,
+		FromStatus:   drawing.Status,
+		ToStatus:     t.to,
+		Comment:      t.comment,
+	}).Error
+}
+
+// notifyDrawingReview 通知图纸创建者审核结果（操作人为创建者本人时不通知）
+func notifyDrawingReview(tx *gorm.DB, drawing system.SysDrawing, operator uuid.UUID, title string, verdict string, comment string) error {
+	if drawing.CreatorUUID == operator {
+		return nil
+	}
+	content := "你的图纸 " + drawing.SerialNumber + " " + drawing.Name + verdict
+	if comment != "" {
+		content += "：" + comment
+	}
+	return createNotifications(tx, []uuid.UUID{drawing.CreatorUUID}, system.SysNotification{
+		Type:    system.NotificationTypeDrawingReview,
+		Title:   title,
+		Content: content,
+		RefType: "drawing",
+		RefID:   drawing.ID,
+	})
+}
+
+// normalizeReviewComment 整理审核意见并校验长度
+func normalizeReviewComment(comment string) (string, error) {
+	comment = strings.TrimSpace(comment)
+	if utf8.RuneCountInString(comment) > 500 {
+		return "", errors.New("审核意见不能超过500个字")
+	}
+	return comment, nil
+}
+
+// SubmitDrawings 将草稿提交审核（图纸创建者或相册审核人）
+func (reviewService *DrawingReviewService) SubmitDrawings(req request.SubmitDrawings, userID uint, userUUID uuid.UUID) error {
+	comment, err := normalizeReviewComment(req.Comment)
+	if err != nil {
+		return err
+	}
+	drawings, err := loadReviewDrawings(req.DrawingIDs, []string{system.DrawingStatusDraft}, true, userID, userUUID)
+	if err != nil {
+		return err
+	}
+	t := drawingStatusTransition{
+		from:    []string{system.DrawingStatusDraft},
+		to:      system.DrawingStatusInReview,
+		action:  system.DrawingReviewSubmit,
+		comment: comment,
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, drawing := range drawings {
+			if err := applyDrawingTransition(tx, drawing, t, userUUID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReviewDrawings 审核待审核的图纸：通过后立即或定时发布，驳回后退回草稿，结果通知图纸创建者
+func (reviewService *DrawingReviewService) ReviewDrawings(req request.ReviewDrawings, userID uint, userUUID uuid.UUID) error {
+	comment, err := normalizeReviewComment(req.Comment)
+	if err != nil {
+		return err
+	}
+	if !req.Approve && comment == "" {
+		return errors.New("驳回时请填写审核意见")
+	}
+	drawings, err := loadReviewDrawings(req.DrawingIDs, []string{system.DrawingStatusInReview}, false, userID, userUUID)
+	if err != nil {
+		return err
+	}
+
+	t := drawingStatusTransition{
+		from:    []string{system.DrawingStatusInReview},
+		to:      system.DrawingStatusDraft,
+		action:  system.DrawingReviewReject,
+		comment: comment,
+	}
+	title, verdict := "图纸审核未通过", "未通过审核"
+	if req.Approve {
+		t.to, t.publishAt = publishStatus(req.PublishAt, time.Now())
+		t.action = system.DrawingReviewApprove
+		title, verdict = "图纸审核通过", "已通过审核并发布"
+		if t.to == system.DrawingStatusScheduled {
+			verdict = "已通过审核，将于 " + t.publishAt.Format("2006-01-02 15:04") + " 发布"
+		}
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, drawing := range drawings {
+			if err := applyDrawingTransition(tx, drawing, t, userUUID); err != nil {
+				return err
+			}
+			if err := notifyDrawingReview(tx, drawing, userUUID, title, verdict, comment); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ArchiveDrawings 归档已发布或等待发布的图纸，或将已归档的图纸重新发布（相册审核人）
+func (reviewService *DrawingReviewService) ArchiveDrawings(req request.ArchiveDrawings, userID uint, userUUID uuid.UUID) error {
+	comment, err := normalizeReviewComment(req.Comment)
+	if err != nil {
+		return err
+	}
+	t := drawingStatusTransition{
+		from:    []string{system.DrawingStatusPublished, system.DrawingStatusScheduled},
+		to:      system.DrawingStatusArchived,
+		action:  system.DrawingReviewArchive,
+		comment: comment,
+	}
+	if !req.Archive {
+		t.from = []string{system.DrawingStatusArchived}
+		t.to = system.DrawingStatusPublished
+		t.action = system.DrawingReviewUnarchive
+	}
+	drawings, err := loadReviewDrawings(req.DrawingIDs, t.from, false, userID, userUUID)
+	if err != nil {
+		return err
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, drawing := range drawings {
+			if err := applyDrawingTransition(tx, drawing, t, userUUID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetReviewQueue 获取相册中指定状态的图纸（默认待审核，相册审核人）
+func (reviewService *DrawingReviewService) GetReviewQueue(req request.GetDrawingReviewQueue, userID uint, userUUID uuid.UUID) ([]*system.SysDrawing, int64, error) {
+	ok, err := canEditAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return nil, 0, errors.New("没有审核该相册图纸的权限")
+	}
+	status := req.Status
+	if status == "" {
+		status = system.DrawingStatusInReview
+	}
+
+	var drawings []*system.SysDrawing
+	var total int64
+	db := global.GVA_DB.Model(&system.SysDrawing{}).Where("album_id = ? AND status = ?", req.AlbumID, status)
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Preload("Album").Preload("Creator").Preload("Renditions").Preload("FieldValues.Field").
+		Order("updated_at ASC").Find(&drawings).Error
+	return drawings, total, err
+}
+
+// GetReviews 获取图纸的审核记录（图纸创建者或相册审核人）
+func (reviewService *DrawingReviewService) GetReviews(req request.GetDrawingReviews, userID uint, userUUID uuid.UUID) ([]system.SysDrawingReview, error) {
+	var drawing system.SysDrawing
+	if err := global.GVA_DB.Select("id", "album_id", "creator_uuid").First(&drawing, req.DrawingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("图纸不存在")
+		}
+		return nil, err
+	}
+	if drawing.CreatorUUID != userUUID {
+		ok, err := canEditAlbum(drawing.AlbumID, userID, userUUID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("没有查看审核记录的权限")
+		}
+	}
+	var reviews []system.SysDrawingReview
+	err := global.GVA_DB.Where("drawing_id = ?", req.DrawingID).
+		Preload("Operator", func(db *gorm.DB) *gorm.DB { return db.Select("id", "uuid", "username", "nick_name") }).
+		Order("id DESC").Find(&reviews).Error
+	return reviews, err
+}
+
+// PublishScheduled 发布已到定时发布时间的图纸，返回发布数量
+func (reviewService *DrawingReviewService) PublishScheduled() (int, error) {
+	var drawings []system.SysDrawing
+	err := global.GVA_DB.Select("id", "album_id", "serial_number", "name", "creator_uuid", "status").
+		Where("status = ? AND publish_at <= ?", system.DrawingStatusScheduled, time.Now()).
+		Find(&drawings).Error
+	if err != nil {
+		return 0, err
+	}
+	t := drawingStatusTransition{
+		from:   []string{system.DrawingStatusScheduled},
+		to:     system.DrawingStatusPublished,
+		action: system.DrawingReviewPublish,
+	}
+	published := 0
+	for _, drawing := range drawings {
+		err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+			if err := applyDrawingTransition(tx, drawing, t, uuid.Nil); err != nil {
+				return err
+			}
+			return notifyDrawingReview(tx, drawing, uuid.Nil, "图纸已发布", "已按计划发布", "")
+		})
+		if err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
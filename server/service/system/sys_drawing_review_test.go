@@ -0,0 +1,30 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func Test_publishStatus(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+	tests := []struct {
+		name      string
+		publishAt *time.Time
+		want      string
+		wantAt    time.Time
+	}{
+		{name: "立即发布", publishAt: nil, want: system.DrawingStatusPublished, wantAt: now},
+		{name: "时间已过", publishAt: &past, want: system.DrawingStatusPublished, wantAt: now},
+		{name: "定时发布", publishAt: &future, want: system.DrawingStatusScheduled, wantAt: future},
+	}
+	for _, tt := range tests {
+		status, at := publishStatus(tt.publishAt, now)
+		if status != tt.want || at == nil || !at.Equal(tt.wantAt) {
+			t.Errorf("%s: publishStatus() = %s, %v, want %s, %v", tt.name, status, at, tt.want, tt.wantAt)
+		}
+	}
+}
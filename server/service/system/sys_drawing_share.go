@@ -0,0 +1,124 @@
+package system
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrSharePasswordRequired 分享链接需要密码或密码错误
+var ErrSharePasswordRequired = errors.New("请输入正确的访问密码")
+
+// maxSharePasswordLength 分享密码长度上限（bcrypt 仅使用前72字节）
+const maxSharePasswordLength = 32
+
+type DrawingShareService struct{}
+
+// CreateShare 创建图纸分享链接（图纸创建者或相册创建者/管理员）
+func (shareService *DrawingShareService) CreateShare(req request.CreateDrawingShare, userID uint, userUUID uuid.UUID) (system.SysDrawingShare, error) {
+	var share system.SysDrawingShare
+	if err := validateGrantWindow(nil, req.ExpiresAt); err != nil {
+		return share, err
+	}
+	if utf8.RuneCountInString(req.Password) > maxSharePasswordLength {
+		return share, errors.New("访问密码过长")
+	}
+	if _, err := checkDrawingsManageable([]uint{req.DrawingID}, userID, userUUID); err != nil {
+		return share, err
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return share, err
+	}
+	share = system.SysDrawingShare{
+		DrawingID:   req.DrawingID,
+		Token:       hex.EncodeToString(buf),
+		ExpiresAt:   req.ExpiresAt,
+		CreatorUUID: userUUID,
+	}
+	if req.Password != "" {
+		share.PasswordHash = utils.BcryptHash(req.Password)
+	}
+	err := global.GVA_DB.Create(&share).Error
+	return share, err
+}
+
+// DeleteShares 删除图纸分享链接，删除后链接立即失效
+func (shareService *DrawingShareService) DeleteShares(req request.DeleteDrawingShares, userID uint, userUUID uuid.UUID) error {
+	var shares []system.SysDrawingShare
+	if err := global.GVA_DB.Select("id", "drawing_id").Where("id IN ?", req.IDs).Find(&shares).Error; err != nil {
+		return err
+	}
+	if len(shares) == 0 {
+		return errors.New("分享链接不存在")
+	}
+	drawingIDs := make([]uint, 0, len(shares))
+	for _, s := range shares {
+		drawingIDs = append(drawingIDs, s.DrawingID)
+	}
+	if _, err := checkDrawingsManageable(drawingIDs, userID, userUUID); err != nil {
+		return err
+	}
+	return global.GVA_DB.Delete(&system.SysDrawingShare{}, "id IN ?", req.IDs).Error
+}
+
+// GetShares 获取图纸的分享链接列表
+func (shareService *DrawingShareService) GetShares(req request.GetDrawingShares, userID uint, userUUID uuid.UUID) (list []system.SysDrawingShare, err error) {
+	if _, err = checkDrawingsManageable([]uint{req.DrawingID}, userID, userUUID); err != nil {
+		return nil, err
+	}
+	err = global.GVA_DB.Where("drawing_id = ?", req.DrawingID).Order("id DESC").Find(&list).Error
+	return list, err
+}
+
+// ViewShare 通过分享令牌查看图纸海报页，校验有效期与密码并累计查看次数
+func (shareService *DrawingShareService) ViewShare(token string, password string) (system.SysDrawingShare, error) {
+	var share system.SysDrawingShare
+	if err := global.GVA_DB.Where("token = ?", token).First(&share).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return share, errors.New("分享链接不存在或已失效")
+		}
+		return share, err
+	}
+	now := time.Now()
+	if share.ExpiresAt != nil && !share.ExpiresAt.After(now) {
+		return share, errors.New("分享链接已过期")
+	}
+	if share.PasswordHash != "" && (password == "" || !utils.BcryptCheck(password, share.PasswordHash)) {
+		return share, ErrSharePasswordRequired
+	}
+
+	// 仅加载已发布图纸的海报相关字段与海报衍生图，图纸文件不对外暴露
+	err := global.GVA_DB.Select("id", "album_id", "serial_number", "name", "bean_quantity", "poster_image_url").
+		Where("status = ?", system.DrawingStatusPublished).
+		Preload("Album", func(db *gorm.DB) *gorm.DB { return db.Select("id", "title") }).
+		Preload("Renditions", "kind = ?", system.RenditionKindPoster).
+		First(&share.Drawing, share.DrawingID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return share, errors.New("分享链接不存在或已失效")
+		}
+		return share, err
+	}
+
+	err = global.GVA_DB.Model(&system.SysDrawingShare{}).Where("id = ?", share.ID).Updates(map[string]interface{}{
+		"view_count":     gorm.Expr("view_count + 1"),
+		"last_viewed_at": now,
+	}).Error
+	if err != nil {
+		return share, err
+	}
+	share.ViewCount++
+	share.LastViewedAt = &now
+	return share, nil
+}
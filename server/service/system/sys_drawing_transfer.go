@@ -0,0 +1,277 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// drawingTransfer 移动或复制图纸的执行计划
+type drawingTransfer struct {
+	drawing     system.SysDrawing
+	serial      string
+	fieldValues []system.SysDrawingFieldValue
+}
+
+// canEditAlbum 判断用户能否向相册添加图纸：相册创建者、管理员或编辑及以上角色的有效成员
+func canEditAlbum(albumID uint, userID uint, userUUID uuid.UUID) (bool, error) {
+	ok, err := canManageAlbum(albumID, userID, userUUID)
+	if err != nil || ok {
+		return ok, err
+	}
+	role, err := albumMemberRole(albumID, userUUID)
+	return system.AlbumRoleRank(role) >= system.AlbumRoleRank(system.AlbumRoleEditor), err
+}
+
+// splitSerialNumber 拆分序号末尾的数字部分，如 A-012 -> ("A-", 12, 3)，无数字时 width 为 0
+func splitSerialNumber(serial string) (prefix string, number int, width int) {
+	i := len(serial)
+	for i > 0 && serial[i-1] >= '0' && serial[i-1] <= '9' {
+		i--
+	}
+	if i == len(serial) {
+		return serial, 0, 0
+	}
+	number, err := strconv.Atoi(serial[i:])
+	if err != nil {
+		return serial, 0, 0
+	}
+	return serial[:i], number, len(serial) - i
+}
+
+// resolveSerialConflict 按冲突策略为序号在目标相册中分配未占用的值，used 为目标相册已占用的序号
+func resolveSerialConflict(serial string, strategy string, used map[string]bool) (string, error) {
+	if !used[serial] {
+		return serial, nil
+	}
+	switch strategy {
+	case system.SerialConflictRenumber:
+		prefix, _, width := splitSerialNumber(serial)
+		if width == 0 {
+			prefix += "-"
+		}
+		next := 0
+		for s := range used {
+			if p, n, w := splitSerialNumber(s); w > 0 && p == prefix && n > next {
+				next = n
+			}
+		}
+		for next++; ; next++ {
+			candidate := fmt.Sprintf("%s%0*d", prefix, width, next)
+			if !used[candidate] {
+				return candidate, nil
+			}
+		}
+	case system.SerialConflictSuffix:
+		for i := 1; ; i++ {
+			candidate := fmt.Sprintf("%s-%d", serial, i)
+			if !used[candidate] {
+				return candidate, nil
+			}
+		}
+	default:
+		return "", fmt.Errorf("目标相册中已存在序号 %s", serial)
+	}
+}
+
+// planDrawingTransfer 校验权限并为每张图纸分配目标相册中的序号与自定义字段值
+// 自定义字段按字段标识迁移，目标相册未定义的字段会被丢弃，必填字段缺失时失败
+func planDrawingTransfer(req request.TransferDrawings, userID uint, userUUID uuid.UUID) ([]drawingTransfer, error) {
+	strategy := req.ConflictStrategy
+	if strategy == "" {
+		strategy = system.SerialConflictFail
+	}
+	switch strategy {
+	case system.SerialConflictFail, system.SerialConflictRenumber, system.SerialConflictSuffix:
+	default:
+		return nil, errors.New("不支持的序号冲突处理策略")
+	}
+	if _, err := checkDrawingsManageable(req.DrawingIDs, userID, userUUID); err != nil {
+		return nil, err
+	}
+	ok, err := canEditAlbum(req.TargetAlbumID, userID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("没有向目标相册添加图纸的权限")
+	}
+
+	var drawings []system.SysDrawing
+	err = global.GVA_DB.Preload("Colors").Preload("FieldValues.Field").
+		Where("id IN ?", req.DrawingIDs).Order("id ASC").Find(&drawings).Error
+	if err != nil {
+		return nil, err
+	}
+	var serials []string
+	err = global.GVA_DB.Unscoped().Model(&system.SysDrawing{}).Where("album_id = ?", req.TargetAlbumID).Pluck("serial_number", &serials).Error
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(serials)+len(drawings))
+	for _, s := range serials {
+		used[s] = true
+	}
+	var keys []string
+	err = global.GVA_DB.Model(&system.SysAlbumField{}).Where("album_id = ?", req.TargetAlbumID).Pluck("key", &keys).Error
+	if err != nil {
+		return nil, err
+	}
+	defined := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		defined[key] = true
+	}
+
+	plans := make([]drawingTransfer, 0, len(drawings))
+	for _, drawing := range drawings {
+		serial, err := resolveSerialConflict(drawing.SerialNumber, strategy, used)
+		if err != nil {
+			return nil, err
+		}
+		used[serial] = true
+
+		values := systemRes.BuildFieldValues(drawing.FieldValues)
+		for key := range values {
+			if !defined[key] {
+				delete(values, key)
+			}
+		}
+		fieldValues, err := validateDrawingFields(req.TargetAlbumID, values)
+		if err != nil {
+			return nil, fmt.Errorf("图纸 %s：%w", drawing.SerialNumber, err)
+		}
+		plans = append(plans, drawingTransfer{drawing: drawing, serial: serial, fieldValues: fieldValues})
+	}
+	return plans, nil
+}
+
+// MoveDrawings 将图纸移动到其他相册，图纸ID不变，下载记录、授权与完成记录随图纸保留
+func (drawingService *DrawingService) MoveDrawings(req request.TransferDrawings, userID uint, userUUID uuid.UUID) ([]systemRes.TransferredDrawing, error) {
+	plans, err := planDrawingTransfer(req, userID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, plan := range plans {
+		if plan.drawing.AlbumID == req.TargetAlbumID {
+			return nil, fmt.Errorf("图纸 %s 已在目标相册中", plan.drawing.SerialNumber)
+		}
+	}
+
+	result := make([]systemRes.TransferredDrawing, 0, len(plans))
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, plan := range plans {
+			err := tx.Model(&system.SysDrawing{}).Where("id = ?", plan.drawing.ID).Updates(map[string]interface{}{
+				"album_id":        req.TargetAlbumID,
+				"serial_number":   plan.serial,
+				"serial_sort_key": serialSortKey(plan.serial),
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err = replaceDrawingFieldValues(tx, plan.drawing.ID, plan.fieldValues); err != nil {
+				return err
+			}
+			result = append(result, systemRes.TransferredDrawing{
+				SourceID:       plan.drawing.ID,
+				DrawingID:      plan.drawing.ID,
+				SerialNumber:   plan.serial,
+				OriginalSerial: plan.drawing.SerialNumber,
+				Renamed:        plan.serial != plan.drawing.SerialNumber,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(result))
+	for _, item := range result {
+		ids = append(ids, item.DrawingID)
+	}
+	SearchServiceApp.QueueDrawings(ids...)
+	return result, nil
+}
+
+// CopyDrawings 将图纸复制到目标相册，复制件由当前用户创建
+// 复制件与原图纸引用相同的海报与图纸文件（图纸删除不会删除文件），衍生图为复制件单独生成，
+// 色号清单、图片哈希与发布状态一并复制；单独授权、下载记录、完成记录与审核记录仍归属原图纸
+func (drawingService *DrawingService) CopyDrawings(req request.TransferDrawings, userID uint, userUUID uuid.UUID) ([]systemRes.TransferredDrawing, error) {
+	plans, err := planDrawingTransfer(req, userID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	var hashes []system.SysDrawingImageHash
+	if err = global.GVA_DB.Where("drawing_id IN ?", req.DrawingIDs).Find(&hashes).Error; err != nil {
+		return nil, err
+	}
+	hashesByDrawing := make(map[uint][]system.SysDrawingImageHash)
+	for _, h := range hashes {
+		hashesByDrawing[h.DrawingID] = append(hashesByDrawing[h.DrawingID], h)
+	}
+
+	result := make([]systemRes.TransferredDrawing, 0, len(plans))
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, plan := range plans {
+			source := plan.drawing
+			colors := make([]system.SysDrawingColor, 0, len(source.Colors))
+			for _, color := range source.Colors {
+				colors = append(colors, system.SysDrawingColor{ColorCode: color.ColorCode, Quantity: color.Quantity})
+			}
+			var beanQuantity *int
+			if source.BeanQuantity != nil {
+				quantity := *source.BeanQuantity
+				beanQuantity = &quantity
+			}
+			drawing := system.SysDrawing{
+				AlbumID:        req.TargetAlbumID,
+				SerialNumber:   plan.serial,
+				SerialSortKey:  serialSortKey(plan.serial),
+				Name:           source.Name,
+				BeanQuantity:   beanQuantity,
+				PosterImageURL: source.PosterImageURL,
+				DrawingURLs:    source.DrawingURLs,
+				CreatorUUID:    userUUID,
+				AllowedMembers: "[]",
+				Status:         source.Status,
+				PublishAt:      source.PublishAt,
+				Colors:         colors,
+			}
+			if err := tx.Create(&drawing).Error; err != nil {
+				return err
+			}
+			if err := replaceDrawingFieldValues(tx, drawing.ID, plan.fieldValues); err != nil {
+				return err
+			}
+			if err := replaceDrawingHashes(tx, drawing.ID, hashesByDrawing[source.ID]); err != nil {
+				return err
+			}
+			result = append(result, systemRes.TransferredDrawing{
+				SourceID:       source.ID,
+				DrawingID:      drawing.ID,
+				SerialNumber:   plan.serial,
+				OriginalSerial: source.SerialNumber,
+				Renamed:        plan.serial != source.SerialNumber,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(result))
+	for _, item := range result {
+		ImageRenditionServiceApp.QueueDrawingRenditions(item.DrawingID)
+		ids = append(ids, item.DrawingID)
+	}
+	SearchServiceApp.QueueDrawings(ids...)
+	return result, nil
+}
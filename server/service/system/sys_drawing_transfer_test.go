@@ -0,0 +1,36 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func Test_resolveSerialConflict(t *testing.T) {
+	used := map[string]bool{"A-012": true, "A-030": true, "A-012-1": true, "ABC": true, "7": true}
+	tests := []struct {
+		name     string
+		serial   string
+		strategy string
+		want     string
+		wantErr  bool
+	}{
+		{name: "无冲突保持原序号", serial: "A-013", strategy: system.SerialConflictFail, want: "A-013"},
+		{name: "冲突时失败", serial: "A-012", strategy: system.SerialConflictFail, wantErr: true},
+		{name: "顺延同前缀最大编号", serial: "A-012", strategy: system.SerialConflictRenumber, want: "A-031"},
+		{name: "无数字序号顺延", serial: "ABC", strategy: system.SerialConflictRenumber, want: "ABC-1"},
+		{name: "纯数字序号顺延", serial: "7", strategy: system.SerialConflictRenumber, want: "8"},
+		{name: "追加后缀跳过已占用", serial: "A-012", strategy: system.SerialConflictSuffix, want: "A-012-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSerialConflict(tt.serial, tt.strategy, used)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveSerialConflict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveSerialConflict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
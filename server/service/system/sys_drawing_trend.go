@@ -0,0 +1,165 @@
+package system
+
+import (
+	"math"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 图纸热度 = 统计窗口内每日下载次数按半衰期衰减后求和，数据取自每日下载汇总表
+type DrawingTrendService struct{}
+
+const (
+	trendHalfLifeDays = 3.0 // 热度半衰期（天）
+	trendWindowDays   = 30  // 统计窗口（天，含当天）
+	trendInsertBatch  = 500
+
+	feedDefaultLimit   = 10
+	feedMaxLimit       = 50
+	feedDefaultNewDays = 14
+	feedMaxNewDays     = 90
+)
+
+// notDownloadedSQL 用户从未下载过的图纸（含已压缩到汇总表的下载记录）
+const notDownloadedSQL = "NOT EXISTS (SELECT 1 FROM sys_download_histories h WHERE h.drawing_id = sys_drawings.id AND h.user_uuid = ? AND h.deleted_at IS NULL)" +
+	" AND NOT EXISTS (SELECT 1 FROM sys_download_summaries ds WHERE ds.drawing_id = sys_drawings.id AND ds.user_uuid = ?)"
+
+// trendDecay 距今 ageDays 天的下载对热度的权重
+func trendDecay(ageDays int) float64 {
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	return math.Pow(0.5, float64(ageDays)/trendHalfLifeDays)
+}
+
+// computeTrendScores 按图纸汇总每日下载的衰减热度
+func computeTrendScores(rows []system.SysDownloadDailyDrawing, today time.Time, now time.Time) []system.SysDrawingTrend {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	index := make(map[uint]int)
+	trends := make([]system.SysDrawingTrend, 0)
+	for _, row := range rows {
+		day, err := time.ParseInLocation(analyticsDateLayout, row.Day, time.Local)
+		if err != nil {
+			continue
+		}
+		age := int(math.Round(today.Sub(day).Hours() / 24))
+		i, ok := index[row.DrawingID]
+		if !ok {
+			i = len(trends)
+			index[row.DrawingID] = i
+			trends = append(trends, system.SysDrawingTrend{DrawingID: row.DrawingID, ComputedAt: now})
+		}
+		trends[i].Downloads += row.Downloads
+		trends[i].Score += float64(row.Downloads) * trendDecay(age)
+	}
+	return trends
+}
+
+// RecomputeTrends 根据最近的每日下载汇总重新计算所有图纸热度，返回有热度的图纸数
+func (trendService *DrawingTrendService) RecomputeTrends() (int, error) {
+	now := time.Now()
+	start := now.AddDate(0, 0, -(trendWindowDays - 1)).Format(analyticsDateLayout)
+	var rows []system.SysDownloadDailyDrawing
+	err := global.GVA_DB.Select("day", "drawing_id", "downloads").
+		Where("day >= ? AND downloads > 0", start).Find(&rows).Error
+	if err != nil {
+		return 0, err
+	}
+	trends := computeTrendScores(rows, now, now)
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&system.SysDrawingTrend{}).Error; err != nil {
+			return err
+		}
+		if len(trends) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(trends, trendInsertBatch).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(trends), nil
+}
+
+// feedLimit 规范推荐数量
+func feedLimit(limit int) int {
+	if limit <= 0 {
+		return feedDefaultLimit
+	}
+	if limit > feedMaxLimit {
+		return feedMaxLimit
+	}
+	return limit
+}
+
+// GetFeed 获取用户可访问相册中的热门与新发布图纸，排除用户自己创建和已下载过的图纸
+func (trendService *DrawingTrendService) GetFeed(req request.GetDrawingFeed, userUUID uuid.UUID) (feed systemRes.DrawingFeed, err error) {
+	newDays := req.NewDays
+	if newDays <= 0 {
+		newDays = feedDefaultNewDays
+	}
+	if newDays > feedMaxNewDays {
+		newDays = feedMaxNewDays
+	}
+	candidates := func() *gorm.DB {
+		return global.GVA_DB.Model(&system.SysDrawing{}).
+			Scopes(visibleDrawingsScope(userUUID)).
+			Where("sys_drawings.status = ? AND sys_drawings.creator_uuid <> ?", system.DrawingStatusPublished, userUUID).
+			Where(notDownloadedSQL, userUUID, userUUID).
+			Preload("Album").Preload("Creator").Preload("Renditions")
+	}
+
+	var trending []*system.SysDrawing
+	err = candidates().Joins("JOIN sys_drawing_trends t ON t.drawing_id = sys_drawings.id").
+		Where("t.score > 0").Order("t.score DESC, sys_drawings.id DESC").
+		Limit(feedLimit(req.TrendingLimit)).Find(&trending).Error
+	if err != nil {
+		return feed, err
+	}
+	ids := make([]uint, 0, len(trending))
+	for _, drawing := range trending {
+		ids = append(ids, drawing.ID)
+	}
+	var trends []system.SysDrawingTrend
+	if len(ids) > 0 {
+		if err = global.GVA_DB.Where("drawing_id IN ?", ids).Find(&trends).Error; err != nil {
+			return feed, err
+		}
+	}
+	trendByID := make(map[uint]system.SysDrawingTrend, len(trends))
+	for _, trend := range trends {
+		trendByID[trend.DrawingID] = trend
+	}
+	feed.Trending = make([]systemRes.TrendingDrawing, 0, len(trending))
+	for _, drawing := range trending {
+		trend := trendByID[drawing.ID]
+		feed.Trending = append(feed.Trending, systemRes.TrendingDrawing{
+			DrawingResponse: systemRes.ToDrawingResponse(drawing),
+			Score:           trend.Score,
+			Downloads:       trend.Downloads,
+		})
+	}
+
+	// 旧数据没有发布时间，以创建时间代替
+	publishedAt := "COALESCE(sys_drawings.publish_at, sys_drawings.created_at)"
+	db := candidates().Where(publishedAt+" >= ?", time.Now().AddDate(0, 0, -newDays))
+	if len(ids) > 0 {
+		db = db.Where("sys_drawings.id NOT IN ?", ids)
+	}
+	var newest []*system.SysDrawing
+	err = db.Order(publishedAt + " DESC, sys_drawings.id DESC").Limit(feedLimit(req.NewLimit)).Find(&newest).Error
+	if err != nil {
+		return feed, err
+	}
+	feed.Newest = make([]systemRes.DrawingResponse, 0, len(newest))
+	for _, drawing := range newest {
+		feed.Newest = append(feed.Newest, systemRes.ToDrawingResponse(drawing))
+	}
+	return feed, nil
+}
@@ -0,0 +1,29 @@
+package system
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func Test_computeTrendScores(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 0, 0, 0, time.Local)
+	trends := computeTrendScores([]system.SysDownloadDailyDrawing{
+		{Day: "2024-05-10", DrawingID: 1, Downloads: 2},
+		{Day: "2024-05-07", DrawingID: 1, Downloads: 4},
+		{Day: "2024-05-10", DrawingID: 2, Downloads: 3},
+		{Day: "bad", DrawingID: 3, Downloads: 9},
+	}, now, now)
+	if len(trends) != 2 {
+		t.Fatalf("computeTrendScores() = %+v", trends)
+	}
+	// 3 天前的下载按半衰期衰减一半
+	if trends[0].DrawingID != 1 || trends[0].Downloads != 6 || math.Abs(trends[0].Score-4) > 1e-9 {
+		t.Fatalf("drawing 1 trend = %+v", trends[0])
+	}
+	if trends[1].DrawingID != 2 || math.Abs(trends[1].Score-3) > 1e-9 || !trends[1].ComputedAt.Equal(now) {
+		t.Fatalf("drawing 2 trend = %+v", trends[1])
+	}
+}
@@ -0,0 +1,118 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/imageproxy"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/rendition"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/upload"
+)
+
+// ErrImageNotFound 原图不存在
+var ErrImageNotFound = errors.New("图片不存在")
+
+// 默认配置，配置文件未设置 image-proxy 时使用
+var (
+	defaultImageProxySizes     = []string{"160x160", "320x0", "320x320", "640x0", "800x0", "1280x0"}
+	defaultImageProxyQualities = []int{60, 75, 85}
+)
+
+// 白名单与磁盘缓存在首次请求时按配置初始化
+var (
+	imageProxyOnce      sync.Once
+	imageProxyInitErr   error
+	imageProxyWhitelist *imageproxy.Whitelist
+	imageProxyCache     *imageproxy.Cache
+)
+
+type ImageProxyService struct{}
+
+// ImageProxyResult 缩放结果
+type ImageProxyResult struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+// initImageProxy 按配置初始化白名单与磁盘缓存
+func initImageProxy() error {
+	imageProxyOnce.Do(func() {
+		cfg := global.GVA_CONFIG.ImageProxy
+		sizes, qualities := cfg.Sizes, cfg.Qualities
+		if len(sizes) == 0 {
+			sizes = defaultImageProxySizes
+		}
+		if len(qualities) == 0 {
+			qualities = defaultImageProxyQualities
+		}
+		imageProxyWhitelist, imageProxyInitErr = imageproxy.NewWhitelist(sizes, qualities)
+		if imageProxyInitErr != nil {
+			return
+		}
+
+		dir := cfg.CacheDir
+		if dir == "" {
+			dir = "cache/image"
+		}
+		maxSize := cfg.MaxCacheSize
+		if maxSize <= 0 {
+			maxSize = 512
+		}
+		imageProxyCache, imageProxyInitErr = imageproxy.NewCache(dir, maxSize<<20)
+	})
+	return imageProxyInitErr
+}
+
+// GetResizedImage 读取存储中的原图并按白名单参数缩放，结果缓存到磁盘
+func (proxyService *ImageProxyService) GetResizedImage(key, width, height, fit, quality string) (*ImageProxyResult, error) {
+	if err := initImageProxy(); err != nil {
+		return nil, err
+	}
+	if !isImageURL(key) {
+		return nil, errors.New("不支持的图片格式")
+	}
+	opts, err := imageProxyWhitelist.ParseOptions(width, height, fit, quality)
+	if err != nil {
+		return nil, err
+	}
+
+	// PNG 原图保持 PNG 输出以保留透明度，其余统一输出 JPEG
+	format, contentType := rendition.FormatJPEG, "image/jpeg"
+	if strings.ToLower(path.Ext(key)) == ".png" {
+		format, contentType = rendition.FormatPNG, "image/png"
+	}
+	name := imageproxy.Name(key, opts, rendition.Ext(format))
+	if data, ok := imageProxyCache.Get(name); ok {
+		return &ImageProxyResult{Data: data, ContentType: contentType, ETag: name}, nil
+	}
+
+	// 同一图片同一参数并发请求只处理一次
+	v, err, _ := global.GVA_Concurrency_Control.Do("imageproxy:"+name, func() (interface{}, error) {
+		f, err := upload.OpenFile(key)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, ErrImageNotFound
+			}
+			return nil, err
+		}
+		defer f.Close()
+
+		data, err := imageproxy.Process(f, opts, format)
+		if err != nil {
+			return nil, err
+		}
+		if err := imageProxyCache.Put(name, data); err != nil {
+			global.GVA_LOG.Warn("写入图片缓存失败: " + err.Error())
+		}
+		return &ImageProxyResult{Data: data, ContentType: contentType, ETag: name}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*ImageProxyResult), nil
+}
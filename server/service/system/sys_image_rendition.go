@@ -0,0 +1,238 @@
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"runtime/debug"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/rendition"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/upload"
+	"go.uber.org/zap"
+)
+
+type ImageRenditionService struct{}
+
+var ImageRenditionServiceApp = new(ImageRenditionService)
+
+// renditionOwner 衍生图所属记录的多态类型，与 gorm polymorphic 默认值（表名）保持一致
+func renditionOwner(owner interface{ TableName() string }) string {
+	return owner.TableName()
+}
+
+// GenerateRenditions 为指定记录的某类图片生成衍生图
+// 已存在的衍生图会被跳过；来源不在 sources 中的旧衍生图会连同存储文件一起删除
+func (renditionService *ImageRenditionService) GenerateRenditions(ownerType string, ownerID uint, kind string, sources []string) error {
+	var existing []system.SysImageRendition
+	err := global.GVA_DB.Where("owner_type = ? AND owner_id = ? AND kind = ?", ownerType, ownerID, kind).Find(&existing).Error
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		if source != "" && isImageURL(source) {
+			wanted[source] = true
+		}
+	}
+
+	// 清理来源已变更的衍生图
+	done := make(map[string]bool)
+	var stale []system.SysImageRendition
+	for _, r := range existing {
+		if wanted[r.SourceURL] {
+			done[r.SourceURL] = true
+			continue
+		}
+		stale = append(stale, r)
+	}
+	if len(stale) > 0 {
+		renditionService.deleteRenditions(stale)
+	}
+
+	oss := upload.NewOss()
+	for source := range wanted {
+		if done[source] {
+			continue
+		}
+		if err := renditionService.generateOne(oss, ownerType, ownerID, kind, source); err != nil {
+			global.GVA_LOG.Warn("生成衍生图失败",
+				zap.String("owner_type", ownerType),
+				zap.Uint("owner_id", ownerID),
+				zap.String("source", source),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// generateOne 读取原图，生成全部规格并写入存储
+func (renditionService *ImageRenditionService) generateOne(oss upload.OSS, ownerType string, ownerID uint, kind string, source string) error {
+	f, err := openDrawingImage(source)
+	if err != nil {
+		return err
+	}
+	outputs, err := rendition.Generate(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(path.Base(source), path.Ext(source))
+	records := make([]system.SysImageRendition, 0, len(outputs))
+	for _, out := range outputs {
+		name := fmt.Sprintf("%s_%s_%d_%s%s", base, ownerType, ownerID, out.Size, rendition.Ext(out.Format))
+		header, err := upload.NewFileHeader(name, out.Data)
+		if err != nil {
+			renditionService.deleteRenditions(records)
+			return err
+		}
+		url, key, err := oss.UploadFile(header)
+		if err != nil {
+			renditionService.deleteRenditions(records)
+			return err
+		}
+		records = append(records, system.SysImageRendition{
+			OwnerID:   ownerID,
+			OwnerType: ownerType,
+			Kind:      kind,
+			SourceURL: source,
+			Size:      out.Size,
+			Format:    out.Format,
+			Width:     out.Width,
+			Height:    out.Height,
+			URL:       url,
+			Key:       key,
+		})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return global.GVA_DB.Create(&records).Error
+}
+
+// deleteRenditions 删除衍生图记录及其存储文件，文件删除失败仅记录日志
+func (renditionService *ImageRenditionService) deleteRenditions(renditions []system.SysImageRendition) {
+	if len(renditions) == 0 {
+		return
+	}
+	oss := upload.NewOss()
+	ids := make([]uint, 0, len(renditions))
+	for _, r := range renditions {
+		if r.Key != "" {
+			if err := oss.DeleteFile(r.Key); err != nil {
+				global.GVA_LOG.Warn("删除衍生图文件失败", zap.String("key", r.Key), zap.Error(err))
+			}
+		}
+		if r.ID != 0 {
+			ids = append(ids, r.ID)
+		}
+	}
+	if len(ids) > 0 {
+		if err := global.GVA_DB.Delete(&system.SysImageRendition{}, ids).Error; err != nil {
+			global.GVA_LOG.Warn("删除衍生图记录失败", zap.Error(err))
+		}
+	}
+}
+
+// GenerateAlbumRenditions 生成相册封面衍生图
+func (renditionService *ImageRenditionService) GenerateAlbumRenditions(album system.SysAlbum) error {
+	return renditionService.GenerateRenditions(renditionOwner(album), album.ID, system.RenditionKindCover, []string{album.CoverImageURL})
+}
+
+// GenerateDrawingRenditions 生成图纸海报与图纸图片衍生图
+func (renditionService *ImageRenditionService) GenerateDrawingRenditions(drawing system.SysDrawing) error {
+	var drawingURLs []string
+	if drawing.DrawingURLs != "" {
+		_ = json.Unmarshal([]byte(drawing.DrawingURLs), &drawingURLs)
+	}
+	ownerType := renditionOwner(drawing)
+	if err := renditionService.GenerateRenditions(ownerType, drawing.ID, system.RenditionKindPoster, []string{drawing.PosterImageURL}); err != nil {
+		return err
+	}
+	return renditionService.GenerateRenditions(ownerType, drawing.ID, system.RenditionKindPattern, drawingURLs)
+}
+
+// QueueAlbumRenditions 异步生成相册封面衍生图，不阻塞保存请求
+func (renditionService *ImageRenditionService) QueueAlbumRenditions(albumID uint) {
+	go func() {
+		defer recoverRendition()
+		var album system.SysAlbum
+		if err := global.GVA_DB.First(&album, albumID).Error; err != nil {
+			return
+		}
+		if err := renditionService.GenerateAlbumRenditions(album); err != nil {
+			global.GVA_LOG.Warn("生成相册衍生图失败", zap.Uint("album_id", albumID), zap.Error(err))
+		}
+	}()
+}
+
+// QueueDrawingRenditions 异步生成图纸衍生图，不阻塞保存请求
+func (renditionService *ImageRenditionService) QueueDrawingRenditions(drawingID uint) {
+	go func() {
+		defer recoverRendition()
+		var drawing system.SysDrawing
+		if err := global.GVA_DB.First(&drawing, drawingID).Error; err != nil {
+			return
+		}
+		if err := renditionService.GenerateDrawingRenditions(drawing); err != nil {
+			global.GVA_LOG.Warn("生成图纸衍生图失败", zap.Uint("drawing_id", drawingID), zap.Error(err))
+		}
+	}()
+}
+
+// BackfillRenditions 为历史相册与图纸补齐衍生图，按批次扫描，返回处理的记录数
+func (renditionService *ImageRenditionService) BackfillRenditions(batchSize int) (processed int, err error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	var lastID uint
+	for {
+		var albums []system.SysAlbum
+		err = global.GVA_DB.Where("id > ? AND cover_image_url <> ''", lastID).Order("id").Limit(batchSize).Find(&albums).Error
+		if err != nil {
+			return processed, err
+		}
+		for _, album := range albums {
+			if err := renditionService.GenerateAlbumRenditions(album); err != nil {
+				global.GVA_LOG.Warn("补齐相册衍生图失败", zap.Uint("album_id", album.ID), zap.Error(err))
+			}
+			lastID = album.ID
+			processed++
+		}
+		if len(albums) < batchSize {
+			break
+		}
+	}
+
+	lastID = 0
+	for {
+		var drawings []system.SysDrawing
+		err = global.GVA_DB.Where("id > ?", lastID).Order("id").Limit(batchSize).Find(&drawings).Error
+		if err != nil {
+			return processed, err
+		}
+		for _, drawing := range drawings {
+			if err := renditionService.GenerateDrawingRenditions(drawing); err != nil {
+				global.GVA_LOG.Warn("补齐图纸衍生图失败", zap.Uint("drawing_id", drawing.ID), zap.Error(err))
+			}
+			lastID = drawing.ID
+			processed++
+		}
+		if len(drawings) < batchSize {
+			break
+		}
+	}
+	return processed, nil
+}
+
+// recoverRendition 防止衍生图生成中的 panic 影响主进程
+func recoverRendition() {
+	if r := recover(); r != nil {
+		global.GVA_LOG.Error("生成衍生图异常", zap.Any("panic", r), zap.String("stack", string(debug.Stack())))
+	}
+}
@@ -0,0 +1,147 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 下载次数子查询，用于按热度排序；取自每日下载汇总表，原始下载历史被压缩清理后排序不变
+const (
+	drawingPopularitySQL = "(SELECT COALESCE(SUM(r.downloads), 0) FROM sys_download_daily_drawings r WHERE r.drawing_id = sys_drawings.id)"
+	albumPopularitySQL   = "(SELECT COALESCE(SUM(r.downloads), 0) FROM sys_download_daily_drawings r WHERE r.album_id = sys_albums.id)"
+	drawingTrendSQL      = "COALESCE((SELECT t.score FROM sys_drawing_trends t WHERE t.drawing_id = sys_drawings.id), 0)"
+)
+
+// 手动排序：位置为 0 表示未排序，排在已排序记录之后
+const (
+	drawingPositionOrder = "CASE WHEN sys_drawings.position = 0 THEN 1 ELSE 0 END, sys_drawings.position ASC, sys_drawings.created_at DESC"
+	albumPositionOrder   = "CASE WHEN sys_albums.position = 0 THEN 1 ELSE 0 END, sys_albums.position ASC, sys_albums.created_at DESC"
+)
+
+// isDrawingListSort 是否为图纸列表内置的排序方式（否则视为按字段排序）
+func isDrawingListSort(sort string) bool {
+	switch sort {
+	case system.ListSortPosition, system.ListSortSerial, system.ListSortName, system.ListSortCreated, system.ListSortPopularity, system.ListSortTrending:
+		return true
+	}
+	return false
+}
+
+// drawingListOrder 图纸列表排序子句，acrossAlbums 为 true 时按手动排序先比较相册位置
+func drawingListOrder(sort string, acrossAlbums bool) (string, error) {
+	switch sort {
+	case system.ListSortPosition:
+		if acrossAlbums {
+			albumPosition := "(SELECT a.position FROM sys_albums a WHERE a.id = sys_drawings.album_id)"
+			return "CASE WHEN " + albumPosition + " = 0 THEN 1 ELSE 0 END, " + albumPosition + " ASC, sys_drawings.album_id ASC, " + drawingPositionOrder, nil
+		}
+		return drawingPositionOrder, nil
+	case system.ListSortSerial:
+		return "sys_drawings.serial_sort_key ASC, sys_drawings.id ASC", nil
+	case system.ListSortName:
+		return "sys_drawings.name ASC, sys_drawings.id ASC", nil
+	case "", system.ListSortCreated:
+		return "sys_drawings.created_at DESC", nil
+	case system.ListSortPopularity:
+		return drawingPopularitySQL + " DESC, sys_drawings.created_at DESC", nil
+	case system.ListSortTrending:
+		return drawingTrendSQL + " DESC, sys_drawings.created_at DESC", nil
+	default:
+		return "", fmt.Errorf("不支持的排序方式 %s", sort)
+	}
+}
+
+// albumListOrder 相册列表排序子句，默认按手动排序位置
+func albumListOrder(sort string) (string, error) {
+	switch sort {
+	case "", system.ListSortPosition:
+		return albumPositionOrder, nil
+	case system.ListSortName:
+		return "sys_albums.title ASC, sys_albums.id ASC", nil
+	case system.ListSortCreated:
+		return "sys_albums.created_at DESC", nil
+	case system.ListSortPopularity:
+		return albumPopularitySQL + " DESC, sys_albums.created_at DESC", nil
+	default:
+		return "", fmt.Errorf("不支持的排序方式 %s", sort)
+	}
+}
+
+// uniqueIDs 校验ID列表无重复
+func uniqueIDs(ids []uint) error {
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return fmt.Errorf("ID %d 重复", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// applyPositions 在事务中将 ids 依次设为位置 1..n，其余已排序的记录整体后移到列表之后并保持原相对顺序，
+// 未排序（位置为 0）的记录不受影响
+func applyPositions(tx *gorm.DB, model interface{}, scope func(*gorm.DB) *gorm.DB, ids []uint) error {
+	n := len(ids)
+	err := tx.Model(model).Scopes(scope).Where("id NOT IN ? AND position > 0", ids).
+		Update("position", gorm.Expr("position + ?", n)).Error
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if err = tx.Model(model).Where("id = ?", id).Update("position", i+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReorderDrawings 按给定顺序调整相册内图纸的位置（相册创建者、管理员或编辑）
+func (drawingService *DrawingService) ReorderDrawings(req request.ReorderDrawings, userID uint, userUUID uuid.UUID) error {
+	if err := uniqueIDs(req.DrawingIDs); err != nil {
+		return err
+	}
+	ok, err := canEditAlbum(req.AlbumID, userID, userUUID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("没有调整该相册图纸顺序的权限")
+	}
+	var count int64
+	err = global.GVA_DB.Model(&system.SysDrawing{}).Where("album_id = ? AND id IN ?", req.AlbumID, req.DrawingIDs).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count != int64(len(req.DrawingIDs)) {
+		return errors.New("存在不属于该相册的图纸")
+	}
+	inAlbum := func(db *gorm.DB) *gorm.DB { return db.Where("album_id = ?", req.AlbumID) }
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		return applyPositions(tx, &system.SysDrawing{}, inAlbum, req.DrawingIDs)
+	})
+}
+
+// ReorderAlbums 按给定顺序调整相册的位置
+func (albumService *AlbumService) ReorderAlbums(req request.ReorderAlbums) error {
+	if err := uniqueIDs(req.AlbumIDs); err != nil {
+		return err
+	}
+	var count int64
+	if err := global.GVA_DB.Model(&system.SysAlbum{}).Where("id IN ?", req.AlbumIDs).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != int64(len(req.AlbumIDs)) {
+		return errors.New("相册不存在")
+	}
+	all := func(db *gorm.DB) *gorm.DB { return db }
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		return applyPositions(tx, &system.SysAlbum{}, all, req.AlbumIDs)
+	})
+}
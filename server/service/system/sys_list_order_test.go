@@ -0,0 +1,34 @@
+package system
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func Test_drawingListOrder(t *testing.T) {
+	order, err := drawingListOrder("position", false)
+	if err != nil || order != "CASE WHEN sys_drawings.position = 0 THEN 1 ELSE 0 END, sys_drawings.position ASC, sys_drawings.created_at DESC" {
+		t.Fatalf("drawingListOrder(position) = %q, %v", order, err)
+	}
+	order, err = drawingListOrder("position", true)
+	if err != nil || !strings.HasPrefix(order, "CASE WHEN (SELECT a.position FROM sys_albums a") {
+		t.Fatalf("drawingListOrder(position, acrossAlbums) = %q, %v", order, err)
+	}
+	if order, _ = drawingListOrder("", false); order != "sys_drawings.created_at DESC" {
+		t.Fatalf("drawingListOrder(\"\") = %q", order)
+	}
+	if _, err = drawingListOrder("size", false); err == nil {
+		t.Fatal("drawingListOrder(size) expected error")
+	}
+	if !isDrawingListSort(system.ListSortTrending) || isDrawingListSort("beanQuantity") {
+		t.Fatal("isDrawingListSort() mismatch")
+	}
+	if _, err = albumListOrder("serial"); err == nil {
+		t.Fatal("albumListOrder(serial) expected error")
+	}
+	if err = uniqueIDs([]uint{1, 2, 1}); err == nil {
+		t.Fatal("uniqueIDs() expected duplicate error")
+	}
+}
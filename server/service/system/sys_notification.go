@@ -0,0 +1,63 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationService struct{}
+
+// createNotifications 向多个用户发送同一条站内通知，自动去重并跳过空UUID
+func createNotifications(tx *gorm.DB, userUUIDs []uuid.UUID, notification system.SysNotification) error {
+	seen := make(map[uuid.UUID]bool, len(userUUIDs))
+	notifications := make([]system.SysNotification, 0, len(userUUIDs))
+	for _, id := range userUUIDs {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		n := notification
+		n.UserUUID = id
+		notifications = append(notifications, n)
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+	return tx.Create(&notifications).Error
+}
+
+// GetNotifications 获取当前用户的站内通知
+func (notificationService *NotificationService) GetNotifications(req request.GetNotifications, userUUID uuid.UUID) (list []system.SysNotification, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysNotification{}).Where("user_uuid = ?", userUUID)
+	if req.UnreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	err = db.Order("id DESC").Find(&list).Error
+	return list, total, err
+}
+
+// MarkRead 标记通知已读，ids 为空时标记全部
+func (notificationService *NotificationService) MarkRead(req request.MarkNotificationsRead, userUUID uuid.UUID) error {
+	db := global.GVA_DB.Model(&system.SysNotification{}).Where("user_uuid = ? AND read_at IS NULL", userUUID)
+	if len(req.IDs) > 0 {
+		db = db.Where("id IN ?", req.IDs)
+	}
+	return db.Update("read_at", time.Now()).Error
+}
+
+// GetUnreadCount 获取未读通知数量
+func (notificationService *NotificationService) GetUnreadCount(userUUID uuid.UUID) (count int64, err error) {
+	err = global.GVA_DB.Model(&system.SysNotification{}).Where("user_uuid = ? AND read_at IS NULL", userUUID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,399 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/example"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/upload"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 回收站以 deleted_at 记录删除时间：删除相册时其图纸使用同一删除时间，恢复相册时据此一并恢复；
+// 相册管理员、成员与图纸授权在回收站期间保留，恢复后即可继续使用，彻底删除时才清理
+type RecycleBinService struct{}
+
+// trashAlbum 将相册及其图纸移入回收站，需在事务中调用
+func trashAlbum(tx *gorm.DB, albumID uint, now time.Time) error {
+	result := tx.Model(&system.SysAlbum{}).Where("id = ?", albumID).Update("deleted_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("相册不存在")
+	}
+	return tx.Model(&system.SysDrawing{}).Where("album_id = ?", albumID).Update("deleted_at", now).Error
+}
+
+// recyclePurgeAt 计算回收站条目的彻底删除时间
+func recyclePurgeAt(deletedAt time.Time) *time.Time {
+	days := global.GVA_CONFIG.RecycleBin.RetentionDays
+	if days <= 0 {
+		return nil
+	}
+	purgeAt := deletedAt.AddDate(0, 0, days)
+	return &purgeAt
+}
+
+// cascadedDrawingsSQL 随所属相册一起删除的图纸（删除时间与相册一致）
+const cascadedDrawingsSQL = "EXISTS (SELECT 1 FROM sys_albums a WHERE a.id = sys_drawings.album_id AND a.deleted_at = sys_drawings.deleted_at)"
+
+// GetItems 获取回收站中的相册或单独删除的图纸
+func (recycleService *RecycleBinService) GetItems(req request.GetRecycleBin) (list []systemRes.RecycleBinItem, total int64, err error) {
+	if req.Type == system.RecycleTypeAlbum {
+		db := global.GVA_DB.Unscoped().Model(&system.SysAlbum{}).Where("deleted_at IS NOT NULL")
+		if req.Keyword != "" {
+			db = db.Where("title LIKE ?", "%"+req.Keyword+"%")
+		}
+		if err = db.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+		if req.Page > 0 && req.PageSize > 0 {
+			db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+		}
+		var albums []system.SysAlbum
+		if err = db.Order("deleted_at DESC").Find(&albums).Error; err != nil {
+			return nil, 0, err
+		}
+		list = make([]systemRes.RecycleBinItem, 0, len(albums))
+		for _, album := range albums {
+			item := systemRes.RecycleBinItem{
+				ID:         album.ID,
+				Type:       system.RecycleTypeAlbum,
+				Title:      album.Title,
+				AlbumID:    album.ID,
+				AlbumTitle: album.Title,
+				DeletedAt:  album.DeletedAt.Time,
+				PurgeAt:    recyclePurgeAt(album.DeletedAt.Time),
+			}
+			err = global.GVA_DB.Unscoped().Model(&system.SysDrawing{}).
+				Where("album_id = ? AND deleted_at = ?", album.ID, album.DeletedAt.Time).
+				Count(&item.DrawingCount).Error
+			if err != nil {
+				return nil, 0, err
+			}
+			list = append(list, item)
+		}
+		return list, total, nil
+	}
+
+	db := global.GVA_DB.Unscoped().Model(&system.SysDrawing{}).
+		Where("sys_drawings.deleted_at IS NOT NULL").
+		Where("NOT " + cascadedDrawingsSQL)
+	if req.Keyword != "" {
+		db = db.Where("sys_drawings.name LIKE ? OR sys_drawings.serial_number LIKE ?", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Page > 0 && req.PageSize > 0 {
+		db = db.Offset((req.Page - 1) * req.PageSize).Limit(req.PageSize)
+	}
+	var drawings []system.SysDrawing
+	err = db.Preload("Album", func(db *gorm.DB) *gorm.DB { return db.Unscoped().Select("id", "title") }).
+		Order("sys_drawings.deleted_at DESC").Find(&drawings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	list = make([]systemRes.RecycleBinItem, 0, len(drawings))
+	for _, drawing := range drawings {
+		list = append(list, systemRes.RecycleBinItem{
+			ID:           drawing.ID,
+			Type:         system.RecycleTypeDrawing,
+			Title:        drawing.Name,
+			SerialNumber: drawing.SerialNumber,
+			AlbumID:      drawing.AlbumID,
+			AlbumTitle:   drawing.Album.Title,
+			DeletedAt:    drawing.DeletedAt.Time,
+			PurgeAt:      recyclePurgeAt(drawing.DeletedAt.Time),
+		})
+	}
+	return list, total, nil
+}
+
+// RestoreItems 从回收站恢复相册（连同一起删除的图纸）或单独删除的图纸
+func (recycleService *RecycleBinService) RestoreItems(req request.RecycleBinItems) error {
+	if req.Type == system.RecycleTypeAlbum {
+		var albums []system.SysAlbum
+		if err := global.GVA_DB.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", req.IDs).Find(&albums).Error; err != nil {
+			return err
+		}
+		if len(albums) == 0 {
+			return errors.New("回收站中没有这些相册")
+		}
+		err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+			for _, album := range albums {
+				err := tx.Unscoped().Model(&system.SysDrawing{}).
+					Where("album_id = ? AND deleted_at = ?", album.ID, album.DeletedAt.Time).
+					Update("deleted_at", nil).Error
+				if err != nil {
+					return err
+				}
+				if err = tx.Unscoped().Model(&system.SysAlbum{}).Where("id = ?", album.ID).Update("deleted_at", nil).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		albumIDs := make([]uint, 0, len(albums))
+		for _, album := range albums {
+			albumIDs = append(albumIDs, album.ID)
+		}
+		SearchServiceApp.QueueAlbums(albumIDs...)
+		return nil
+	}
+
+	var drawings []system.SysDrawing
+	err := global.GVA_DB.Unscoped().Select("id", "album_id", "serial_number").
+		Where("id IN ? AND deleted_at IS NOT NULL", req.IDs).Find(&drawings).Error
+	if err != nil {
+		return err
+	}
+	if len(drawings) == 0 {
+		return errors.New("回收站中没有这些图纸")
+	}
+	for _, drawing := range drawings {
+		var count int64
+		if err = global.GVA_DB.Model(&system.SysAlbum{}).Where("id = ?", drawing.AlbumID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("图纸 " + drawing.SerialNumber + " 所属相册已删除，请先恢复相册")
+		}
+	}
+	ids := make([]uint, 0, len(drawings))
+	for _, drawing := range drawings {
+		ids = append(ids, drawing.ID)
+	}
+	if err = global.GVA_DB.Unscoped().Model(&system.SysDrawing{}).Where("id IN ?", ids).Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	SearchServiceApp.QueueDrawings(ids...)
+	return nil
+}
+
+// PurgeItems 彻底删除回收站中的相册或图纸，连同关联数据与存储文件
+func (recycleService *RecycleBinService) PurgeItems(req request.RecycleBinItems) error {
+	if req.Type == system.RecycleTypeAlbum {
+		var albums []system.SysAlbum
+		if err := global.GVA_DB.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", req.IDs).Find(&albums).Error; err != nil {
+			return err
+		}
+		if len(albums) == 0 {
+			return errors.New("回收站中没有这些相册")
+		}
+		return purgeAlbums(albums)
+	}
+	var drawings []system.SysDrawing
+	if err := global.GVA_DB.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", req.IDs).Find(&drawings).Error; err != nil {
+		return err
+	}
+	if len(drawings) == 0 {
+		return errors.New("回收站中没有这些图纸")
+	}
+	return purgeDrawings(drawings)
+}
+
+// PurgeExpired 彻底删除超过保留期的回收站条目，返回清理的相册数与图纸数
+func (recycleService *RecycleBinService) PurgeExpired(retentionDays int) (albumCount int, drawingCount int, err error) {
+	if retentionDays <= 0 {
+		return 0, 0, nil
+	}
+	// 历史版本删除相册时未删除其图纸，先将这些遗留图纸归入所属相册的回收站条目
+	err = global.GVA_DB.Exec("UPDATE sys_drawings SET deleted_at = (SELECT a.deleted_at FROM sys_albums a WHERE a.id = sys_drawings.album_id)" +
+		" WHERE deleted_at IS NULL AND album_id IN (SELECT id FROM sys_albums WHERE deleted_at IS NOT NULL)").Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	var albums []system.SysAlbum
+	if err = global.GVA_DB.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Find(&albums).Error; err != nil {
+		return 0, 0, err
+	}
+	if len(albums) > 0 {
+		if err = purgeAlbums(albums); err != nil {
+			return 0, 0, err
+		}
+	}
+	var drawings []system.SysDrawing
+	if err = global.GVA_DB.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Find(&drawings).Error; err != nil {
+		return len(albums), 0, err
+	}
+	if len(drawings) > 0 {
+		if err = purgeDrawings(drawings); err != nil {
+			return len(albums), 0, err
+		}
+	}
+	return len(albums), len(drawings), nil
+}
+
+// purgeAlbums 彻底删除相册及其全部图纸、成员、字段、编号规则、邀请与访问申请
+func purgeAlbums(albums []system.SysAlbum) error {
+	ids := make([]uint, 0, len(albums))
+	for _, album := range albums {
+		ids = append(ids, album.ID)
+	}
+	var drawings []system.SysDrawing
+	if err := global.GVA_DB.Unscoped().Where("album_id IN ?", ids).Find(&drawings).Error; err != nil {
+		return err
+	}
+	if len(drawings) > 0 {
+		if err := purgeDrawings(drawings); err != nil {
+			return err
+		}
+	}
+
+	var renditions []system.SysImageRendition
+	err := global.GVA_DB.Where("owner_type = ? AND owner_id IN ?", renditionOwner(system.SysAlbum{}), ids).Find(&renditions).Error
+	if err != nil {
+		return err
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&system.SysAlbumAdmin{},
+			&system.SysAlbumMember{},
+			&system.SysAlbumField{},
+			&system.SysAlbumSerialScheme{},
+			&system.SysAlbumSerialSequence{},
+			&system.SysAlbumInvite{},
+			&system.SysAlbumInviteRedemption{},
+			&system.SysAccessRequest{},
+		} {
+			if err := tx.Unscoped().Where("album_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&system.SysAlbum{}, ids).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	ImageRenditionServiceApp.deleteRenditions(renditions)
+	covers := make([]string, 0, len(albums))
+	for _, album := range albums {
+		covers = append(covers, album.CoverImageURL)
+	}
+	deleteUnreferencedFiles(covers)
+	return nil
+}
+
+// purgeDrawings 彻底删除图纸及其色号、字段值、哈希、授权、分享、完成记录、审核记录、访问申请与衍生图，下载记录保留
+func purgeDrawings(drawings []system.SysDrawing) error {
+	ids := make([]uint, 0, len(drawings))
+	var urls []string
+	for _, drawing := range drawings {
+		ids = append(ids, drawing.ID)
+		urls = append(urls, drawing.PosterImageURL)
+		var drawingURLs []string
+		if drawing.DrawingURLs != "" {
+			_ = json.Unmarshal([]byte(drawing.DrawingURLs), &drawingURLs)
+		}
+		urls = append(urls, drawingURLs...)
+	}
+
+	var renditions []system.SysImageRendition
+	err := global.GVA_DB.Where("owner_type = ? AND owner_id IN ?", renditionOwner(system.SysDrawing{}), ids).Find(&renditions).Error
+	if err != nil {
+		return err
+	}
+	var photoKeys []string
+	err = global.GVA_DB.Model(&system.SysDrawingCompletion{}).Where("drawing_id IN ? AND photo_key <> ''", ids).Pluck("photo_key", &photoKeys).Error
+	if err != nil {
+		return err
+	}
+
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&system.SysDrawingColor{},
+			&system.SysDrawingFieldValue{},
+			&system.SysDrawingImageHash{},
+			&system.SysDrawingGrant{},
+			&system.SysDrawingShare{},
+			&system.SysDrawingCompletion{},
+			&system.SysDrawingReview{},
+			&system.SysAccessRequest{},
+			&system.SysDrawingCollectionItem{},
+		} {
+			if err := tx.Unscoped().Where("drawing_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&system.SysDrawing{}, ids).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	ImageRenditionServiceApp.deleteRenditions(renditions)
+	oss := upload.NewOss()
+	for _, key := range photoKeys {
+		if err = oss.DeleteFile(key); err != nil {
+			global.GVA_LOG.Warn("删除成品照片失败", zap.String("key", key), zap.Error(err))
+		}
+	}
+	deleteUnreferencedFiles(urls)
+	return nil
+}
+
+// deleteUnreferencedFiles 删除不再被任何图纸或相册引用的上传文件
+// 复制的图纸与原图纸共用文件，仍有引用时保留；按文件库记录的 Key 删除存储文件
+func deleteUnreferencedFiles(urls []string) {
+	seen := make(map[string]bool, len(urls))
+	oss := upload.NewOss()
+	for _, u := range urls {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		referenced, err := fileReferenced(u)
+		if err != nil {
+			global.GVA_LOG.Warn("检查文件引用失败", zap.String("url", u), zap.Error(err))
+			continue
+		}
+		if referenced {
+			continue
+		}
+		var files []example.ExaFileUploadAndDownload
+		if err = global.GVA_DB.Where("url = ?", u).Find(&files).Error; err != nil {
+			global.GVA_LOG.Warn("查询文件记录失败", zap.String("url", u), zap.Error(err))
+			continue
+		}
+		for _, file := range files {
+			if err = oss.DeleteFile(file.Key); err != nil {
+				global.GVA_LOG.Warn("删除文件失败", zap.String("key", file.Key), zap.Error(err))
+				continue
+			}
+			if err = global.GVA_DB.Unscoped().Delete(&file).Error; err != nil {
+				global.GVA_LOG.Warn("删除文件记录失败", zap.Uint("id", file.ID), zap.Error(err))
+			}
+		}
+	}
+}
+
+// fileReferenced 判断文件是否仍被图纸（含回收站中的图纸）或相册引用
+func fileReferenced(u string) (bool, error) {
+	encoded, err := json.Marshal(u)
+	if err != nil {
+		return false, err
+	}
+	var count int64
+	err = global.GVA_DB.Unscoped().Model(&system.SysDrawing{}).
+		Where("poster_image_url = ? OR drawing_urls LIKE ?", u, "%"+string(encoded)+"%").
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = global.GVA_DB.Unscoped().Model(&system.SysAlbum{}).Where("cover_image_url = ?", u).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,583 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/search"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 全文检索使用进程内倒排索引（utils/search），启动时从数据库全量构建，
+// 图纸与相册增删改后异步增量同步；权限在检索时按数据库中的当前授权过滤
+type SearchService struct{}
+
+var SearchServiceApp = new(SearchService)
+
+const (
+	searchDefaultPageSize = 20
+	searchMaxPageSize     = 100
+	searchFacetLimit      = 20
+	searchHighlightRunes  = 80 // 高亮片段的最大字数
+	searchBatchSize       = 500
+	searchHighlightPre    = "<em>"
+	searchHighlightPost   = "</em>"
+)
+
+// 索引字段，名称、序号与相册标题权重更高
+const (
+	searchFieldSerial      = "serialNumber"
+	searchFieldName        = "name"
+	searchFieldAlbum       = "album"
+	searchFieldFields      = "fields"
+	searchFieldTitle       = "title"
+	searchFieldDescription = "description"
+)
+
+var (
+	searchIndex = search.NewIndex(map[string]float64{
+		searchFieldSerial: 3,
+		searchFieldName:   3,
+		searchFieldTitle:  3,
+	})
+	// searchSyncMu 串行化增量同步与重建完成时的索引替换
+	searchSyncMu sync.Mutex
+	// searchRebuilding 全量重建进行中时记录期间同步过的图纸与相册，替换索引后重新同步，避免变更被重建时读取的旧数据覆盖
+	searchRebuilding *searchRebuildChanges
+)
+
+// searchRebuildChanges 全量重建期间发生变更的图纸与相册ID
+type searchRebuildChanges struct {
+	drawings map[uint]bool
+	albums   map[uint]bool
+}
+
+func sortedIDs(set map[uint]bool) []uint {
+	ids := make([]uint, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// visibleAlbumsSQL 可检索到的相册：创建者、管理员或有效期内的成员
+const visibleAlbumsSQL = "sys_albums.creator_uuid = ?" +
+	" OR EXISTS (SELECT 1 FROM sys_album_admin aa JOIN sys_users u ON u.id = aa.user_id WHERE aa.album_id = sys_albums.id AND u.uuid = ?)" +
+	" OR EXISTS (SELECT 1 FROM sys_album_members am WHERE am.album_id = sys_albums.id AND am.user_uuid = ? AND (am.starts_at IS NULL OR am.starts_at <= ?) AND (am.expires_at IS NULL OR am.expires_at > ?))"
+
+func searchDocID(docType string, id uint) string {
+	return docType + ":" + strconv.FormatUint(uint64(id), 10)
+}
+
+func parseSearchDocID(docID string) (string, uint, bool) {
+	docType, rawID, ok := strings.Cut(docID, ":")
+	if !ok {
+		return "", 0, false
+	}
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return docType, uint(id), true
+}
+
+// drawingFieldsText 图纸自定义字段中的文本值（文本、枚举、日期）
+func drawingFieldsText(values []system.SysDrawingFieldValue) string {
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		if value.TextValue != "" {
+			texts = append(texts, value.TextValue)
+		}
+	}
+	return strings.Join(texts, " ")
+}
+
+// drawingSearchFields 图纸参与检索的字段，需预加载 Album 与 FieldValues
+func drawingSearchFields(drawing *system.SysDrawing) map[string]string {
+	return map[string]string{
+		searchFieldSerial: drawing.SerialNumber,
+		searchFieldName:   drawing.Name,
+		searchFieldAlbum:  drawing.Album.Title,
+		searchFieldFields: drawingFieldsText(drawing.FieldValues),
+	}
+}
+
+// albumSearchFields 相册参与检索的字段
+func albumSearchFields(album *system.SysAlbum) map[string]string {
+	return map[string]string{
+		searchFieldTitle:       album.Title,
+		searchFieldDescription: album.Description,
+	}
+}
+
+// searchHighlights 生成命中字段的高亮片段，未命中的字段不返回
+func searchHighlights(fields map[string]string, terms []string) map[string]string {
+	highlights := make(map[string]string)
+	for field, text := range fields {
+		if fragment := search.Highlight(text, terms, searchHighlightPre, searchHighlightPost, searchHighlightRunes); fragment != "" {
+			highlights[field] = fragment
+		}
+	}
+	return highlights
+}
+
+// QueueDrawings 异步同步图纸的检索索引，不阻塞保存请求
+func (searchService *SearchService) QueueDrawings(ids ...uint) {
+	if len(ids) == 0 {
+		return
+	}
+	go func() {
+		defer recoverSearchSync()
+		if err := searchService.SyncDrawings(ids); err != nil {
+			global.GVA_LOG.Warn("同步图纸检索索引失败", zap.Uints("drawing_ids", ids), zap.Error(err))
+		}
+	}()
+}
+
+// QueueAlbums 异步同步相册及其图纸的检索索引，不阻塞保存请求
+func (searchService *SearchService) QueueAlbums(ids ...uint) {
+	if len(ids) == 0 {
+		return
+	}
+	go func() {
+		defer recoverSearchSync()
+		if err := searchService.SyncAlbums(ids); err != nil {
+			global.GVA_LOG.Warn("同步相册检索索引失败", zap.Uints("album_ids", ids), zap.Error(err))
+		}
+	}()
+}
+
+// SyncDrawings 按数据库当前内容刷新图纸索引，已删除（含回收站中）的图纸从索引移除
+func (searchService *SearchService) SyncDrawings(ids []uint) error {
+	searchSyncMu.Lock()
+	defer searchSyncMu.Unlock()
+	if searchRebuilding != nil {
+		for _, id := range ids {
+			searchRebuilding.drawings[id] = true
+		}
+	}
+	return syncSearchDrawings(ids)
+}
+
+// SyncAlbums 按数据库当前内容刷新相册索引，图纸索引包含相册标题，因此同时刷新相册下的全部图纸
+func (searchService *SearchService) SyncAlbums(ids []uint) error {
+	searchSyncMu.Lock()
+	defer searchSyncMu.Unlock()
+	if searchRebuilding != nil {
+		for _, id := range ids {
+			searchRebuilding.albums[id] = true
+		}
+	}
+	return syncSearchAlbums(ids)
+}
+
+func syncSearchAlbums(ids []uint) error {
+	var albums []system.SysAlbum
+	if err := global.GVA_DB.Where("id IN ?", ids).Find(&albums).Error; err != nil {
+		return err
+	}
+	found := make(map[uint]bool, len(albums))
+	for i := range albums {
+		found[albums[i].ID] = true
+		searchIndex.Put(search.Document{ID: searchDocID(system.SearchTypeAlbum, albums[i].ID), Fields: albumSearchFields(&albums[i])})
+	}
+	for _, id := range ids {
+		if !found[id] {
+			searchIndex.Delete(searchDocID(system.SearchTypeAlbum, id))
+		}
+	}
+
+	var drawingIDs []uint
+	if err := global.GVA_DB.Unscoped().Model(&system.SysDrawing{}).Where("album_id IN ?", ids).Pluck("id", &drawingIDs).Error; err != nil {
+		return err
+	}
+	for start := 0; start < len(drawingIDs); start += searchBatchSize {
+		end := min(start+searchBatchSize, len(drawingIDs))
+		if err := syncSearchDrawings(drawingIDs[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncSearchDrawings(ids []uint) error {
+	var drawings []system.SysDrawing
+	if err := global.GVA_DB.Where("id IN ?", ids).Preload("Album").Preload("FieldValues").Find(&drawings).Error; err != nil {
+		return err
+	}
+	found := make(map[uint]bool, len(drawings))
+	for i := range drawings {
+		found[drawings[i].ID] = true
+		searchIndex.Put(search.Document{ID: searchDocID(system.SearchTypeDrawing, drawings[i].ID), Fields: drawingSearchFields(&drawings[i])})
+	}
+	for _, id := range ids {
+		if !found[id] {
+			searchIndex.Delete(searchDocID(system.SearchTypeDrawing, id))
+		}
+	}
+	return nil
+}
+
+// RebuildIndex 从数据库全量重建检索索引，返回索引的文档数
+// 扫描数据库期间不阻塞增量同步，扫描完成后在同步锁内替换索引，并重新同步扫描期间发生变更的图纸与相册
+func (searchService *SearchService) RebuildIndex() (int, error) {
+	searchSyncMu.Lock()
+	if searchRebuilding != nil {
+		searchSyncMu.Unlock()
+		return 0, errors.New("检索索引正在重建")
+	}
+	changes := &searchRebuildChanges{drawings: make(map[uint]bool), albums: make(map[uint]bool)}
+	searchRebuilding = changes
+	searchSyncMu.Unlock()
+
+	docs, err := loadSearchDocuments()
+
+	searchSyncMu.Lock()
+	defer searchSyncMu.Unlock()
+	searchRebuilding = nil
+	if err != nil {
+		return 0, err
+	}
+	searchIndex.Reset(docs)
+	if err = syncSearchAlbums(sortedIDs(changes.albums)); err != nil {
+		return 0, err
+	}
+	drawingIDs := sortedIDs(changes.drawings)
+	for start := 0; start < len(drawingIDs); start += searchBatchSize {
+		end := min(start+searchBatchSize, len(drawingIDs))
+		if err = syncSearchDrawings(drawingIDs[start:end]); err != nil {
+			return 0, err
+		}
+	}
+	return searchIndex.Len(), nil
+}
+
+// loadSearchDocuments 从数据库读取全部相册与图纸的检索文档
+func loadSearchDocuments() ([]search.Document, error) {
+	var docs []search.Document
+	var albums []system.SysAlbum
+	err := global.GVA_DB.FindInBatches(&albums, searchBatchSize, func(tx *gorm.DB, batch int) error {
+		for i := range albums {
+			docs = append(docs, search.Document{ID: searchDocID(system.SearchTypeAlbum, albums[i].ID), Fields: albumSearchFields(&albums[i])})
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var drawings []system.SysDrawing
+	err = global.GVA_DB.Preload("Album").Preload("FieldValues").FindInBatches(&drawings, searchBatchSize, func(tx *gorm.DB, batch int) error {
+		for i := range drawings {
+			docs = append(docs, search.Document{ID: searchDocID(system.SearchTypeDrawing, drawings[i].ID), Fields: drawingSearchFields(&drawings[i])})
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// QueueRebuild 异步全量重建检索索引，用于服务启动
+func (searchService *SearchService) QueueRebuild() {
+	go func() {
+		defer recoverSearchSync()
+		start := time.Now()
+		count, err := searchService.RebuildIndex()
+		if err != nil {
+			global.GVA_LOG.Error("构建检索索引失败", zap.Error(err))
+			return
+		}
+		global.GVA_LOG.Info("检索索引构建完成", zap.Int("documents", count), zap.Duration("elapsed", time.Since(start)))
+	}()
+}
+
+// searchCandidate 通过权限过滤的命中项及其分面属性
+type searchCandidate struct {
+	docType     string
+	id          uint
+	score       float64
+	albumID     uint
+	creatorUUID uuid.UUID
+}
+
+type searchMeta struct {
+	ID          uint
+	AlbumID     uint
+	CreatorUUID uuid.UUID
+}
+
+// Search 全文检索图纸与相册，只返回当前用户可访问的结果，附带高亮片段与按相册、创建者的分面统计
+func (searchService *SearchService) Search(req request.SearchQuery, userUUID uuid.UUID) (result systemRes.SearchResult, err error) {
+	terms := search.Terms(req.Keyword)
+	if len(terms) == 0 {
+		return result, errors.New("请输入检索关键字")
+	}
+	if req.Type != "" && req.Type != system.SearchTypeDrawing && req.Type != system.SearchTypeAlbum {
+		return result, fmt.Errorf("不支持的结果类型: %s", req.Type)
+	}
+
+	scores := make(map[string]float64)
+	var drawingIDs, albumIDs []uint
+	for _, hit := range searchIndex.Search(terms) {
+		docType, id, ok := parseSearchDocID(hit.ID)
+		if !ok || (req.Type != "" && docType != req.Type) {
+			continue
+		}
+		scores[hit.ID] = hit.Score
+		switch docType {
+		case system.SearchTypeDrawing:
+			drawingIDs = append(drawingIDs, id)
+		case system.SearchTypeAlbum:
+			albumIDs = append(albumIDs, id)
+		}
+	}
+
+	candidates, err := searchCandidates(drawingIDs, albumIDs, userUUID, scores)
+	if err != nil {
+		return result, err
+	}
+
+	// 分面统计：每个分面只应用另一个维度的筛选条件
+	albumCounts := make(map[uint]int)
+	creatorCounts := make(map[uuid.UUID]int)
+	var matched []searchCandidate
+	for _, candidate := range candidates {
+		albumOK := req.AlbumID == 0 || candidate.albumID == req.AlbumID
+		creatorOK := req.CreatorUUID == uuid.Nil || candidate.creatorUUID == req.CreatorUUID
+		if creatorOK {
+			albumCounts[candidate.albumID]++
+		}
+		if albumOK {
+			creatorCounts[candidate.creatorUUID]++
+		}
+		if albumOK && creatorOK {
+			matched = append(matched, candidate)
+		}
+	}
+	if result.Facets, err = searchFacets(albumCounts, creatorCounts); err != nil {
+		return result, err
+	}
+
+	result.Page, result.PageSize = req.Page, req.PageSize
+	if result.Page <= 0 {
+		result.Page = 1
+	}
+	if result.PageSize <= 0 {
+		result.PageSize = searchDefaultPageSize
+	}
+	if result.PageSize > searchMaxPageSize {
+		result.PageSize = searchMaxPageSize
+	}
+	result.Total = int64(len(matched))
+	start := min((result.Page-1)*result.PageSize, len(matched))
+	end := min(start+result.PageSize, len(matched))
+	result.List, err = searchHits(matched[start:end], terms)
+	return result, err
+}
+
+// searchCandidates 按权限过滤命中的图纸与相册并按得分排序
+func searchCandidates(drawingIDs, albumIDs []uint, userUUID uuid.UUID, scores map[string]float64) ([]searchCandidate, error) {
+	var candidates []searchCandidate
+	for start := 0; start < len(drawingIDs); start += searchBatchSize {
+		end := min(start+searchBatchSize, len(drawingIDs))
+		var metas []searchMeta
+		err := global.GVA_DB.Model(&system.SysDrawing{}).
+			Select("sys_drawings.id, sys_drawings.album_id, sys_drawings.creator_uuid").
+			Where("sys_drawings.id IN ?", drawingIDs[start:end]).
+			Scopes(visibleDrawingsScope(userUUID)).
+			Scan(&metas).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, meta := range metas {
+			candidates = append(candidates, searchCandidate{
+				docType:     system.SearchTypeDrawing,
+				id:          meta.ID,
+				score:       scores[searchDocID(system.SearchTypeDrawing, meta.ID)],
+				albumID:     meta.AlbumID,
+				creatorUUID: meta.CreatorUUID,
+			})
+		}
+	}
+
+	now := time.Now()
+	for start := 0; start < len(albumIDs); start += searchBatchSize {
+		end := min(start+searchBatchSize, len(albumIDs))
+		var metas []searchMeta
+		err := global.GVA_DB.Model(&system.SysAlbum{}).
+			Select("sys_albums.id, sys_albums.id AS album_id, sys_albums.creator_uuid").
+			Where("sys_albums.id IN ?", albumIDs[start:end]).
+			Where(visibleAlbumsSQL, userUUID, userUUID, userUUID, now, now).
+			Scan(&metas).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, meta := range metas {
+			candidates = append(candidates, searchCandidate{
+				docType:     system.SearchTypeAlbum,
+				id:          meta.ID,
+				score:       scores[searchDocID(system.SearchTypeAlbum, meta.ID)],
+				albumID:     meta.AlbumID,
+				creatorUUID: meta.CreatorUUID,
+			})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].docType != candidates[j].docType {
+			return candidates[i].docType < candidates[j].docType
+		}
+		return candidates[i].id < candidates[j].id
+	})
+	return candidates, nil
+}
+
+// searchFacets 生成分面统计，按命中数倒序，各取前 searchFacetLimit 项
+func searchFacets(albumCounts map[uint]int, creatorCounts map[uuid.UUID]int) (systemRes.SearchFacets, error) {
+	facets := systemRes.SearchFacets{
+		Albums:   make([]systemRes.SearchFacet, 0, len(albumCounts)),
+		Creators: make([]systemRes.SearchFacet, 0, len(creatorCounts)),
+	}
+
+	if len(albumCounts) > 0 {
+		ids := make([]uint, 0, len(albumCounts))
+		for id := range albumCounts {
+			ids = append(ids, id)
+		}
+		var albums []system.SysAlbum
+		if err := global.GVA_DB.Select("id, title").Where("id IN ?", ids).Find(&albums).Error; err != nil {
+			return facets, err
+		}
+		for _, album := range albums {
+			facets.Albums = append(facets.Albums, systemRes.SearchFacet{
+				Value: strconv.FormatUint(uint64(album.ID), 10),
+				Label: album.Title,
+				Count: albumCounts[album.ID],
+			})
+		}
+	}
+
+	if len(creatorCounts) > 0 {
+		uuids := make([]uuid.UUID, 0, len(creatorCounts))
+		for id := range creatorCounts {
+			uuids = append(uuids, id)
+		}
+		var users []system.SysUser
+		if err := global.GVA_DB.Select("uuid, username, nick_name").Where("uuid IN ?", uuids).Find(&users).Error; err != nil {
+			return facets, err
+		}
+		for _, user := range users {
+			label := user.NickName
+			if label == "" {
+				label = user.Username
+			}
+			facets.Creators = append(facets.Creators, systemRes.SearchFacet{
+				Value: user.UUID.String(),
+				Label: label,
+				Count: creatorCounts[user.UUID],
+			})
+		}
+	}
+
+	facets.Albums = topSearchFacets(facets.Albums)
+	facets.Creators = topSearchFacets(facets.Creators)
+	return facets, nil
+}
+
+func topSearchFacets(facets []systemRes.SearchFacet) []systemRes.SearchFacet {
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Label < facets[j].Label
+	})
+	if len(facets) > searchFacetLimit {
+		facets = facets[:searchFacetLimit]
+	}
+	return facets
+}
+
+// searchHits 加载当前页命中的图纸与相册，按数据库中的最新内容生成高亮片段
+func searchHits(page []searchCandidate, terms []string) ([]systemRes.SearchHit, error) {
+	var drawingIDs, albumIDs []uint
+	for _, candidate := range page {
+		if candidate.docType == system.SearchTypeDrawing {
+			drawingIDs = append(drawingIDs, candidate.id)
+		} else {
+			albumIDs = append(albumIDs, candidate.id)
+		}
+	}
+
+	drawings := make(map[uint]*system.SysDrawing, len(drawingIDs))
+	if len(drawingIDs) > 0 {
+		var list []*system.SysDrawing
+		err := global.GVA_DB.Where("id IN ?", drawingIDs).
+			Preload("Album").Preload("Creator").Preload("Renditions").Preload("FieldValues.Field").
+			Find(&list).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, drawing := range list {
+			drawings[drawing.ID] = drawing
+		}
+	}
+	albums := make(map[uint]*system.SysAlbum, len(albumIDs))
+	if len(albumIDs) > 0 {
+		var list []*system.SysAlbum
+		if err := global.GVA_DB.Where("id IN ?", albumIDs).Preload("Creator").Preload("Renditions").Find(&list).Error; err != nil {
+			return nil, err
+		}
+		for _, album := range list {
+			albums[album.ID] = album
+		}
+	}
+
+	hits := make([]systemRes.SearchHit, 0, len(page))
+	for _, candidate := range page {
+		hit := systemRes.SearchHit{Type: candidate.docType, ID: candidate.id, Score: candidate.score}
+		switch candidate.docType {
+		case system.SearchTypeDrawing:
+			drawing, ok := drawings[candidate.id]
+			if !ok {
+				continue
+			}
+			item := systemRes.ToDrawingResponse(drawing)
+			hit.Drawing = &item
+			hit.Highlights = searchHighlights(drawingSearchFields(drawing), terms)
+		case system.SearchTypeAlbum:
+			album, ok := albums[candidate.id]
+			if !ok {
+				continue
+			}
+			item := systemRes.ToAlbumResponse(*album)
+			hit.Album = &item
+			hit.Highlights = searchHighlights(albumSearchFields(album), terms)
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+func recoverSearchSync() {
+	if r := recover(); r != nil {
+		global.GVA_LOG.Error("同步检索索引异常", zap.Any("panic", r), zap.String("stack", string(debug.Stack())))
+	}
+}
@@ -230,6 +230,127 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/mustRead/get", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/mustRead/latest", V2: "GET"},
 
+		// 拼豆库存权限 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/inventory/create", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/inventory/update", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/inventory/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/inventory/import", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/inventory/list", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/inventory/match", V2: "POST"},
+
+		// 以图搜图权限 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/similar", V2: "POST"},
+
+		// 相册自定义字段 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/album/fields/save", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/album/fields/list", V2: "POST"},
+
+		// 图纸完成记录 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/completion/create", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/completion/update", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/completion/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/completion/moderate", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/completion/gallery", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/completion/my", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/getUserCompletions/:id", V2: "GET"},
+
+		// 相册成员 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/album/members/add", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/album/members/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/album/members/list", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/album/members/explain", V2: "POST"},
+
+		// 图纸限时授权 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/grants/add", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/drawing/grants/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/drawing/grants/list", V2: "POST"},
+
+		// 访问申请与站内通知 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/accessRequest/create", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/accessRequest/decide", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/accessRequest/my", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/accessRequest/list", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/notification/list", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/notification/read", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/notification/unreadCount", V2: "GET"},
+
+		// 相册邀请链接 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/album/invites/create", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/album/invites/revoke", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/album/invites/redeem", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/album/invites/list", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/album/invites/redemptions", V2: "POST"},
+
+		// 图纸分享链接 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/shares/create", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/drawing/shares/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/drawing/shares/list", V2: "POST"},
+
+		// 图纸移动与复制 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/move", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/drawing/copy", V2: "POST"},
+
+		// 相册编号规则 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/album/serialScheme/save", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/album/serialScheme/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/album/serialScheme/get", V2: "POST"},
+
+		// 回收站 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/recycleBin/list", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/recycleBin/restore", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/recycleBin/purge", V2: "DELETE"},
+
+		// 图纸审核发布 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/review/submit", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/drawing/review/decide", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/drawing/review/archive", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/drawing/review/queue", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/drawing/review/history", V2: "POST"},
+
+		// 图纸排序 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/reorder", V2: "PUT"},
+
+		// 相册排序 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/album/reorder", V2: "PUT"},
+
+		// 下载统计 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/analytics/downloads/series", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/analytics/downloads/top", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/analytics/downloads/summary", V2: "POST"},
+
+		// 下载审计 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/downloadAudit/search", V2: "POST"},
+
+		// 下载额度管理 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/downloadQuota/usage", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/downloadQuota/reset", V2: "POST"},
+
+		// 图纸推荐 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/feed", V2: "POST"},
+
+		// 图纸关联推荐 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/drawing/related", V2: "POST"},
+
+		// 全文检索 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/search/query", V2: "POST"},
+
+		// 重建检索索引 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/search/rebuild", V2: "POST"},
+
+		// 图纸收藏夹 - 角色888（超级管理员）
+		{Ptype: "p", V0: "888", V1: "/collection/create", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/collection/update", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/collection/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/collection/list", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/collection/drawings", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/collection/drawings/add", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/collection/drawings/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/collection/drawings/reorder", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/collection/share", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/collection/unshare", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/collection/favorite", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/collection/download", V2: "POST"},
+
 		{Ptype: "p", V0: "8881", V1: "/user/admin_register", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/createApi", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/getApiList", V2: "POST"},
@@ -291,6 +412,110 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "8881", V1: "/drawing/download", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/drawing/batchDownload", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/drawing/my", V2: "POST"},
+
+		// 拼豆库存权限 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/inventory/create", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/inventory/update", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/inventory/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/inventory/import", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/inventory/list", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/inventory/match", V2: "POST"},
+
+		// 以图搜图权限 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/similar", V2: "POST"},
+
+		// 相册自定义字段 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/album/fields/save", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/album/fields/list", V2: "POST"},
+
+		// 图纸完成记录 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/completion/create", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/completion/update", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/completion/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/completion/moderate", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/completion/gallery", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/completion/my", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/user/getUserCompletions/:id", V2: "GET"},
+
+		// 相册成员 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/album/members/add", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/album/members/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/album/members/list", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/album/members/explain", V2: "POST"},
+
+		// 图纸限时授权 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/grants/add", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/grants/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/grants/list", V2: "POST"},
+
+		// 访问申请与站内通知 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/accessRequest/create", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/accessRequest/decide", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/accessRequest/my", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/accessRequest/list", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/notification/list", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/notification/read", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/notification/unreadCount", V2: "GET"},
+
+		// 相册邀请链接 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/album/invites/create", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/album/invites/revoke", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/album/invites/redeem", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/album/invites/list", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/album/invites/redemptions", V2: "POST"},
+
+		// 图纸分享链接 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/shares/create", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/shares/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/shares/list", V2: "POST"},
+
+		// 图纸移动与复制 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/move", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/copy", V2: "POST"},
+
+		// 相册编号规则 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/album/serialScheme/save", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/album/serialScheme/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/album/serialScheme/get", V2: "POST"},
+
+		// 图纸审核发布 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/review/submit", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/review/decide", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/review/archive", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/review/queue", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/drawing/review/history", V2: "POST"},
+
+		// 图纸排序 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/reorder", V2: "PUT"},
+
+		// 下载统计 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/analytics/downloads/series", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/analytics/downloads/top", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/analytics/downloads/summary", V2: "POST"},
+
+		// 图纸推荐 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/feed", V2: "POST"},
+
+		// 图纸关联推荐 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/drawing/related", V2: "POST"},
+
+		// 全文检索 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/search/query", V2: "POST"},
+
+		// 图纸收藏夹 - 角色8881（普通用户）
+		{Ptype: "p", V0: "8881", V1: "/collection/create", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/collection/update", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/collection/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/collection/list", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/collection/drawings", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/collection/drawings/add", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/collection/drawings/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/collection/drawings/reorder", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/collection/share", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/collection/unshare", V2: "DELETE"},
+		{Ptype: "p", V0: "8881", V1: "/collection/favorite", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/collection/download", V2: "POST"},
+
 		{Ptype: "p", V0: "9528", V1: "/user/admin_register", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/api/createApi", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/api/getApiList", V2: "POST"},
@@ -356,6 +581,109 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "9528", V1: "/drawing/downloadStatus", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/mustRead/get", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/mustRead/latest", V2: "GET"},
+
+		// 拼豆库存权限 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/inventory/create", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/inventory/update", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/inventory/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/inventory/import", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/inventory/list", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/inventory/match", V2: "POST"},
+
+		// 以图搜图权限 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/similar", V2: "POST"},
+
+		// 相册自定义字段 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/album/fields/save", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/album/fields/list", V2: "POST"},
+
+		// 图纸完成记录 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/completion/create", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/completion/update", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/completion/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/completion/moderate", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/completion/gallery", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/completion/my", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/user/getUserCompletions/:id", V2: "GET"},
+
+		// 相册成员 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/album/members/add", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/album/members/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/album/members/list", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/album/members/explain", V2: "POST"},
+
+		// 图纸限时授权 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/grants/add", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/grants/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/grants/list", V2: "POST"},
+
+		// 访问申请与站内通知 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/accessRequest/create", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/accessRequest/decide", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/accessRequest/my", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/accessRequest/list", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/notification/list", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/notification/read", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/notification/unreadCount", V2: "GET"},
+
+		// 相册邀请链接 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/album/invites/create", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/album/invites/revoke", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/album/invites/redeem", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/album/invites/list", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/album/invites/redemptions", V2: "POST"},
+
+		// 图纸分享链接 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/shares/create", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/shares/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/shares/list", V2: "POST"},
+
+		// 图纸移动与复制 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/move", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/copy", V2: "POST"},
+
+		// 相册编号规则 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/album/serialScheme/save", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/album/serialScheme/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/album/serialScheme/get", V2: "POST"},
+
+		// 图纸审核发布 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/review/submit", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/review/decide", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/review/archive", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/review/queue", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/drawing/review/history", V2: "POST"},
+
+		// 图纸排序 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/reorder", V2: "PUT"},
+
+		// 下载统计 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/analytics/downloads/series", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/analytics/downloads/top", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/analytics/downloads/summary", V2: "POST"},
+
+		// 图纸推荐 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/feed", V2: "POST"},
+
+		// 图纸关联推荐 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/drawing/related", V2: "POST"},
+
+		// 全文检索 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/search/query", V2: "POST"},
+
+		// 图纸收藏夹 - 角色9528（测试角色）
+		{Ptype: "p", V0: "9528", V1: "/collection/create", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/collection/update", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/collection/delete", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/collection/list", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/collection/drawings", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/collection/drawings/add", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/collection/drawings/remove", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/collection/drawings/reorder", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/collection/share", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/collection/unshare", V2: "DELETE"},
+		{Ptype: "p", V0: "9528", V1: "/collection/favorite", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/collection/download", V2: "POST"},
 	}
 	if err := db.Create(&entities).Error; err != nil {
 		return ctx, errors.Wrap(err, "Casbin 表 ("+i.InitializerName()+") 数据初始化失败!")
@@ -0,0 +1,29 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// RevokeExpiredAccessGrants 回收已过期的相册成员与图纸限时授权
+func RevokeExpiredAccessGrants() {
+	albumRevoked, drawingRevoked, err := service.ServiceGroupApp.SystemServiceGroup.AccessGrantService.RevokeExpiredGrants()
+	if err != nil {
+		global.GVA_LOG.Error("回收过期授权失败", zap.Error(err))
+		return
+	}
+	if albumRevoked > 0 || drawingRevoked > 0 {
+		global.GVA_LOG.Info("回收过期授权完成", zap.Int("album", albumRevoked), zap.Int("drawing", drawingRevoked))
+	}
+}
+
+// RemindExpiringAccessGrants 对即将到期的授权发送提醒
+func RemindExpiringAccessGrants() {
+	reminded, err := service.ServiceGroupApp.SystemServiceGroup.AccessGrantService.RemindExpiringGrants(global.GVA_CONFIG.AccessGrant.RemindDays)
+	if err != nil {
+		global.GVA_LOG.Error("发送授权到期提醒失败", zap.Error(err))
+		return
+	}
+	global.GVA_LOG.Info("授权到期提醒完成", zap.Int("reminded", reminded))
+}
@@ -0,0 +1,20 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// CompactDownloadHistories 将超过保留期的原始下载历史压缩为用户-图纸汇总，按配置归档后删除
+func CompactDownloadHistories() {
+	cfg := global.GVA_CONFIG.DownloadHistory
+	n, archive, err := service.ServiceGroupApp.SystemServiceGroup.DownloadHistoryService.CompactExpired(cfg.RetentionDays, cfg.Archive, cfg.ArchivePath)
+	if err != nil {
+		global.GVA_LOG.Error("压缩下载历史失败", zap.Int("compacted", n), zap.Error(err))
+		return
+	}
+	if n > 0 {
+		global.GVA_LOG.Info("压缩下载历史完成", zap.Int("compacted", n), zap.String("archive", archive))
+	}
+}
@@ -0,0 +1,19 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// RollupDownloads 将新增下载历史增量汇总到每日下载统计表
+func RollupDownloads() {
+	processed, err := service.ServiceGroupApp.SystemServiceGroup.DownloadAnalyticsService.RollupDownloads()
+	if err != nil {
+		global.GVA_LOG.Error("汇总下载统计失败", zap.Error(err), zap.Int("processed", processed))
+		return
+	}
+	if processed > 0 {
+		global.GVA_LOG.Info("汇总下载统计完成", zap.Int("processed", processed))
+	}
+}
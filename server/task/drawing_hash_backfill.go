@@ -0,0 +1,17 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// BackfillDrawingHashes 为尚未计算感知哈希的历史图纸补齐图片哈希
+func BackfillDrawingHashes() {
+	processed, err := service.ServiceGroupApp.SystemServiceGroup.DrawingService.BackfillDrawingHashes(100)
+	if err != nil {
+		global.GVA_LOG.Error("补齐图片哈希失败", zap.Error(err))
+		return
+	}
+	global.GVA_LOG.Info("补齐图片哈希完成", zap.Int("processed", processed))
+}
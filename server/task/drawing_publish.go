@@ -0,0 +1,19 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// PublishScheduledDrawings 发布已到定时发布时间的图纸
+func PublishScheduledDrawings() {
+	published, err := service.ServiceGroupApp.SystemServiceGroup.DrawingReviewService.PublishScheduled()
+	if err != nil {
+		global.GVA_LOG.Error("定时发布图纸失败", zap.Error(err), zap.Int("published", published))
+		return
+	}
+	if published > 0 {
+		global.GVA_LOG.Info("定时发布图纸完成", zap.Int("published", published))
+	}
+}
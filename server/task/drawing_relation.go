@@ -0,0 +1,17 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// RebuildDrawingRelations 根据下载共现重新计算图纸关联推荐
+func RebuildDrawingRelations() {
+	n, err := service.ServiceGroupApp.SystemServiceGroup.DrawingRelationService.RebuildRelations()
+	if err != nil {
+		global.GVA_LOG.Error("计算图纸关联推荐失败", zap.Error(err))
+		return
+	}
+	global.GVA_LOG.Info("计算图纸关联推荐完成", zap.Int("relation", n))
+}
@@ -0,0 +1,17 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// RecomputeDrawingTrends 根据每日下载汇总重新计算图纸热度
+func RecomputeDrawingTrends() {
+	n, err := service.ServiceGroupApp.SystemServiceGroup.DrawingTrendService.RecomputeTrends()
+	if err != nil {
+		global.GVA_LOG.Error("计算图纸热度失败", zap.Error(err))
+		return
+	}
+	global.GVA_LOG.Debug("计算图纸热度完成", zap.Int("drawing", n))
+}
@@ -0,0 +1,17 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// BackfillImageRenditions 为历史相册封面、图纸海报与图纸图片补齐缺失的衍生图
+func BackfillImageRenditions() {
+	processed, err := service.ServiceGroupApp.SystemServiceGroup.ImageRenditionService.BackfillRenditions(100)
+	if err != nil {
+		global.GVA_LOG.Error("补齐衍生图失败", zap.Error(err))
+		return
+	}
+	global.GVA_LOG.Info("补齐衍生图完成", zap.Int("processed", processed))
+}
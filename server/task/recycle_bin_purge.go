@@ -0,0 +1,19 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// PurgeRecycleBin 彻底删除回收站中超过保留期的相册与图纸
+func PurgeRecycleBin() {
+	albums, drawings, err := service.ServiceGroupApp.SystemServiceGroup.RecycleBinService.PurgeExpired(global.GVA_CONFIG.RecycleBin.RetentionDays)
+	if err != nil {
+		global.GVA_LOG.Error("清理回收站失败", zap.Error(err))
+		return
+	}
+	if albums > 0 || drawings > 0 {
+		global.GVA_LOG.Info("清理回收站完成", zap.Int("album", albums), zap.Int("drawing", drawings))
+	}
+}
@@ -0,0 +1,17 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"go.uber.org/zap"
+)
+
+// RebuildSearchIndex 从数据库全量重建检索索引，修正多实例部署时各实例增量同步的差异
+func RebuildSearchIndex() {
+	n, err := service.ServiceGroupApp.SystemServiceGroup.SearchService.RebuildIndex()
+	if err != nil {
+		global.GVA_LOG.Error("重建检索索引失败", zap.Error(err))
+		return
+	}
+	global.GVA_LOG.Info("重建检索索引完成", zap.Int("documents", n))
+}
@@ -0,0 +1,59 @@
+package imagehash
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"math/bits"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+// hashWidth/hashHeight 差值哈希的采样尺寸，宽度比高度多1列用于相邻像素比较
+const (
+	hashWidth  = 9
+	hashHeight = 8
+)
+
+// DHash 计算图片的差值哈希（dHash），返回64位哈希
+func DHash(img image.Image) uint64 {
+	small := imaging.Grayscale(imaging.Resize(img, hashWidth, hashHeight, imaging.Lanczos))
+
+	var hash uint64
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth-1; x++ {
+			left := small.Pix[small.PixOffset(x, y)]
+			right := small.Pix[small.PixOffset(x+1, y)]
+			hash <<= 1
+			if left < right {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// DHashReader 从图片数据流计算差值哈希
+func DHashReader(r io.Reader) (uint64, error) {
+	img, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return 0, err
+	}
+	return DHash(img), nil
+}
+
+// Distance 计算两个哈希的汉明距离
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Format 将哈希格式化为16位十六进制字符串，便于跨数据库存储
+func Format(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// Parse 解析 Format 生成的十六进制哈希
+func Parse(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
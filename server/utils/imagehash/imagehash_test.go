@@ -0,0 +1,42 @@
+package imagehash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func gradient(w, h int, reverse bool) image.Image {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(x * 255 / (w - 1))
+			if reverse {
+				v = 255 - v
+			}
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+	return img
+}
+
+func TestDHash_SimilarImages(t *testing.T) {
+	small := DHash(gradient(90, 80, false))
+	large := DHash(gradient(900, 800, false))
+	if d := Distance(small, large); d > 4 {
+		t.Errorf("resized image distance = %d, want <= 4", d)
+	}
+
+	reversed := DHash(gradient(90, 80, true))
+	if d := Distance(small, reversed); d < 32 {
+		t.Errorf("reversed image distance = %d, want >= 32", d)
+	}
+}
+
+func TestFormatParse(t *testing.T) {
+	const hash uint64 = 0x8f00ff00aa5500ff
+	got, err := Parse(Format(hash))
+	if err != nil || got != hash {
+		t.Errorf("Parse(Format()) = %x, %v, want %x", got, err, hash)
+	}
+}
@@ -0,0 +1,158 @@
+package imageproxy
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache 缩放结果磁盘缓存，总大小超过上限时按最近访问时间淘汰
+type Cache struct {
+	dir      string
+	maxBytes int64
+
+	mu     sync.Mutex
+	size   int64
+	loaded bool
+}
+
+// NewCache 创建磁盘缓存，maxBytes <= 0 表示不限制大小
+func NewCache(dir string, maxBytes int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir, maxBytes: maxBytes}, nil
+}
+
+// Name 生成缓存文件名
+func Name(key string, opts Options, ext string) string {
+	sum := md5.Sum([]byte(key + "|" + opts.String()))
+	return hex.EncodeToString(sum[:]) + ext
+}
+
+// Get 读取缓存，命中时刷新访问时间
+func (c *Cache) Get(name string) ([]byte, bool) {
+	p := filepath.Join(c.dir, name)
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+	return data, true
+}
+
+// Put 写入缓存，写入后超过容量上限时淘汰最久未访问的文件
+func (c *Cache) Put(name string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.load(); err != nil {
+		return err
+	}
+
+	p := filepath.Join(c.dir, name)
+	var old int64
+	if info, err := os.Stat(p); err == nil {
+		old = info.Size()
+	}
+
+	// 先写临时文件再重命名，避免并发读取到半截文件
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Rename(tmp.Name(), p); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	c.size += int64(len(data)) - old
+	if c.maxBytes > 0 && c.size > c.maxBytes {
+		return c.evict()
+	}
+	return nil
+}
+
+// Size 当前缓存总大小
+func (c *Cache) Size() (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.load(); err != nil {
+		return 0, err
+	}
+	return c.size, nil
+}
+
+// load 首次使用时统计已有缓存大小
+func (c *Cache) load() error {
+	if c.loaded {
+		return nil
+	}
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	var size int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+	}
+	c.size = size
+	c.loaded = true
+	return nil
+}
+
+// evict 淘汰最久未访问的文件，直到总大小降到上限的 90% 以下
+func (c *Cache) evict() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	files := make([]os.FileInfo, 0, len(entries))
+	var size int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		size += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	target := c.maxBytes * 9 / 10
+	for _, info := range files {
+		if size <= target {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, info.Name())); err != nil {
+			continue
+		}
+		size -= info.Size()
+	}
+	c.size = size
+	return nil
+}
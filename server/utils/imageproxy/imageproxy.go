@@ -0,0 +1,144 @@
+package imageproxy
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/rendition"
+)
+
+// 缩放方式
+const (
+	FitContain = "contain" // 等比缩放至边界内
+	FitCover   = "cover"   // 等比缩放并居中裁剪填满
+	FitFill    = "fill"    // 拉伸至指定尺寸
+)
+
+// DefaultQuality 未指定质量时使用的JPEG质量
+const DefaultQuality = 85
+
+// Options 单次缩放参数
+type Options struct {
+	Width   int    // 目标宽度，0 表示按比例
+	Height  int    // 目标高度，0 表示按比例
+	Fit     string // 缩放方式
+	Quality int    // JPEG质量
+}
+
+// String 缩放参数的规范化表示，用于生成缓存键
+func (o Options) String() string {
+	return fmt.Sprintf("%dx%d_%s_q%d", o.Width, o.Height, o.Fit, o.Quality)
+}
+
+// Whitelist 允许的尺寸与质量白名单，防止任意参数刷爆缓存
+type Whitelist struct {
+	sizes     map[[2]int]bool
+	qualities map[int]bool
+}
+
+// NewWhitelist 根据配置创建白名单，sizes 格式为 "宽x高"
+func NewWhitelist(sizes []string, qualities []int) (*Whitelist, error) {
+	w := &Whitelist{
+		sizes:     make(map[[2]int]bool, len(sizes)),
+		qualities: make(map[int]bool, len(qualities)),
+	}
+	for _, size := range sizes {
+		width, height, err := parseSize(size)
+		if err != nil {
+			return nil, err
+		}
+		w.sizes[[2]int{width, height}] = true
+	}
+	for _, q := range qualities {
+		w.qualities[q] = true
+	}
+	return w, nil
+}
+
+func parseSize(size string) (int, int, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(size)), "x")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("非法的尺寸配置: %s", size)
+	}
+	width, err1 := strconv.Atoi(parts[0])
+	height, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || width < 0 || height < 0 || (width == 0 && height == 0) {
+		return 0, 0, fmt.Errorf("非法的尺寸配置: %s", size)
+	}
+	return width, height, nil
+}
+
+// ParseOptions 解析并校验请求参数，w/h/fit/q 为空时使用默认值
+func (w *Whitelist) ParseOptions(width, height, fit, quality string) (Options, error) {
+	var opts Options
+	var err error
+	if width != "" {
+		if opts.Width, err = strconv.Atoi(width); err != nil {
+			return opts, errors.New("非法的宽度参数")
+		}
+	}
+	if height != "" {
+		if opts.Height, err = strconv.Atoi(height); err != nil {
+			return opts, errors.New("非法的高度参数")
+		}
+	}
+	if !w.sizes[[2]int{opts.Width, opts.Height}] {
+		return opts, errors.New("不支持的图片尺寸")
+	}
+
+	switch fit {
+	case "":
+		opts.Fit = FitContain
+	case FitContain, FitCover, FitFill:
+		opts.Fit = fit
+	default:
+		return opts, errors.New("不支持的缩放方式")
+	}
+	// 裁剪与拉伸需要同时指定宽高
+	if opts.Fit != FitContain && (opts.Width == 0 || opts.Height == 0) {
+		opts.Fit = FitContain
+	}
+
+	opts.Quality = DefaultQuality
+	if quality != "" {
+		if opts.Quality, err = strconv.Atoi(quality); err != nil || !w.qualities[opts.Quality] {
+			return opts, errors.New("不支持的图片质量")
+		}
+	}
+	return opts, nil
+}
+
+// Process 读取原图并按参数缩放，format 为输出格式（rendition.FormatJPEG/FormatPNG）
+func Process(r io.Reader, opts Options, format string) ([]byte, error) {
+	src, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, err
+	}
+	return rendition.Encode(resize(src, opts), format, opts.Quality)
+}
+
+func resize(src image.Image, opts Options) image.Image {
+	bounds := src.Bounds()
+	// 不放大原图
+	if opts.Width >= bounds.Dx() && opts.Height >= bounds.Dy() {
+		return src
+	}
+	switch opts.Fit {
+	case FitCover:
+		return imaging.Fill(src, opts.Width, opts.Height, imaging.Center, imaging.Lanczos)
+	case FitFill:
+		return imaging.Resize(src, opts.Width, opts.Height, imaging.Lanczos)
+	}
+	if opts.Width == 0 || opts.Height == 0 {
+		if (opts.Width == 0 || opts.Width >= bounds.Dx()) && (opts.Height == 0 || opts.Height >= bounds.Dy()) {
+			return src
+		}
+		return imaging.Resize(src, opts.Width, opts.Height, imaging.Lanczos)
+	}
+	return imaging.Fit(src, opts.Width, opts.Height, imaging.Lanczos)
+}
@@ -0,0 +1,72 @@
+package imageproxy
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWhitelist_ParseOptions(t *testing.T) {
+	w, err := NewWhitelist([]string{"320x0", "200x200"}, []int{75})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name                  string
+		width, height, fit, q string
+		wantErr               bool
+		wantFit               string
+		wantQuality           int
+	}{
+		{name: "仅宽度", width: "320", wantFit: FitContain, wantQuality: DefaultQuality},
+		{name: "裁剪", width: "200", height: "200", fit: FitCover, q: "75", wantFit: FitCover, wantQuality: 75},
+		{name: "单边裁剪退化为等比", width: "320", fit: FitCover, wantFit: FitContain, wantQuality: DefaultQuality},
+		{name: "尺寸不在白名单", width: "321", wantErr: true},
+		{name: "质量不在白名单", width: "320", q: "99", wantErr: true},
+		{name: "非法缩放方式", width: "320", fit: "zoom", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := w.ParseOptions(tt.width, tt.height, tt.fit, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (opts.Fit != tt.wantFit || opts.Quality != tt.wantQuality) {
+				t.Errorf("ParseOptions() = %+v", opts)
+			}
+		})
+	}
+}
+
+func TestCache_Evict(t *testing.T) {
+	c, err := NewCache(t.TempDir(), 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 100)
+	if err = c.Put("a", data); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Put("b", data); err != nil {
+		t.Fatal(err)
+	}
+	// 让 a 成为最近访问的文件
+	old := time.Now().Add(-time.Hour)
+	_ = os.Chtimes(c.dir+"/b", old, old)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) miss")
+	}
+	if err = c.Put("c", data); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("b should be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("a should be kept")
+	}
+	if size, _ := c.Size(); size > 250 {
+		t.Errorf("Size() = %d, want <= 250", size)
+	}
+}
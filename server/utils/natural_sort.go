@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// naturalSortDigits 自然排序键中数字段补齐的位数
+const naturalSortDigits = 10
+
+// NaturalSortKey 生成自然排序键：将字符串中的每段数字补零到固定位数，
+// 使按字典序排序时 A-9 排在 A-10 之前。结果最长 maxLen 字节
+func NaturalSortKey(s string, maxLen int) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] < '0' || s[i] > '9' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		digits := strings.TrimLeft(s[i:j], "0")
+		if len(digits) < naturalSortDigits {
+			b.WriteString(strings.Repeat("0", naturalSortDigits-len(digits)))
+		}
+		b.WriteString(digits)
+		i = j
+	}
+	key := b.String()
+	if maxLen > 0 && len(key) > maxLen {
+		key = key[:maxLen]
+		for len(key) > 0 && !utf8.ValidString(key) {
+			key = key[:len(key)-1]
+		}
+	}
+	return key
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestNaturalSortKey(t *testing.T) {
+	serials := []string{"A-10", "B-1", "A-9", "A-009-2", "A-100", "A-9-10", "A-9-2"}
+	sort.Slice(serials, func(i, j int) bool {
+		return NaturalSortKey(serials[i], 0) < NaturalSortKey(serials[j], 0)
+	})
+	want := []string{"A-9", "A-009-2", "A-9-2", "A-9-10", "A-10", "A-100", "B-1"}
+	for i := range want {
+		if serials[i] != want[i] {
+			t.Fatalf("自然排序结果 = %v, want %v", serials, want)
+		}
+	}
+
+	if got := NaturalSortKey("图纸12", 5); got != "图" {
+		t.Errorf("NaturalSortKey() 截断后 = %q, want %q", got, "图")
+	}
+}
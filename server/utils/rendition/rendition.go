@@ -0,0 +1,97 @@
+package rendition
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/disintegration/imaging"
+)
+
+// 输出格式
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+// Spec 衍生图规格，按最长边等比缩放，原图更小时不放大
+type Spec struct {
+	Name    string // 规格名称
+	MaxEdge int    // 最长边像素
+}
+
+// Specs 默认生成的衍生图规格
+var Specs = []Spec{
+	{Name: "thumb", MaxEdge: 320},
+	{Name: "medium", MaxEdge: 800},
+	{Name: "large", MaxEdge: 1600},
+}
+
+// Formats 默认生成的衍生图格式
+var Formats = []string{FormatJPEG, FormatPNG}
+
+// jpegQuality 衍生图JPEG质量
+const jpegQuality = 85
+
+// Output 单个衍生图
+type Output struct {
+	Size   string // 规格名称
+	Format string // 输出格式
+	Width  int    // 宽度
+	Height int    // 高度
+	Data   []byte // 图片数据
+}
+
+// Ext 输出格式对应的文件扩展名
+func Ext(format string) string {
+	if format == FormatPNG {
+		return ".png"
+	}
+	return ".jpg"
+}
+
+// Generate 读取原图并生成全部规格与格式的衍生图
+func Generate(r io.Reader) ([]Output, error) {
+	src, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]Output, 0, len(Specs)*len(Formats))
+	for _, spec := range Specs {
+		resized := imaging.Fit(src, spec.MaxEdge, spec.MaxEdge, imaging.Lanczos)
+		for _, format := range Formats {
+			data, err := Encode(resized, format, jpegQuality)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, Output{
+				Size:   spec.Name,
+				Format: format,
+				Width:  resized.Bounds().Dx(),
+				Height: resized.Bounds().Dy(),
+				Data:   data,
+			})
+		}
+	}
+	return outputs, nil
+}
+
+// Encode 按指定格式编码图片，JPEG 会将透明背景填充为白色
+func Encode(img image.Image, format string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if format == FormatPNG {
+		err = png.Encode(&buf, img)
+	} else {
+		flat := imaging.New(img.Bounds().Dx(), img.Bounds().Dy(), image.White.C)
+		flat = imaging.Overlay(flat, img, image.Point{}, 1.0)
+		err = jpeg.Encode(&buf, flat, &jpeg.Options{Quality: quality})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,45 @@
+package rendition
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 1000, 500))
+	for y := 0; y < 500; y++ {
+		for x := 0; x < 1000; x++ {
+			src.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := Generate(&buf)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(outputs) != len(Specs)*len(Formats) {
+		t.Fatalf("Generate() got %d outputs, want %d", len(outputs), len(Specs)*len(Formats))
+	}
+
+	want := map[string][2]int{
+		"thumb":  {320, 160},
+		"medium": {800, 400},
+		"large":  {1000, 500}, // 原图小于规格时不放大
+	}
+	for _, out := range outputs {
+		size := want[out.Size]
+		if out.Width != size[0] || out.Height != size[1] {
+			t.Errorf("%s/%s = %dx%d, want %dx%d", out.Size, out.Format, out.Width, out.Height, size[0], size[1])
+		}
+		if len(out.Data) == 0 {
+			t.Errorf("%s/%s empty data", out.Size, out.Format)
+		}
+	}
+}
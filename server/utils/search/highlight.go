@@ -0,0 +1,91 @@
+package search
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const ellipsis = "…"
+
+// Highlight 用 pre/post 包裹文本中命中查询词项的部分，其余文本做 HTML 转义；
+// maxRunes > 0 且文本较长时只截取首个命中附近的片段。没有命中时返回空字符串
+func Highlight(text string, terms []string, pre, post string, maxRunes int) string {
+	wanted := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		wanted[term] = true
+	}
+
+	// 收集命中区间并合并重叠或相邻的区间（如相邻的二元组）
+	var spans [][2]int
+	for _, token := range indexTokens(text) {
+		if wanted[token.Term] {
+			spans = append(spans, [2]int{token.Start, token.End})
+		}
+	}
+	if len(spans) == 0 {
+		return ""
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span[0] <= last[1] {
+			if span[1] > last[1] {
+				last[1] = span[1]
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+
+	from, to := window(text, merged[0][0], maxRunes)
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString(ellipsis)
+	}
+	pos := from
+	for _, span := range merged {
+		start, end := max(span[0], from), min(span[1], to)
+		if start >= end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:start]))
+		b.WriteString(pre)
+		b.WriteString(html.EscapeString(text[start:end]))
+		b.WriteString(post)
+		pos = end
+	}
+	b.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		b.WriteString(ellipsis)
+	}
+	return b.String()
+}
+
+// window 计算包含首个命中位置、最多 maxRunes 个字符的截取范围（字节偏移），命中位置尽量靠前但保留少量上文
+func window(text string, hitStart int, maxRunes int) (int, int) {
+	total := utf8.RuneCountInString(text)
+	if maxRunes <= 0 || total <= maxRunes {
+		return 0, len(text)
+	}
+	hitRune := utf8.RuneCountInString(text[:hitStart])
+	startRune := max(hitRune-maxRunes/4, 0)
+	endRune := min(startRune+maxRunes, total)
+	startRune = max(endRune-maxRunes, 0)
+
+	from, to := len(text), len(text)
+	i := 0
+	for offset := range text {
+		if i == startRune {
+			from = offset
+		}
+		if i == endRune {
+			to = offset
+			break
+		}
+		i++
+	}
+	return from, to
+}
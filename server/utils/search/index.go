@@ -0,0 +1,179 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Document 待索引文档
+type Document struct {
+	ID     string            // 文档ID，同一索引内唯一，如 drawing:12
+	Fields map[string]string // 字段名 -> 文本
+}
+
+// Hit 检索命中的文档及相关度得分
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+type indexedDoc struct {
+	terms  []string // 文档包含的词项，删除时用于清理倒排表
+	length float64  // 按字段权重加权后的词数
+}
+
+// Index 内存倒排索引，并发安全
+// 词频按字段权重加权，检索时要求命中全部查询词项，按 BM25 计算相关度
+type Index struct {
+	mu       sync.RWMutex
+	boosts   map[string]float64
+	postings map[string]map[string]float64 // 词项 -> 文档ID -> 加权词频
+	docs     map[string]*indexedDoc
+	totalLen float64
+}
+
+// NewIndex 创建索引，boosts 为字段权重，未配置的字段权重为1
+func NewIndex(boosts map[string]float64) *Index {
+	return &Index{
+		boosts:   boosts,
+		postings: make(map[string]map[string]float64),
+		docs:     make(map[string]*indexedDoc),
+	}
+}
+
+func (idx *Index) boost(field string) float64 {
+	if b, ok := idx.boosts[field]; ok {
+		return b
+	}
+	return 1
+}
+
+// Put 写入文档，已存在的同ID文档会被替换
+func (idx *Index) Put(doc Document) {
+	freqs := make(map[string]float64)
+	var length float64
+	for field, text := range doc.Fields {
+		boost := idx.boost(field)
+		for _, token := range indexTokens(text) {
+			freqs[token.Term] += boost
+			length += boost
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(doc.ID)
+	if len(freqs) == 0 {
+		return
+	}
+	entry := &indexedDoc{terms: make([]string, 0, len(freqs)), length: length}
+	for term, freq := range freqs {
+		postings := idx.postings[term]
+		if postings == nil {
+			postings = make(map[string]float64)
+			idx.postings[term] = postings
+		}
+		postings[doc.ID] = freq
+		entry.terms = append(entry.terms, term)
+	}
+	idx.docs[doc.ID] = entry
+	idx.totalLen += length
+}
+
+// Delete 删除文档
+func (idx *Index) Delete(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(id)
+}
+
+func (idx *Index) remove(id string) {
+	entry, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range entry.terms {
+		postings := idx.postings[term]
+		delete(postings, id)
+		if len(postings) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLen -= entry.length
+	delete(idx.docs, id)
+}
+
+// Reset 用给定文档整体替换索引内容
+func (idx *Index) Reset(docs []Document) {
+	fresh := NewIndex(idx.boosts)
+	for _, doc := range docs {
+		fresh.Put(doc)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.postings, idx.docs, idx.totalLen = fresh.postings, fresh.docs, fresh.totalLen
+}
+
+// Len 索引中的文档数
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Search 检索同时包含全部词项的文档，按相关度倒序（相同得分按ID升序）
+func (idx *Index) Search(terms []string) []Hit {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	lists := make([]map[string]float64, 0, len(terms))
+	for _, term := range terms {
+		postings := idx.postings[term]
+		if len(postings) == 0 {
+			return nil
+		}
+		lists = append(lists, postings)
+	}
+	// 从最短的倒排表开始求交集
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+
+	n := float64(len(idx.docs))
+	avgLen := idx.totalLen / n
+	var hits []Hit
+	for id := range lists[0] {
+		var score float64
+		matched := true
+		for _, postings := range lists {
+			freq, ok := postings[id]
+			if !ok {
+				matched = false
+				break
+			}
+			df := float64(len(postings))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := bm25K1 * (1 - bm25B + bm25B*idx.docs[id].length/avgLen)
+			score += idf * freq * (bm25K1 + 1) / (freq + norm)
+		}
+		if matched {
+			hits = append(hits, Hit{ID: id, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	return hits
+}
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTerms_Bigram(t *testing.T) {
+	got := Terms("星空猫咪 Perler-Beads 2024，猫")
+	want := []string{"星空", "空猫", "猫咪", "perler", "beads", "2024", "猫"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Terms() = %v, want %v", got, want)
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := NewIndex(map[string]float64{"name": 3})
+	idx.Put(Document{ID: "drawing:1", Fields: map[string]string{"name": "星空猫咪", "album": "动物"}})
+	idx.Put(Document{ID: "drawing:2", Fields: map[string]string{"name": "小狗", "album": "星空系列"}})
+	idx.Put(Document{ID: "drawing:3", Fields: map[string]string{"name": "海边日落"}})
+
+	hits := idx.Search(Terms("星空"))
+	if len(hits) != 2 || hits[0].ID != "drawing:1" {
+		t.Fatalf("Search(星空) = %v, want drawing:1 ranked first of 2", hits)
+	}
+	// 单字查询命中二元组中的字
+	if hits := idx.Search(Terms("猫")); len(hits) != 1 || hits[0].ID != "drawing:1" {
+		t.Fatalf("Search(猫) = %v", hits)
+	}
+	// 多个词项要求全部命中
+	if hits := idx.Search(Terms("星空 小狗")); len(hits) != 1 || hits[0].ID != "drawing:2" {
+		t.Fatalf("Search(星空 小狗) = %v", hits)
+	}
+
+	idx.Put(Document{ID: "drawing:1", Fields: map[string]string{"name": "月亮"}})
+	idx.Delete("drawing:2")
+	if hits := idx.Search(Terms("星空")); len(hits) != 0 {
+		t.Fatalf("Search(星空) after update = %v, want none", hits)
+	}
+	if idx.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", idx.Len())
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	got := Highlight("<b>星空猫咪</b> 图纸", Terms("星空猫"), "<em>", "</em>", 0)
+	want := "&lt;b&gt;<em>星空猫</em>咪&lt;/b&gt; 图纸"
+	if got != want {
+		t.Fatalf("Highlight() = %q, want %q", got, want)
+	}
+
+	got = Highlight("一二三四五六七八九十星空", Terms("星空"), "[", "]", 8)
+	if want := "…五六七八九十[星空]"; got != want {
+		t.Fatalf("Highlight() window = %q, want %q", got, want)
+	}
+
+	if got := Highlight("海边日落", Terms("星空"), "<em>", "</em>", 0); got != "" {
+		t.Fatalf("Highlight() without match = %q, want empty", got)
+	}
+}
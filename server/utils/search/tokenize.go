@@ -0,0 +1,108 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token 分词结果，Start/End 为词在原文中的字节偏移
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// isCJK 中日韩文字按二元切分，其余字母数字按单词切分
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Tokenize 查询分词：连续的中日韩文字切分为相邻二元组（单字时保留单字），
+// 其余连续字母数字作为一个小写单词，标点与空白作为分隔
+func Tokenize(text string) []Token {
+	return tokenize(text, false)
+}
+
+// indexTokens 索引分词：在 Tokenize 的基础上额外输出每个中日韩单字，使单字查询也能命中
+func indexTokens(text string) []Token {
+	return tokenize(text, true)
+}
+
+func tokenize(text string, unigrams bool) []Token {
+	var tokens []Token
+	type runePos struct {
+		r     rune
+		start int
+		end   int
+	}
+	var run []runePos
+	flushCJK := func() {
+		if len(run) == 1 {
+			tokens = append(tokens, Token{Term: string(run[0].r), Start: run[0].start, End: run[0].end})
+		} else {
+			for i := 0; i+1 < len(run); i++ {
+				tokens = append(tokens, Token{Term: string([]rune{run[i].r, run[i+1].r}), Start: run[i].start, End: run[i+1].end})
+			}
+			if unigrams {
+				for _, p := range run {
+					tokens = append(tokens, Token{Term: string(p.r), Start: p.start, End: p.end})
+				}
+			}
+		}
+		run = run[:0]
+	}
+
+	wordStart := -1
+	flushWord := func(end int) {
+		if wordStart >= 0 {
+			tokens = append(tokens, Token{Term: strings.ToLower(text[wordStart:end]), Start: wordStart, End: end})
+			wordStart = -1
+		}
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isCJK(r):
+			flushWord(i)
+			run = append(run, runePos{r: r, start: i, end: i + size})
+		case isWordRune(r):
+			if len(run) > 0 {
+				flushCJK()
+			}
+			if wordStart < 0 {
+				wordStart = i
+			}
+		default:
+			flushWord(i)
+			if len(run) > 0 {
+				flushCJK()
+			}
+		}
+		i += size
+	}
+	flushWord(len(text))
+	if len(run) > 0 {
+		flushCJK()
+	}
+	return tokens
+}
+
+// Terms 查询分词后的去重词项
+func Terms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, token := range Tokenize(text) {
+		if !seen[token.Term] {
+			seen[token.Term] = true
+			terms = append(terms, token.Term)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,29 @@
+package upload
+
+import (
+	"bytes"
+	"mime/multipart"
+)
+
+// NewFileHeader 将内存中的文件内容包装为 multipart.FileHeader，
+// 以便服务端生成的文件（如衍生图）也能通过 OSS.UploadFile 写入任意存储
+func NewFileHeader(filename string, data []byte) (*multipart.FileHeader, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = part.Write(data); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(int64(len(data)) + 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	return form.File["file"][0], nil
+}
@@ -0,0 +1,76 @@
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// maxOpenFileSize 读取远程对象的大小上限
+const maxOpenFileSize = 32 << 20
+
+var openHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ObjectURL 根据当前存储类型将 UploadFile 返回的 key 转换为访问地址
+// 本地存储返回文件路径，其余存储返回对象的公网 URL
+func ObjectURL(key string) string {
+	cfg := global.GVA_CONFIG
+	switch cfg.System.OssType {
+	case "qiniu":
+		return cfg.Qiniu.ImgPath + "/" + key
+	case "tencent-cos":
+		return cfg.TencentCOS.BaseURL + "/" + cfg.TencentCOS.PathPrefix + "/" + key
+	case "aliyun-oss":
+		return cfg.AliyunOSS.BucketUrl + "/" + key
+	case "huawei-obs":
+		return cfg.HuaWeiObs.Path + "/" + key
+	case "aws-s3":
+		return cfg.AwsS3.BaseURL + "/" + cfg.AwsS3.PathPrefix + "/" + key
+	case "cloudflare-r2":
+		return cfg.CloudflareR2.BaseURL + "/" + cfg.CloudflareR2.Path + "/" + key
+	case "minio":
+		return cfg.Minio.BucketUrl + "/" + key
+	default:
+		return filepath.Join(cfg.Local.StorePath, key)
+	}
+}
+
+// OpenFile 按 key 从当前存储中读取文件内容
+func OpenFile(key string) (io.ReadCloser, error) {
+	key = strings.TrimPrefix(key, "/")
+	if key == "" {
+		return nil, errors.New("key不能为空")
+	}
+	// 禁止访问存储路径之外的文件
+	if strings.Contains(key, "..") || strings.ContainsAny(key, `\:*?"<>|`) {
+		return nil, errors.New("非法的key")
+	}
+
+	u := ObjectURL(key)
+	if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		return os.Open(u)
+	}
+
+	resp, err := openHTTPClient.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, os.ErrNotExist
+		}
+		return nil, fmt.Errorf("读取文件失败: %s", resp.Status)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, maxOpenFileSize), resp.Body}, nil
+}
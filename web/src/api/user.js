@@ -200,13 +200,14 @@ export const getUserDetail = (id) => {
 // @Security ApiKeyAuth
 // @accept application/json
 // @Produce application/json
-// @Param data body {id: "number"} true "获取用户图纸列表"
-// @Success 200 {string} json "{"success":true,"data":{},"msg":"获取成功"}"
-// @Router /user/getUserDrawings [get]
-export const getUserDrawings = (id) => {
+// @Param data query {page: "number", pageSize: "number", sort: "string", sortOrder: "string", albumId: "number", keyword: "string", neverDownloaded: "boolean"} true "分页、排序与筛选参数"
+// @Success 200 {string} json "{"success":true,"data":{"list":[],"total":0},"msg":"获取成功"}"
+// @Router /user/getUserDrawings/{id} [get]
+export const getUserDrawings = (id, params) => {
   return service({
     url: `/user/getUserDrawings/${id}`,
-    method: 'get'
+    method: 'get',
+    params
   })
 }
 